@@ -2,7 +2,6 @@ package binance
 
 import (
 	"context"
-	"time"
 
 	"github.com/souloss/quantds/clients/binance"
 	"github.com/souloss/quantds/domain"
@@ -62,12 +61,12 @@ func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Re
 		return kline.Response{}, trace, err
 	}
 
-	// Calculate limit based on date range
+	// Calculate limit based on the number of bars in the date range
 	limit := 500
 	if !req.StartTime.IsZero() && !req.EndTime.IsZero() {
-		days := int(req.EndTime.Sub(req.StartTime).Hours() / 24)
-		if days > 0 && days < 1000 {
-			limit = days + 1
+		bars := int(req.EndTime.Sub(req.StartTime)/req.Timeframe.Duration()) + 1
+		if bars > 0 {
+			limit = min(bars, binance.MaxKlineLimit)
 		}
 	}
 
@@ -107,28 +106,4 @@ func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Re
 	}, trace, nil
 }
 
-// inferIntervalFromTimeframe infers K-line interval from timeframe
-func inferIntervalFromTimeframe(tf kline.Timeframe) time.Duration {
-	switch tf {
-	case kline.Timeframe1m:
-		return time.Minute
-	case kline.Timeframe5m:
-		return 5 * time.Minute
-	case kline.Timeframe15m:
-		return 15 * time.Minute
-	case kline.Timeframe30m:
-		return 30 * time.Minute
-	case kline.Timeframe60m:
-		return time.Hour
-	case kline.Timeframe1d, "":
-		return 24 * time.Hour
-	case kline.Timeframe1w:
-		return 7 * 24 * time.Hour
-	case kline.Timeframe1M:
-		return 30 * 24 * time.Hour
-	default:
-		return 24 * time.Hour
-	}
-}
-
 var _ manager.Provider[kline.Request, kline.Response] = (*KlineAdapter)(nil)
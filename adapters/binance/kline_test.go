@@ -5,7 +5,6 @@ import (
 
 	"github.com/souloss/quantds/clients/binance"
 	"github.com/souloss/quantds/domain"
-	"github.com/souloss/quantds/domain/kline"
 )
 
 func TestNewKlineAdapter(t *testing.T) {
@@ -115,27 +114,3 @@ func TestSpotAdapter_CanHandle(t *testing.T) {
 		})
 	}
 }
-
-func TestInferIntervalFromTimeframe(t *testing.T) {
-	tests := []struct {
-		timeframe kline.Timeframe
-	}{
-		{kline.Timeframe1m},
-		{kline.Timeframe5m},
-		{kline.Timeframe15m},
-		{kline.Timeframe30m},
-		{kline.Timeframe60m},
-		{kline.Timeframe1d},
-		{kline.Timeframe1w},
-		{kline.Timeframe1M},
-	}
-
-	for _, tt := range tests {
-		t.Run(string(tt.timeframe), func(t *testing.T) {
-			result := inferIntervalFromTimeframe(tt.timeframe)
-			if result <= 0 {
-				t.Errorf("inferIntervalFromTimeframe(%s) returned non-positive duration", tt.timeframe)
-			}
-		})
-	}
-}
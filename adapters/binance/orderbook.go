@@ -0,0 +1,90 @@
+package binance
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/clients/binance"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/orderbook"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// OrderBookAdapter adapts Binance order book depth
+type OrderBookAdapter struct {
+	client *binance.Client
+}
+
+// NewOrderBookAdapter creates a new order book adapter
+func NewOrderBookAdapter(client *binance.Client) *OrderBookAdapter {
+	return &OrderBookAdapter{client: client}
+}
+
+// Name returns the adapter name
+func (a *OrderBookAdapter) Name() string {
+	return Name
+}
+
+// SupportedMarkets returns supported markets
+func (a *OrderBookAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+// CanHandle checks if the adapter can handle the symbol
+func (a *OrderBookAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return binance.IsCryptoSymbol(symbol)
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch retrieves an order book snapshot
+func (a *OrderBookAdapter) Fetch(ctx context.Context, _ request.Client, req orderbook.Request) (orderbook.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	symbol, err := binance.ToBinanceSymbol(req.Symbol)
+	if err != nil {
+		trace.Finish()
+		return orderbook.Response{}, trace, err
+	}
+
+	result, record, err := a.client.GetDepth(ctx, &binance.DepthParams{
+		Symbol: symbol,
+		Limit:  req.Depth,
+	})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return orderbook.Response{}, trace, err
+	}
+
+	// The depth endpoint carries no timestamp; use the time it was received
+	trace.Finish()
+	return orderbook.Response{
+		Book: orderbook.NewBook(
+			req.Symbol,
+			toLevels(result.Bids),
+			toLevels(result.Asks),
+			time.Now(),
+			req.Depth,
+		),
+		Source: Name,
+	}, trace, nil
+}
+
+func toLevels(levels []binance.DepthLevel) []orderbook.Level {
+	out := make([]orderbook.Level, 0, len(levels))
+	for _, l := range levels {
+		out = append(out, orderbook.Level{Price: l.Price, Volume: l.Quantity})
+	}
+	return out
+}
+
+var _ manager.Provider[orderbook.Request, orderbook.Response] = (*OrderBookAdapter)(nil)
@@ -0,0 +1,46 @@
+package binance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/souloss/quantds/clients/binance"
+	"github.com/souloss/quantds/domain/orderbook"
+	"github.com/souloss/quantds/request"
+)
+
+// depthClient serves a fixed order book.
+type depthClient struct{}
+
+func (depthClient) Do(_ context.Context, _ request.Request) (request.Response, *request.Record, error) {
+	body := `{"lastUpdateId":1,"bids":[["100.5","2"]],"asks":[["100.6","1"]]}`
+	return request.Response{StatusCode: 200, Body: []byte(body)}, &request.Record{}, nil
+}
+
+func (depthClient) Close() {}
+
+func TestOrderBookAdapter_Fetch(t *testing.T) {
+	adapter := NewOrderBookAdapter(binance.NewClient(binance.WithHTTPClient(depthClient{})))
+
+	resp, trace, err := adapter.Fetch(context.Background(), nil, orderbook.Request{Symbol: "BTC-USDT", Depth: 5})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if resp.Book.Symbol != "BTC-USDT" {
+		t.Errorf("Symbol = %s, want the requested BTC-USDT", resp.Book.Symbol)
+	}
+	if len(resp.Book.Bids) != 1 || resp.Book.Bids[0].Price != 100.5 {
+		t.Errorf("Bids = %+v", resp.Book.Bids)
+	}
+	if trace.TotalTime == 0 {
+		t.Error("trace should be finished")
+	}
+
+	_, trace, err = adapter.Fetch(context.Background(), nil, orderbook.Request{Symbol: "600519.SH"})
+	if err == nil {
+		t.Fatal("Fetch() should fail for a non-crypto symbol")
+	}
+	if trace.TotalTime == 0 {
+		t.Error("trace should be finished on error")
+	}
+}
@@ -0,0 +1,113 @@
+package coingecko
+
+import (
+	"context"
+	"strings"
+
+	"github.com/souloss/quantds/clients/coingecko"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/instrument"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// InstrumentAdapter adapts the CoinGecko coin list to instruments
+type InstrumentAdapter struct {
+	client *coingecko.Client
+}
+
+// NewInstrumentAdapter creates a new instrument adapter
+func NewInstrumentAdapter(client *coingecko.Client) *InstrumentAdapter {
+	return &InstrumentAdapter{client: client}
+}
+
+// Name returns the adapter name
+func (a *InstrumentAdapter) Name() string {
+	return Name
+}
+
+// SupportedMarkets returns supported markets
+func (a *InstrumentAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+// CanHandle checks if the adapter can handle the symbol
+func (a *InstrumentAdapter) CanHandle(symbol string) bool {
+	return canHandle(symbol)
+}
+
+// Fetch retrieves the coin list from CoinGecko as BTCUSDT-style pairs.
+// Market may name the quote asset (e.g. "USDC"), USDT is used otherwise.
+func (a *InstrumentAdapter) Fetch(ctx context.Context, _ request.Client, req instrument.Request) (instrument.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	result, record, err := a.client.GetCoinsList(ctx, &coingecko.CoinsListRequest{})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return instrument.Response{}, trace, err
+	}
+
+	quote := strings.ToUpper(req.Market)
+	if vsCurrency(quote) == "" {
+		quote = defaultQuote
+	}
+	instruments := coinInstruments(result, quote)
+
+	// Apply pagination if requested
+	total := len(instruments)
+	if req.PageSize > 0 {
+		start := 0
+		if req.PageNumber > 1 {
+			start = (req.PageNumber - 1) * req.PageSize
+		}
+		end := start + req.PageSize
+		if start > total {
+			instruments = nil
+		} else {
+			if end > total {
+				end = total
+			}
+			instruments = instruments[start:end]
+		}
+	}
+
+	trace.Finish()
+	return instrument.Response{
+		Data:       instruments,
+		Total:      total,
+		Source:     Name,
+		PageNumber: req.PageNumber,
+		PageSize:   req.PageSize,
+	}, trace, nil
+}
+
+// coinInstruments maps coins to base+quote instruments. Tickers are shared by
+// many coins on CoinGecko; each ticker keeps its pinned coin from
+// knownCoinIDs, otherwise the first coin listed.
+func coinInstruments(coins coingecko.CoinsListResponse, quote string) []instrument.Instrument {
+	seen := make(map[string]bool, len(coins))
+	instruments := make([]instrument.Instrument, 0, len(coins))
+	for _, c := range coins {
+		base := strings.ToUpper(c.Symbol)
+		if base == "" || base == quote || seen[base] {
+			continue
+		}
+		if id, ok := knownCoinIDs[base]; ok && id != c.ID {
+			continue
+		}
+		seen[base] = true
+		instruments = append(instruments, instrument.Instrument{
+			Symbol:    base + quote,
+			Code:      c.ID,
+			Name:      c.Name,
+			Market:    "SPOT",
+			AssetType: instrument.AssetTypeStock,
+			Currency:  quote,
+			Status:    instrument.StatusNormal,
+		})
+	}
+	return instruments
+}
+
+var _ manager.Provider[instrument.Request, instrument.Response] = (*InstrumentAdapter)(nil)
@@ -0,0 +1,39 @@
+package coingecko
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/coingecko"
+)
+
+func TestNewInstrumentAdapter(t *testing.T) {
+	adapter := NewInstrumentAdapter(coingecko.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewInstrumentAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestCoinInstruments(t *testing.T) {
+	coins := coingecko.CoinsListResponse{
+		{ID: "batcat", Symbol: "btc", Name: "BatCat"},
+		{ID: "bitcoin", Symbol: "btc", Name: "Bitcoin"},
+		{ID: "pepe", Symbol: "pepe", Name: "Pepe"},
+		{ID: "pepe-2", Symbol: "pepe", Name: "Pepe 2"},
+		{ID: "tether", Symbol: "usdt", Name: "Tether"},
+	}
+
+	got := coinInstruments(coins, "USDT")
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2: %+v", len(got), got)
+	}
+	if got[0].Symbol != "BTCUSDT" || got[0].Code != "bitcoin" {
+		t.Errorf("got[0] = %+v, want the pinned bitcoin coin", got[0])
+	}
+	if got[1].Symbol != "PEPEUSDT" || got[1].Code != "pepe" || got[1].Currency != "USDT" {
+		t.Errorf("got[1] = %+v", got[1])
+	}
+}
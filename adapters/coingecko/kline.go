@@ -0,0 +1,229 @@
+package coingecko
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/souloss/quantds/clients/coingecko"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// Adapter name
+const Name = "coingecko"
+
+// Supported markets for CoinGecko adapter
+var supportedMarkets = []domain.Market{domain.MarketCrypto}
+
+// KlineAdapter builds K-lines from CoinGecko market charts
+type KlineAdapter struct {
+	client *coingecko.Client
+	coins  *coinResolver
+	now    func() time.Time
+}
+
+// NewKlineAdapter creates a new K-line adapter
+func NewKlineAdapter(client *coingecko.Client) *KlineAdapter {
+	return &KlineAdapter{client: client, coins: newCoinResolver(client), now: time.Now}
+}
+
+// Name returns the adapter name
+func (a *KlineAdapter) Name() string {
+	return Name
+}
+
+// SupportedMarkets returns supported markets
+func (a *KlineAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+// CanHandle checks if the adapter can handle the symbol
+func (a *KlineAdapter) CanHandle(symbol string) bool {
+	return canHandle(symbol)
+}
+
+// Fetch retrieves K-line data from CoinGecko.
+// market_chart returns price samples whose spacing depends on the range: 5
+// minutes for one day, hourly up to 90 days and daily beyond. Bars are built
+// by grouping the samples by timeframe, so timeframes finer than the sample
+// spacing are rejected. CoinGecko only reports rolling 24h volumes in the
+// quote currency: bars of a day or longer carry them as Turnover, Volume is
+// always zero.
+func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	p, err := a.coins.resolve(ctx, req.Symbol, trace)
+	if err != nil {
+		trace.Finish()
+		return kline.Response{}, trace, err
+	}
+
+	tf := req.Timeframe
+	if tf == "" {
+		tf = kline.Timeframe1d
+	}
+	days := chartDays(tf, req.StartTime, a.now())
+	if timeframeDuration(tf) < sampleSpacing(days) {
+		trace.Finish()
+		return kline.Response{}, trace, fmt.Errorf("coingecko: %s bars are not available over %s days", tf, days)
+	}
+
+	result, record, err := a.client.GetMarketChart(ctx, &coingecko.MarketChartRequest{
+		ID:         p.coinID,
+		VsCurrency: p.vs,
+		Days:       days,
+	})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return kline.Response{}, trace, err
+	}
+
+	bars := buildBars(result.Prices, result.TotalVolumes, tf)
+	bars = filterBars(bars, tf, req.StartTime, req.EndTime)
+
+	trace.Finish()
+	return kline.Response{
+		Symbol: req.Symbol,
+		Bars:   bars,
+		Source: Name,
+	}, trace, nil
+}
+
+// chartDays returns the market_chart days parameter covering start, or a
+// default range for the timeframe when start is not set.
+func chartDays(tf kline.Timeframe, start, now time.Time) string {
+	if start.IsZero() {
+		switch tf {
+		case kline.Timeframe1m, kline.Timeframe5m, kline.Timeframe15m, kline.Timeframe30m:
+			return "1"
+		case kline.Timeframe60m:
+			return "30"
+		default:
+			// "max" is not available on the public API, which serves at
+			// most 365 days of history
+			return "365"
+		}
+	}
+	days := int(math.Ceil(now.Sub(start).Hours() / 24))
+	if days < 1 {
+		days = 1
+	}
+	return strconv.Itoa(days)
+}
+
+// sampleSpacing returns the spacing of market_chart samples for days.
+func sampleSpacing(days string) time.Duration {
+	n, err := strconv.Atoi(days)
+	switch {
+	case err != nil || n > 90:
+		return 24 * time.Hour
+	case n > 1:
+		return time.Hour
+	default:
+		return 5 * time.Minute
+	}
+}
+
+// timeframeDuration returns the nominal length of a timeframe
+func timeframeDuration(tf kline.Timeframe) time.Duration {
+	switch tf {
+	case kline.Timeframe1m:
+		return time.Minute
+	case kline.Timeframe5m:
+		return 5 * time.Minute
+	case kline.Timeframe15m:
+		return 15 * time.Minute
+	case kline.Timeframe30m:
+		return 30 * time.Minute
+	case kline.Timeframe60m:
+		return time.Hour
+	case kline.Timeframe1w:
+		return 7 * 24 * time.Hour
+	case kline.Timeframe1M:
+		return 30 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// bucketStart returns the start (UTC) of the bar containing t.
+func bucketStart(t time.Time, tf kline.Timeframe) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch tf {
+	case kline.Timeframe1M:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case kline.Timeframe1w:
+		// Weeks start on Monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case kline.Timeframe1d:
+		return day
+	default:
+		return t.Truncate(timeframeDuration(tf))
+	}
+}
+
+// buildBars groups [timestamp ms, value] price samples into bars. For bars of
+// a day or longer, Turnover sums the last 24h volume sample of each day.
+func buildBars(prices, volumes [][]float64, tf kline.Timeframe) []kline.Bar {
+	var bars []kline.Bar
+	for _, sample := range prices {
+		if len(sample) < 2 {
+			continue
+		}
+		ts, price := time.UnixMilli(int64(sample[0])), sample[1]
+		start := bucketStart(ts, tf)
+		if n := len(bars); n > 0 && bars[n-1].Timestamp.Equal(start) {
+			b := &bars[n-1]
+			b.High = max(b.High, price)
+			b.Low = min(b.Low, price)
+			b.Close = price
+			continue
+		}
+		bars = append(bars, kline.Bar{Timestamp: start, Open: price, High: price, Low: price, Close: price})
+	}
+
+	if timeframeDuration(tf) < 24*time.Hour || len(bars) == 0 {
+		return bars
+	}
+	daily := make(map[time.Time]float64)
+	for _, sample := range volumes {
+		if len(sample) < 2 {
+			continue
+		}
+		daily[bucketStart(time.UnixMilli(int64(sample[0])), kline.Timeframe1d)] = sample[1]
+	}
+	index := make(map[time.Time]int, len(bars))
+	for i, b := range bars {
+		index[b.Timestamp] = i
+	}
+	for day, vol := range daily {
+		if i, ok := index[bucketStart(day, tf)]; ok {
+			bars[i].Turnover += vol
+		}
+	}
+	return bars
+}
+
+// filterBars keeps the bars overlapping [start, end]; zero bounds are open.
+func filterBars(bars []kline.Bar, tf kline.Timeframe, start, end time.Time) []kline.Bar {
+	out := bars[:0]
+	for _, b := range bars {
+		if !start.IsZero() && b.Timestamp.Before(bucketStart(start, tf)) {
+			continue
+		}
+		if !end.IsZero() && b.Timestamp.After(end) {
+			continue
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+var _ manager.Provider[kline.Request, kline.Response] = (*KlineAdapter)(nil)
@@ -0,0 +1,142 @@
+package coingecko
+
+import (
+	"testing"
+	"time"
+
+	"github.com/souloss/quantds/clients/coingecko"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/kline"
+)
+
+func TestNewKlineAdapter(t *testing.T) {
+	adapter := NewKlineAdapter(coingecko.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewKlineAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+	markets := adapter.SupportedMarkets()
+	if len(markets) != 1 || markets[0] != domain.MarketCrypto {
+		t.Errorf("Expected supported markets [%s], got %v", domain.MarketCrypto, markets)
+	}
+}
+
+func TestKlineAdapter_CanHandle(t *testing.T) {
+	adapter := NewKlineAdapter(coingecko.NewClient())
+
+	tests := []struct {
+		symbol    string
+		canHandle bool
+	}{
+		{"BTCUSDT", true},
+		{"SOLUSDT", true},
+		{"ETH-BTC", true},
+		{"BTCUSDT.CRYPTO.BINANCE", true},
+		{"BTCXYZ", false},
+		{"000001.SZ", false},
+		{"AAPL.US", false},
+	}
+
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.canHandle {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.canHandle)
+		}
+	}
+}
+
+func TestSplitSymbol(t *testing.T) {
+	tests := []struct {
+		symbol, base, quote string
+	}{
+		{"BTCUSDT", "BTC", "USDT"},
+		{"ethbtc", "ETH", "BTC"},
+		{"SOL-USDC", "SOL", "USDC"},
+		{"BTCFDUSD", "BTC", "FDUSD"},
+		{"DOGEUSDT.CRYPTO.OKX", "DOGE", "USDT"},
+	}
+	for _, tt := range tests {
+		base, quote, ok := splitSymbol(tt.symbol)
+		if !ok || base != tt.base || quote != tt.quote {
+			t.Errorf("splitSymbol(%s) = %s, %s, %v", tt.symbol, base, quote, ok)
+		}
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	coins := []coingecko.SearchCoin{
+		{ID: "pepe-unranked", Symbol: "PEPE"},
+		{ID: "pepe-wrapped", Symbol: "PEPE", MarketCapRank: 900},
+		{ID: "pepe", Symbol: "pepe", MarketCapRank: 30},
+		{ID: "pepecoin", Symbol: "PEPECOIN", MarketCapRank: 10},
+	}
+	if got := bestMatch(coins, "PEPE"); got != "pepe" {
+		t.Errorf("bestMatch() = %s, want pepe", got)
+	}
+	if got := bestMatch(coins, "NOPE"); got != "" {
+		t.Errorf("bestMatch() = %s, want no match", got)
+	}
+}
+
+func TestChartDays(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		tf      kline.Timeframe
+		start   time.Time
+		days    string
+		spacing time.Duration
+	}{
+		{kline.Timeframe5m, time.Time{}, "1", 5 * time.Minute},
+		{kline.Timeframe60m, time.Time{}, "30", time.Hour},
+		{kline.Timeframe1d, time.Time{}, "365", 24 * time.Hour},
+		{kline.Timeframe1w, time.Time{}, "365", 24 * time.Hour},
+		{kline.Timeframe1d, now.AddDate(0, 0, -10), "10", time.Hour},
+	}
+	for _, tt := range tests {
+		days := chartDays(tt.tf, tt.start, now)
+		if days != tt.days || sampleSpacing(days) != tt.spacing {
+			t.Errorf("chartDays(%s) = %s (spacing %v), want %s (%v)", tt.tf, days, sampleSpacing(days), tt.days, tt.spacing)
+		}
+	}
+}
+
+func TestBuildBars(t *testing.T) {
+	ms := func(day, hour int) float64 {
+		return float64(time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC).UnixMilli())
+	}
+	prices := [][]float64{
+		{ms(3, 0), 100}, {ms(3, 8), 120}, {ms(3, 16), 90}, {ms(3, 23), 110},
+		{ms(4, 0), 111}, {ms(4, 12), 105},
+	}
+	volumes := [][]float64{
+		{ms(3, 0), 1}, {ms(3, 23), 1000},
+		{ms(4, 12), 2000},
+	}
+
+	daily := buildBars(prices, volumes, kline.Timeframe1d)
+	if len(daily) != 2 {
+		t.Fatalf("len(daily) = %d, want 2", len(daily))
+	}
+	b := daily[0]
+	if b.Open != 100 || b.High != 120 || b.Low != 90 || b.Close != 110 || b.Turnover != 1000 {
+		t.Errorf("daily[0] = %+v", b)
+	}
+
+	// 2025-03-03 is a Monday: both days fall into one weekly bar
+	weekly := buildBars(prices, volumes, kline.Timeframe1w)
+	if len(weekly) != 1 || weekly[0].Close != 105 || weekly[0].Turnover != 3000 {
+		t.Errorf("weekly = %+v", weekly)
+	}
+
+	hourly := buildBars(prices, volumes, kline.Timeframe60m)
+	if len(hourly) != 6 || hourly[0].Turnover != 0 {
+		t.Errorf("hourly = %+v", hourly)
+	}
+
+	filtered := filterBars(daily, kline.Timeframe1d, time.Date(2025, time.March, 4, 9, 0, 0, 0, time.UTC), time.Time{})
+	if len(filtered) != 1 || filtered[0].Close != 105 {
+		t.Errorf("filterBars() = %+v", filtered)
+	}
+}
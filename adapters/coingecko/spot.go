@@ -0,0 +1,128 @@
+package coingecko
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/souloss/quantds/clients/coingecko"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/spot"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// SpotAdapter adapts CoinGecko simple prices to domain spot
+type SpotAdapter struct {
+	client *coingecko.Client
+	coins  *coinResolver
+}
+
+// NewSpotAdapter creates a new spot adapter
+func NewSpotAdapter(client *coingecko.Client) *SpotAdapter {
+	return &SpotAdapter{client: client, coins: newCoinResolver(client)}
+}
+
+// Name returns the adapter name
+func (a *SpotAdapter) Name() string {
+	return Name
+}
+
+// SupportedMarkets returns supported markets
+func (a *SpotAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+// CanHandle checks if the adapter can handle the symbol
+func (a *SpotAdapter) CanHandle(symbol string) bool {
+	return canHandle(symbol)
+}
+
+// Fetch retrieves real-time prices from CoinGecko, one request per quote
+// currency. CoinGecko only reports the price, its 24h change and the 24h
+// volume in the quote currency.
+func (a *SpotAdapter) Fetch(ctx context.Context, _ request.Client, req spot.Request) (spot.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	// Group coin IDs by vs_currency, keeping the requested symbol of each pair
+	var lastErr error
+	symbols := make([]string, 0, len(req.Symbols))
+	pairs := make([]pair, 0, len(req.Symbols))
+	idsByVs := make(map[string][]string)
+	for _, s := range req.Symbols {
+		p, err := a.coins.resolve(ctx, s, trace)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		symbols = append(symbols, s)
+		pairs = append(pairs, p)
+		idsByVs[p.vs] = append(idsByVs[p.vs], p.coinID)
+	}
+
+	prices := make(map[string]coingecko.SimplePriceResponse, len(idsByVs))
+	for vs, ids := range idsByVs {
+		result, record, err := a.client.GetSimplePrice(ctx, &coingecko.SimplePriceRequest{
+			IDs:                  ids,
+			VsCurrencies:         []string{vs},
+			Include24hrVol:       true,
+			Include24hrChange:    true,
+			IncludeLastUpdatedAt: true,
+		})
+		trace.AddRequest(record)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		prices[vs] = result
+	}
+
+	quotes := make([]spot.Quote, 0, len(pairs))
+	for i, p := range pairs {
+		if q, ok := toQuote(symbols[i], p, prices[p.vs][p.coinID]); ok {
+			quotes = append(quotes, q)
+		}
+	}
+
+	trace.Finish()
+	if len(quotes) == 0 {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("coingecko: no price for %s: %w", strings.Join(req.Symbols, ","), request.ErrNoData)
+		}
+		return spot.Response{}, trace, lastErr
+	}
+	return spot.Response{
+		Quotes: quotes,
+		Total:  len(quotes),
+		Source: Name,
+	}, trace, nil
+}
+
+// toQuote converts the simple price fields of a coin to a quote of symbol.
+func toQuote(symbol string, p pair, fields map[string]float64) (spot.Quote, bool) {
+	latest, ok := fields[p.vs]
+	if !ok {
+		return spot.Quote{}, false
+	}
+	changeRate := fields[p.vs+"_24h_change"]
+	preClose := latest
+	if changeRate > -100 {
+		preClose = latest / (1 + changeRate/100)
+	}
+	quote := spot.Quote{
+		Symbol:     symbol,
+		Name:       p.coinID,
+		Latest:     latest,
+		PreClose:   preClose,
+		Change:     latest - preClose,
+		ChangeRate: changeRate,
+		Turnover:   fields[p.vs+"_24h_vol"],
+	}
+	if ts := fields["last_updated_at"]; ts > 0 {
+		quote.Timestamp = time.Unix(int64(ts), 0)
+	}
+	return quote, true
+}
+
+var _ manager.Provider[spot.Request, spot.Response] = (*SpotAdapter)(nil)
@@ -0,0 +1,61 @@
+package coingecko
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/souloss/quantds/clients/coingecko"
+	"github.com/souloss/quantds/domain/spot"
+)
+
+func TestNewSpotAdapter(t *testing.T) {
+	adapter := NewSpotAdapter(coingecko.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewSpotAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+	if !adapter.CanHandle("BTCUSDT") || adapter.CanHandle("600519.SH") {
+		t.Error("CanHandle() should accept crypto pairs only")
+	}
+}
+
+func TestToQuote(t *testing.T) {
+	p := pair{base: "BTC", quote: "USDT", coinID: "bitcoin", vs: "usd"}
+	fields := map[string]float64{
+		"usd":             110,
+		"usd_24h_change":  10,
+		"usd_24h_vol":     5e9,
+		"last_updated_at": 1741000000,
+	}
+
+	q, ok := toQuote("BTC-USDT", p, fields)
+	if !ok {
+		t.Fatal("toQuote() returned no quote")
+	}
+	if q.Symbol != "BTC-USDT" || q.Name != "bitcoin" || q.Latest != 110 || q.Turnover != 5e9 {
+		t.Errorf("quote = %+v", q)
+	}
+	if math.Abs(q.PreClose-100) > 1e-9 || math.Abs(q.Change-10) > 1e-9 {
+		t.Errorf("PreClose = %v, Change = %v, want 100 and 10", q.PreClose, q.Change)
+	}
+	if q.Timestamp.Unix() != 1741000000 {
+		t.Errorf("Timestamp = %v", q.Timestamp)
+	}
+
+	if _, ok := toQuote("BTCUSDT", p, map[string]float64{"eur": 1}); ok {
+		t.Error("toQuote() should skip coins without a price in the vs currency")
+	}
+}
+
+func TestSpotAdapter_FetchInvalidSymbol(t *testing.T) {
+	adapter := NewSpotAdapter(coingecko.NewClient())
+
+	_, _, err := adapter.Fetch(context.Background(), nil, spot.Request{Symbols: []string{"600519.SH"}})
+	if err == nil {
+		t.Error("Fetch() should return the resolve error when no quote is produced")
+	}
+}
@@ -0,0 +1,172 @@
+package coingecko
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/souloss/quantds/clients/coingecko"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/manager"
+)
+
+// defaultQuote is the quote asset of symbols built from CoinGecko coin IDs.
+const defaultQuote = "USDT"
+
+// knownCoinIDs maps base assets to CoinGecko coin IDs. Many coins share a
+// ticker on CoinGecko, so the IDs of the major ones are pinned here.
+var knownCoinIDs = map[string]string{
+	"BTC":   "bitcoin",
+	"ETH":   "ethereum",
+	"BNB":   "binancecoin",
+	"SOL":   "solana",
+	"XRP":   "ripple",
+	"ADA":   "cardano",
+	"DOGE":  "dogecoin",
+	"DOT":   "polkadot",
+	"TRX":   "tron",
+	"TON":   "the-open-network",
+	"AVAX":  "avalanche-2",
+	"LINK":  "chainlink",
+	"MATIC": "matic-network",
+	"LTC":   "litecoin",
+	"BCH":   "bitcoin-cash",
+	"SHIB":  "shiba-inu",
+	"UNI":   "uniswap",
+	"ATOM":  "cosmos",
+	"USDT":  "tether",
+	"USDC":  "usd-coin",
+}
+
+// quoteAssets lists the supported quote assets, longest suffix first, with
+// the CoinGecko vs_currency they are priced in. Stablecoins are priced in usd.
+var quoteAssets = []struct {
+	asset string
+	vs    string
+}{
+	{"FDUSD", "usd"},
+	{"USDT", "usd"},
+	{"USDC", "usd"},
+	{"BUSD", "usd"},
+	{"USD", "usd"},
+	{"BTC", "btc"},
+	{"ETH", "eth"},
+	{"BNB", "bnb"},
+	{"EUR", "eur"},
+}
+
+// pair is a BTCUSDT-style symbol resolved against CoinGecko.
+type pair struct {
+	base   string
+	quote  string
+	coinID string
+	vs     string
+}
+
+// splitSymbol splits a BTCUSDT-style symbol (also BTC-USDT and
+// BTCUSDT.CRYPTO.BINANCE) into base and quote assets.
+func splitSymbol(symbol string) (base, quote string, ok bool) {
+	code := strings.ToUpper(strings.TrimSpace(symbol))
+	if parts := strings.Split(code, "."); len(parts) > 1 {
+		if len(parts) != 3 || domain.Market(parts[1]) != domain.MarketCrypto {
+			return "", "", false
+		}
+		code = parts[0]
+	}
+	code = strings.NewReplacer("-", "", "/", "", "_", "").Replace(code)
+	for _, q := range quoteAssets {
+		if len(code) > len(q.asset) && strings.HasSuffix(code, q.asset) {
+			return code[:len(code)-len(q.asset)], q.asset, true
+		}
+	}
+	return "", "", false
+}
+
+// vsCurrency returns the CoinGecko vs_currency of a quote asset.
+func vsCurrency(quote string) string {
+	for _, q := range quoteAssets {
+		if q.asset == quote {
+			return q.vs
+		}
+	}
+	return ""
+}
+
+// canHandle reports whether symbol is a crypto pair with a supported quote.
+func canHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err == nil && sym.Market != domain.MarketCrypto {
+		return false
+	}
+	_, _, ok := splitSymbol(symbol)
+	return ok
+}
+
+// coinResolver maps base assets to CoinGecko coin IDs, searching CoinGecko
+// for assets missing from knownCoinIDs and caching the results.
+type coinResolver struct {
+	client *coingecko.Client
+
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+func newCoinResolver(client *coingecko.Client) *coinResolver {
+	return &coinResolver{client: client, ids: make(map[string]string)}
+}
+
+// resolve returns the pair of symbol, recording any search in trace.
+func (r *coinResolver) resolve(ctx context.Context, symbol string, trace *manager.RequestTrace) (pair, error) {
+	base, quote, ok := splitSymbol(symbol)
+	if !ok {
+		return pair{}, fmt.Errorf("coingecko: invalid symbol %s", symbol)
+	}
+	id, err := r.coinID(ctx, base, trace)
+	if err != nil {
+		return pair{}, err
+	}
+	return pair{base: base, quote: quote, coinID: id, vs: vsCurrency(quote)}, nil
+}
+
+func (r *coinResolver) coinID(ctx context.Context, base string, trace *manager.RequestTrace) (string, error) {
+	if id, ok := knownCoinIDs[base]; ok {
+		return id, nil
+	}
+	r.mu.Lock()
+	id, ok := r.ids[base]
+	r.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	result, record, err := r.client.Search(ctx, &coingecko.SearchRequest{Query: base})
+	trace.AddRequest(record)
+	if err != nil {
+		return "", err
+	}
+	id = bestMatch(result.Coins, base)
+	if id == "" {
+		return "", fmt.Errorf("coingecko: no coin found for %s", base)
+	}
+
+	r.mu.Lock()
+	r.ids[base] = id
+	r.mu.Unlock()
+	return id, nil
+}
+
+// bestMatch returns the ID of the best ranked coin whose ticker is base.
+func bestMatch(coins []coingecko.SearchCoin, base string) string {
+	best, bestRank := "", 0
+	for _, c := range coins {
+		if !strings.EqualFold(c.Symbol, base) {
+			continue
+		}
+		// Unranked coins (rank 0) only win when nothing else matches
+		if best == "" || (c.MarketCapRank > 0 && (bestRank == 0 || c.MarketCapRank < bestRank)) {
+			best, bestRank = c.ID, c.MarketCapRank
+		}
+	}
+	return best
+}
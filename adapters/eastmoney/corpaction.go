@@ -0,0 +1,153 @@
+package eastmoney
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/corpaction"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// CorpActionAdapter adapts Eastmoney dividend and bonus share plans and
+// rights issues. The source publishes no pay date, so PayDate is left zero.
+type CorpActionAdapter struct {
+	client *eastmoney.Client
+}
+
+// NewCorpActionAdapter creates a new corporate action adapter
+func NewCorpActionAdapter(client *eastmoney.Client) *CorpActionAdapter {
+	return &CorpActionAdapter{client: client}
+}
+
+func (a *CorpActionAdapter) Name() string {
+	return Name
+}
+
+func (a *CorpActionAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+func (a *CorpActionAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *CorpActionAdapter) Fetch(ctx context.Context, _ request.Client, req corpaction.Request) (corpaction.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	items, record, err := a.client.GetDividends(ctx, &eastmoney.DividendParams{
+		Symbol:   req.Symbol,
+		PageSize: 100,
+	})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return corpaction.Response{}, trace, err
+	}
+
+	rights, record, err := a.client.GetRightsIssues(ctx, &eastmoney.RightsIssueParams{
+		Symbol:   req.Symbol,
+		PageSize: 100,
+	})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return corpaction.Response{}, trace, err
+	}
+
+	actions := append(dividendActions(req.Symbol, items), rightsActions(req.Symbol, rights)...)
+	trace.Finish()
+	return corpaction.Response{
+		Symbol:  req.Symbol,
+		Actions: req.Filter(actions),
+		Source:  Name,
+	}, trace, nil
+}
+
+// dividendActions splits each plan with an ex-date into cash dividend, stock
+// dividend and transfer actions. Plans that have not reached the ex-date
+// stage carry no ex-date and are skipped.
+func dividendActions(symbol string, items []eastmoney.DividendItem) []corpaction.Action {
+	var actions []corpaction.Action
+	for _, item := range items {
+		exDate := parseDateTime(item.ExDate)
+		if exDate.IsZero() {
+			continue
+		}
+		base := corpaction.Action{
+			Symbol:     symbol,
+			AnnDate:    parseDateTime(item.NoticeDate),
+			RecordDate: parseDateTime(item.RecordDate),
+			ExDate:     exDate,
+			Progress:   item.Progress,
+		}
+
+		// Eastmoney quotes everything per 10 shares
+		if item.PretaxBonusRMB > 0 {
+			action := base
+			action.Type = corpaction.ActionCashDividend
+			action.Cash = item.PretaxBonusRMB / 10
+			actions = append(actions, action)
+		}
+		stockDiv := item.BonusRatio
+		if stockDiv == 0 && item.ITRatio == 0 {
+			stockDiv = item.BonusITRatio
+		}
+		if stockDiv > 0 {
+			action := base
+			action.Type = corpaction.ActionStockDividend
+			action.Ratio = stockDiv / 10
+			actions = append(actions, action)
+		}
+		if item.ITRatio > 0 {
+			action := base
+			action.Type = corpaction.ActionTransfer
+			action.Ratio = item.ITRatio / 10
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// rightsActions converts the rights issues with an ex-date to rights actions.
+func rightsActions(symbol string, items []eastmoney.RightsIssueItem) []corpaction.Action {
+	var actions []corpaction.Action
+	for _, item := range items {
+		exDate := parseDateTime(item.ExDate)
+		if exDate.IsZero() || item.PlacingRatio <= 0 {
+			continue
+		}
+		actions = append(actions, corpaction.Action{
+			Symbol:     symbol,
+			Type:       corpaction.ActionRights,
+			AnnDate:    parseDateTime(item.NoticeDate),
+			RecordDate: parseDateTime(item.RecordDate),
+			ExDate:     exDate,
+			Ratio:      item.PlacingRatio / 10,
+			Price:      item.IssuePrice,
+		})
+	}
+	return actions
+}
+
+// parseDateTime parses the date part of a datacenter timestamp such as
+// "2024-06-13 00:00:00".
+func parseDateTime(s string) time.Time {
+	if len(s) < 10 {
+		return time.Time{}
+	}
+	return eastmoney.ParseDate(s[:10])
+}
+
+var _ manager.Provider[corpaction.Request, corpaction.Response] = (*CorpActionAdapter)(nil)
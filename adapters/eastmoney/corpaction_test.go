@@ -0,0 +1,79 @@
+package eastmoney
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+	"github.com/souloss/quantds/domain/corpaction"
+)
+
+func TestNewCorpActionAdapter(t *testing.T) {
+	adapter := NewCorpActionAdapter(eastmoney.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewCorpActionAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestCorpActionAdapter_CanHandle(t *testing.T) {
+	adapter := NewCorpActionAdapter(eastmoney.NewClient())
+
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"000001.SZ", true},
+		{"600519.SH", true},
+		{"AAPL", false},
+		{"BTCUSDT", false},
+	}
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestDividendActions(t *testing.T) {
+	items := []eastmoney.DividendItem{
+		{ExDate: "", PretaxBonusRMB: 300, Progress: "董事会预案"},
+		{ExDate: "2024-06-13 00:00:00", RecordDate: "2024-06-12 00:00:00", PretaxBonusRMB: 300,
+			BonusRatio: 2, ITRatio: 3, BonusITRatio: 5, Progress: "实施分配"},
+	}
+
+	actions := dividendActions("600519.SH", items)
+	if len(actions) != 3 {
+		t.Fatalf("expected 3 actions, got %d: %+v", len(actions), actions)
+	}
+	if actions[0].Type != corpaction.ActionCashDividend || actions[0].Cash != 30 {
+		t.Errorf("unexpected cash action: %+v", actions[0])
+	}
+	if actions[0].ExDate.Format("2006-01-02") != "2024-06-13" {
+		t.Errorf("unexpected ex-date: %v", actions[0].ExDate)
+	}
+	if actions[1].Type != corpaction.ActionStockDividend || actions[1].Ratio != 0.2 {
+		t.Errorf("unexpected stock dividend: %+v", actions[1])
+	}
+	if actions[2].Type != corpaction.ActionTransfer || actions[2].Ratio != 0.3 {
+		t.Errorf("unexpected transfer: %+v", actions[2])
+	}
+}
+
+func TestRightsActions(t *testing.T) {
+	items := []eastmoney.RightsIssueItem{
+		{ExDate: "", PlacingRatio: 3, IssuePrice: 4.5},
+		{ExDate: "2024-06-13 00:00:00", RecordDate: "2024-06-12 00:00:00", PlacingRatio: 3, IssuePrice: 4.5},
+	}
+
+	actions := rightsActions("600519.SH", items)
+	if len(actions) != 1 {
+		t.Fatalf("expected 1 action, got %d: %+v", len(actions), actions)
+	}
+	a := actions[0]
+	if a.Type != corpaction.ActionRights || a.Ratio != 0.3 || a.Price != 4.5 || a.ShareMultiplier() != 1.3 {
+		t.Errorf("unexpected rights action: %+v", a)
+	}
+}
@@ -0,0 +1,96 @@
+package eastmoney
+
+import (
+	"context"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/index"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// indexExchanges are the exchanges whose index series EastMoney lists
+var indexExchanges = []domain.Exchange{domain.ExchangeSH, domain.ExchangeSZ}
+
+// IndexListAdapter adapts the EastMoney index series to domain index
+type IndexListAdapter struct {
+	client *eastmoney.Client
+}
+
+// NewIndexListAdapter creates a new index list adapter
+func NewIndexListAdapter(client *eastmoney.Client) *IndexListAdapter {
+	return &IndexListAdapter{client: client}
+}
+
+func (a *IndexListAdapter) Name() string {
+	return Name
+}
+
+func (a *IndexListAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+func (a *IndexListAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	return sym.AssetType == domain.AssetTypeIndex && (sym.Exchange == domain.ExchangeSH || sym.Exchange == domain.ExchangeSZ)
+}
+
+func (a *IndexListAdapter) Fetch(ctx context.Context, _ request.Client, req index.ListRequest) (index.ListResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var indexes []index.Index
+	for _, ex := range indexExchanges {
+		if req.Exchange != "" && req.Exchange != ex {
+			continue
+		}
+		for page := 1; page <= maxSectorPages; page++ {
+			result, record, err := a.client.GetIndexList(ctx, &eastmoney.IndexListParams{
+				Exchange: string(ex),
+				PageSize: sectorPageSize,
+				PageNo:   page,
+			})
+			trace.AddRequest(record)
+			if err != nil {
+				trace.Finish()
+				return index.ListResponse{}, trace, err
+			}
+			indexes = append(indexes, quoteIndexes(result.Data, ex)...)
+			if len(result.Data) < sectorPageSize || page*sectorPageSize >= result.Total {
+				break
+			}
+		}
+	}
+	page, total := req.Filter(indexes)
+
+	trace.Finish()
+	return index.ListResponse{
+		Indexes:    page,
+		Total:      total,
+		Source:     Name,
+		PageNumber: req.PageNumber,
+		PageSize:   req.PageSize,
+	}, trace, nil
+}
+
+// quoteIndexes converts the quote rows of an exchange's index series, keeping
+// only codes that parse as indexes of that exchange.
+func quoteIndexes(rows []eastmoney.QuoteData, ex domain.Exchange) []index.Index {
+	indexes := make([]index.Index, 0, len(rows))
+	for _, row := range rows {
+		if !domain.IsCNIndex(row.Code, ex) {
+			continue
+		}
+		indexes = append(indexes, index.Index{
+			Symbol:   domain.FormatSymbol(row.Code, ex),
+			Name:     row.Name,
+			Exchange: ex,
+		})
+	}
+	return indexes
+}
+
+var _ manager.Provider[index.ListRequest, index.ListResponse] = (*IndexListAdapter)(nil)
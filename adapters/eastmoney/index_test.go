@@ -0,0 +1,29 @@
+package eastmoney
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+	"github.com/souloss/quantds/domain"
+)
+
+func TestIndexListAdapter_CanHandle(t *testing.T) {
+	adapter := NewIndexListAdapter(eastmoney.NewClient())
+
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+	if !adapter.CanHandle("000300.SH") || !adapter.CanHandle("399006.SZ") || adapter.CanHandle("000300.SZ") {
+		t.Error("CanHandle() should accept SH and SZ indexes only")
+	}
+}
+
+func TestQuoteIndexes(t *testing.T) {
+	indexes := quoteIndexes([]eastmoney.QuoteData{
+		{Code: "000300", Name: "沪深300"},
+		{Code: "600519", Name: "贵州茅台"},
+	}, domain.ExchangeSH)
+	if len(indexes) != 1 || indexes[0].Symbol != "000300.SH" || indexes[0].Exchange != domain.ExchangeSH {
+		t.Errorf("quoteIndexes() = %+v", indexes)
+	}
+}
@@ -0,0 +1,111 @@
+package eastmoney
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/moneyflow"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// defaultMoneyFlowDays is the number of daily flows fetched when the request
+// sets neither a limit nor a start date.
+const defaultMoneyFlowDays = 100
+
+// MoneyFlowAdapter adapts Eastmoney money flow data
+type MoneyFlowAdapter struct {
+	client *eastmoney.Client
+}
+
+// NewMoneyFlowAdapter creates a new money flow adapter
+func NewMoneyFlowAdapter(client *eastmoney.Client) *MoneyFlowAdapter {
+	return &MoneyFlowAdapter{client: client}
+}
+
+func (a *MoneyFlowAdapter) Name() string {
+	return Name
+}
+
+func (a *MoneyFlowAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+func (a *MoneyFlowAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *MoneyFlowAdapter) Fetch(ctx context.Context, _ request.Client, req moneyflow.Request) (moneyflow.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var flows []moneyflow.Flow
+	if req.Period == moneyflow.PeriodDaily {
+		items, record, err := a.client.GetMoneyFlowHistory(ctx, &eastmoney.MoneyFlowHistoryParams{
+			Symbol: req.Symbol,
+			Limit:  req.Days(defaultMoneyFlowDays),
+		})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return moneyflow.Response{}, trace, err
+		}
+		for _, item := range items {
+			flows = append(flows, moneyflow.Flow{
+				Date:                eastmoney.ParseDate(item.Date),
+				MainNetInflow:       item.MainNetInflow,
+				SuperLargeNetInflow: item.SuperNetInflow,
+				LargeNetInflow:      item.LargeNetInflow,
+				MediumNetInflow:     item.MediumNetInflow,
+				SmallNetInflow:      item.SmallNetInflow,
+				MainNetRatio:        item.MainNetInflowRatio,
+				SuperLargeNetRatio:  item.SuperNetRatio,
+				LargeNetRatio:       item.LargeNetRatio,
+				MediumNetRatio:      item.MediumNetRatio,
+				SmallNetRatio:       item.SmallNetRatio,
+				Close:               item.ClosePrice,
+				ChangeRate:          item.ChangePercent,
+			})
+		}
+		flows = req.Filter(flows)
+	} else {
+		data, record, err := a.client.GetMoneyFlow(ctx, &eastmoney.MoneyFlowParams{Symbol: req.Symbol})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return moneyflow.Response{}, trace, err
+		}
+		now := time.Now().In(timeLoc)
+		flows = append(flows, moneyflow.Flow{
+			Date:                time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, timeLoc),
+			MainNetInflow:       data.MainNetInflow,
+			SuperLargeNetInflow: data.SuperNetInflow,
+			LargeNetInflow:      data.LargeNetInflow,
+			MediumNetInflow:     data.MediumNetInflow,
+			SmallNetInflow:      data.SmallNetInflow,
+			MainNetRatio:        data.MainNetInflowRatio,
+		})
+	}
+
+	trace.Finish()
+	return moneyflow.Response{
+		Symbol: req.Symbol,
+		Period: req.Period,
+		Flows:  flows,
+		Source: Name,
+	}, trace, nil
+}
+
+var timeLoc, _ = time.LoadLocation("Asia/Shanghai")
+
+var _ manager.Provider[moneyflow.Request, moneyflow.Response] = (*MoneyFlowAdapter)(nil)
@@ -0,0 +1,38 @@
+package eastmoney
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+)
+
+func TestNewMoneyFlowAdapter(t *testing.T) {
+	client := eastmoney.NewClient()
+	adapter := NewMoneyFlowAdapter(client)
+
+	if adapter == nil {
+		t.Fatal("NewMoneyFlowAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestMoneyFlowAdapter_CanHandle(t *testing.T) {
+	adapter := NewMoneyFlowAdapter(eastmoney.NewClient())
+
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"000001.SZ", true},
+		{"600519.SH", true},
+		{"AAPL", false},
+		{"BTCUSDT", false},
+	}
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package eastmoney
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/sector"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+const (
+	// sectorPageSize is the largest page the board list endpoint returns.
+	sectorPageSize = 100
+	// maxSectorPages bounds the pages fetched for one board or constituent list.
+	maxSectorPages = 50
+	// boardKindsTTL is how long the board code to kind mapping is kept.
+	boardKindsTTL = 24 * time.Hour
+)
+
+// boardTypes maps sector kinds to EastMoney board types.
+var boardTypes = map[sector.Kind]string{
+	sector.KindIndustry: eastmoney.BoardTypeIndustry,
+	sector.KindConcept:  eastmoney.BoardTypeConcept,
+	sector.KindRegion:   eastmoney.BoardTypeRegion,
+}
+
+// isBoardCode reports whether code is an EastMoney board code such as BK0477.
+func isBoardCode(code string) bool {
+	return len(code) > 2 && strings.HasPrefix(code, "BK")
+}
+
+// listBoards fetches all boards of kind, recording the requests in trace. The
+// pages are requested by code so that they do not overlap while prices move;
+// the boards are returned by descending change rate.
+func listBoards(ctx context.Context, client *eastmoney.Client, kind sector.Kind, trace *manager.RequestTrace) ([]sector.Sector, error) {
+	boardType, ok := boardTypes[kind]
+	if !ok {
+		return nil, fmt.Errorf("eastmoney: unsupported sector kind %q", kind)
+	}
+	var sectors []sector.Sector
+	for page := 1; page <= maxSectorPages; page++ {
+		items, record, err := client.GetConceptList(ctx, &eastmoney.ConceptListParams{
+			BoardType: boardType,
+			SortBy:    eastmoney.SortByCode,
+			PageSize:  sectorPageSize,
+			PageNo:    page,
+		})
+		trace.AddRequest(record)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			sectors = append(sectors, toSector(item, kind))
+		}
+		if len(items) < sectorPageSize {
+			break
+		}
+	}
+	sort.SliceStable(sectors, func(i, j int) bool {
+		return sectors[i].ChangeRate > sectors[j].ChangeRate
+	})
+	return sectors, nil
+}
+
+func toSector(item eastmoney.ConceptItem, kind sector.Kind) sector.Sector {
+	return sector.Sector{
+		Code:          item.Code,
+		Name:          item.Name,
+		Kind:          kind,
+		ChangeRate:    item.ChangePercent,
+		MainNetInflow: item.MainNetInflow,
+		MarketCap:     item.TotalMarketCap,
+	}
+}
+
+// SectorListAdapter adapts EastMoney board lists to domain sector
+type SectorListAdapter struct {
+	client *eastmoney.Client
+}
+
+// NewSectorListAdapter creates a new sector list adapter
+func NewSectorListAdapter(client *eastmoney.Client) *SectorListAdapter {
+	return &SectorListAdapter{client: client}
+}
+
+func (a *SectorListAdapter) Name() string {
+	return Name
+}
+
+func (a *SectorListAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+func (a *SectorListAdapter) CanHandle(code string) bool {
+	return isBoardCode(code)
+}
+
+func (a *SectorListAdapter) Fetch(ctx context.Context, _ request.Client, req sector.ListRequest) (sector.ListResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var sectors []sector.Sector
+	for _, kind := range req.Kinds() {
+		boards, err := listBoards(ctx, a.client, kind, trace)
+		if err != nil {
+			trace.Finish()
+			return sector.ListResponse{}, trace, err
+		}
+		sectors = append(sectors, boards...)
+	}
+	page, total := req.Paginate(sectors)
+
+	trace.Finish()
+	return sector.ListResponse{
+		Sectors:    page,
+		Total:      total,
+		Source:     Name,
+		PageNumber: req.PageNumber,
+		PageSize:   req.PageSize,
+	}, trace, nil
+}
+
+// SectorConstituentsAdapter adapts EastMoney board members to domain sector
+type SectorConstituentsAdapter struct {
+	client *eastmoney.Client
+}
+
+// NewSectorConstituentsAdapter creates a new sector constituents adapter
+func NewSectorConstituentsAdapter(client *eastmoney.Client) *SectorConstituentsAdapter {
+	return &SectorConstituentsAdapter{client: client}
+}
+
+func (a *SectorConstituentsAdapter) Name() string {
+	return Name
+}
+
+func (a *SectorConstituentsAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+// CanHandle accepts EastMoney board codes.
+func (a *SectorConstituentsAdapter) CanHandle(code string) bool {
+	return isBoardCode(code)
+}
+
+// Fetch pages through the board members by code and returns them by
+// descending change rate.
+func (a *SectorConstituentsAdapter) Fetch(ctx context.Context, _ request.Client, req sector.ConstituentsRequest) (sector.ConstituentsResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var constituents []sector.Constituent
+	for page := 1; page <= maxSectorPages; page++ {
+		items, record, err := a.client.GetConceptStocks(ctx, &eastmoney.ConceptStocksParams{
+			ConceptCode: req.Code,
+			SortBy:      eastmoney.SortByCode,
+			PageSize:    sectorPageSize,
+			PageNo:      page,
+		})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return sector.ConstituentsResponse{}, trace, err
+		}
+		for _, item := range items {
+			constituents = append(constituents, sector.Constituent{
+				Symbol:     quoteSymbol(item.Code, item.MarketID),
+				Name:       item.Name,
+				Price:      item.Latest,
+				ChangeRate: item.ChangeRate,
+			})
+		}
+		if len(items) < sectorPageSize {
+			break
+		}
+	}
+	sort.SliceStable(constituents, func(i, j int) bool {
+		return constituents[i].ChangeRate > constituents[j].ChangeRate
+	})
+
+	trace.Finish()
+	return sector.ConstituentsResponse{
+		Code:         req.Code,
+		Constituents: constituents,
+		Source:       Name,
+	}, trace, nil
+}
+
+// quoteSymbol builds the symbol of a quote row. Market 1 is Shanghai; market
+// 0 covers both Shenzhen and Beijing, told apart by the code.
+func quoteSymbol(code string, marketID int) string {
+	switch {
+	case marketID == 1:
+		return code + ".SH"
+	case strings.HasPrefix(code, "8"), strings.HasPrefix(code, "4"), strings.HasPrefix(code, "92"):
+		return code + ".BJ"
+	}
+	return code + ".SZ"
+}
+
+// SectorMembershipAdapter adapts the EastMoney boards of a stock to domain
+// sector. The endpoint does not report board kinds, so they are looked up in
+// the board lists, which are cached for boardKindsTTL.
+type SectorMembershipAdapter struct {
+	client *eastmoney.Client
+
+	mu        sync.Mutex
+	kinds     map[string]sector.Kind
+	expiresAt time.Time
+}
+
+// NewSectorMembershipAdapter creates a new sector membership adapter
+func NewSectorMembershipAdapter(client *eastmoney.Client) *SectorMembershipAdapter {
+	return &SectorMembershipAdapter{client: client}
+}
+
+func (a *SectorMembershipAdapter) Name() string {
+	return Name
+}
+
+func (a *SectorMembershipAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+func (a *SectorMembershipAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *SectorMembershipAdapter) Fetch(ctx context.Context, _ request.Client, req sector.MembershipRequest) (sector.MembershipResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	items, record, err := a.client.GetStockBoards(ctx, req.Symbol)
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return sector.MembershipResponse{}, trace, err
+	}
+
+	kinds, err := a.boardKinds(ctx, trace)
+	if err != nil {
+		trace.Finish()
+		return sector.MembershipResponse{}, trace, err
+	}
+	sectors := make([]sector.Sector, 0, len(items))
+	for _, item := range items {
+		sectors = append(sectors, toSector(item, kinds[item.Code]))
+	}
+
+	trace.Finish()
+	return sector.MembershipResponse{
+		Symbol:  req.Symbol,
+		Sectors: req.Filter(sectors),
+		Source:  Name,
+	}, trace, nil
+}
+
+// boardKinds returns the kind of every board code, refreshing the cached
+// board lists when they are older than boardKindsTTL.
+func (a *SectorMembershipAdapter) boardKinds(ctx context.Context, trace *manager.RequestTrace) (map[string]sector.Kind, error) {
+	a.mu.Lock()
+	kinds, expiresAt := a.kinds, a.expiresAt
+	a.mu.Unlock()
+	if kinds != nil && time.Now().Before(expiresAt) {
+		return kinds, nil
+	}
+
+	kinds = make(map[string]sector.Kind)
+	for _, kind := range []sector.Kind{sector.KindIndustry, sector.KindConcept, sector.KindRegion} {
+		boards, err := listBoards(ctx, a.client, kind, trace)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range boards {
+			kinds[b.Code] = kind
+		}
+	}
+
+	a.mu.Lock()
+	a.kinds, a.expiresAt = kinds, time.Now().Add(boardKindsTTL)
+	a.mu.Unlock()
+	return kinds, nil
+}
+
+var (
+	_ manager.Provider[sector.ListRequest, sector.ListResponse]                 = (*SectorListAdapter)(nil)
+	_ manager.Provider[sector.ConstituentsRequest, sector.ConstituentsResponse] = (*SectorConstituentsAdapter)(nil)
+	_ manager.Provider[sector.MembershipRequest, sector.MembershipResponse]     = (*SectorMembershipAdapter)(nil)
+)
@@ -0,0 +1,98 @@
+package eastmoney
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/souloss/quantds/clients/eastmoney"
+	"github.com/souloss/quantds/domain/sector"
+	"github.com/souloss/quantds/request"
+)
+
+// boardsClient serves one page of boards and records the sort field of
+// each request.
+type boardsClient struct {
+	sortBy []string
+}
+
+func (c *boardsClient) Do(_ context.Context, req request.Request) (request.Response, *request.Record, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return request.Response{}, nil, err
+	}
+	c.sortBy = append(c.sortBy, u.Query().Get("fid"))
+	body := `{"rc":0,"data":{"diff":[{"f12":"BK0001","f14":"A","f3":-1.2},{"f12":"BK0002","f14":"B","f3":2.5}]}}`
+	return request.Response{StatusCode: 200, Body: []byte(body)}, &request.Record{}, nil
+}
+
+func (c *boardsClient) Close() {}
+
+func TestNewSectorAdapters(t *testing.T) {
+	client := eastmoney.NewClient()
+
+	if a := NewSectorListAdapter(client); a.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, a.Name())
+	}
+	if a := NewSectorMembershipAdapter(client); a.Name() != Name || !a.CanHandle("600519.SH") || a.CanHandle("AAPL.US") {
+		t.Error("SectorMembershipAdapter should handle CN symbols only")
+	}
+
+	constituents := NewSectorConstituentsAdapter(client)
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"BK0477", true},
+		{"TS2", false},
+		{"银行", false},
+		{"BK", false},
+	}
+	for _, tt := range tests {
+		if got := constituents.CanHandle(tt.code); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteSymbol(t *testing.T) {
+	tests := []struct {
+		code     string
+		marketID int
+		want     string
+	}{
+		{"600519", 1, "600519.SH"},
+		{"000001", 0, "000001.SZ"},
+		{"300750", 0, "300750.SZ"},
+		{"830799", 0, "830799.BJ"},
+		{"920002", 0, "920002.BJ"},
+	}
+	for _, tt := range tests {
+		if got := quoteSymbol(tt.code, tt.marketID); got != tt.want {
+			t.Errorf("quoteSymbol(%s, %d) = %s, want %s", tt.code, tt.marketID, got, tt.want)
+		}
+	}
+}
+
+func TestToSector(t *testing.T) {
+	s := toSector(eastmoney.ConceptItem{Code: "BK0477", Name: "酿酒行业", ChangePercent: 1.5, MainNetInflow: 1e8}, sector.KindIndustry)
+	if s.Code != "BK0477" || s.Kind != sector.KindIndustry || s.ChangeRate != 1.5 || s.MainNetInflow != 1e8 {
+		t.Errorf("toSector() = %+v", s)
+	}
+}
+
+func TestSectorListAdapter_PagesByCode(t *testing.T) {
+	http := &boardsClient{}
+	adapter := NewSectorListAdapter(eastmoney.NewClient(eastmoney.WithHTTPClient(http)))
+
+	resp, _, err := adapter.Fetch(context.Background(), nil, sector.ListRequest{Kind: sector.KindIndustry})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(http.sortBy) != 1 || http.sortBy[0] != eastmoney.SortByCode {
+		t.Errorf("requests sorted by %v, want %s", http.sortBy, eastmoney.SortByCode)
+	}
+	if len(resp.Sectors) != 2 || resp.Sectors[0].Code != "BK0002" {
+		t.Errorf("sectors = %+v, want descending change rate", resp.Sectors)
+	}
+}
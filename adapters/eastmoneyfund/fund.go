@@ -0,0 +1,274 @@
+package eastmoneyfund
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/souloss/quantds/clients/eastmoneyfund"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/fund"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// Adapter name
+const Name = "eastmoneyfund"
+
+// Supported markets for EastMoney fund adapters
+var supportedMarkets = []domain.Market{domain.MarketCN}
+
+const (
+	// maxNAVPages bounds the pages fetched for one NAV history request.
+	maxNAVPages = 200
+	// fundListTTL is how long the full fund list is kept between pages.
+	fundListTTL = 12 * time.Hour
+	// maxEstimateFetches bounds the estimate requests in flight for one fetch.
+	maxEstimateFetches = 8
+)
+
+var timeLoc, _ = time.LoadLocation("Asia/Shanghai")
+
+// isFundCode reports whether code is a 6-digit fund code.
+func isFundCode(code string) bool {
+	code = fund.NormalizeCode(code)
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FundListAdapter adapts the EastMoney fund list to domain fund. The endpoint
+// only returns the full list, which is cached for fundListTTL so that paging
+// through it does not download it again for every page.
+type FundListAdapter struct {
+	client *eastmoneyfund.Client
+
+	mu        sync.Mutex
+	funds     []fund.Fund
+	expiresAt time.Time
+}
+
+// NewFundListAdapter creates a new fund list adapter
+func NewFundListAdapter(client *eastmoneyfund.Client) *FundListAdapter {
+	return &FundListAdapter{client: client}
+}
+
+func (a *FundListAdapter) Name() string                      { return Name }
+func (a *FundListAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *FundListAdapter) CanHandle(symbol string) bool      { return isFundCode(symbol) }
+
+// Fetch retrieves all funds and filters them locally
+func (a *FundListAdapter) Fetch(ctx context.Context, _ request.Client, req fund.ListRequest) (fund.ListResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	funds, err := a.list(ctx, trace)
+	if err != nil {
+		trace.Finish()
+		return fund.ListResponse{}, trace, err
+	}
+	page, total := req.Filter(funds)
+
+	trace.Finish()
+	return fund.ListResponse{
+		Funds:      page,
+		Total:      total,
+		Source:     Name,
+		PageNumber: req.PageNumber,
+		PageSize:   req.PageSize,
+	}, trace, nil
+}
+
+// list returns all funds, refreshing the cached list when it is older than
+// fundListTTL. The returned slice is shared and must not be modified.
+func (a *FundListAdapter) list(ctx context.Context, trace *manager.RequestTrace) ([]fund.Fund, error) {
+	a.mu.Lock()
+	funds, expiresAt := a.funds, a.expiresAt
+	a.mu.Unlock()
+	if funds != nil && time.Now().Before(expiresAt) {
+		return funds, nil
+	}
+
+	result, record, err := a.client.GetFundList(ctx)
+	trace.AddRequest(record)
+	if err != nil {
+		return nil, err
+	}
+	funds = make([]fund.Fund, 0, len(result.Funds))
+	for _, f := range result.Funds {
+		funds = append(funds, fund.Fund{
+			Code:     f.Code,
+			Name:     f.Name,
+			FullName: f.FullName,
+			Type:     fund.ParseType(f.Type),
+			Category: f.Type,
+		})
+	}
+
+	a.mu.Lock()
+	a.funds, a.expiresAt = funds, time.Now().Add(fundListTTL)
+	a.mu.Unlock()
+	return funds, nil
+}
+
+// FundNAVAdapter adapts the EastMoney NAV history to domain fund
+type FundNAVAdapter struct {
+	client *eastmoneyfund.Client
+}
+
+// NewFundNAVAdapter creates a new fund NAV adapter
+func NewFundNAVAdapter(client *eastmoneyfund.Client) *FundNAVAdapter {
+	return &FundNAVAdapter{client: client}
+}
+
+func (a *FundNAVAdapter) Name() string                      { return Name }
+func (a *FundNAVAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *FundNAVAdapter) CanHandle(symbol string) bool      { return isFundCode(symbol) }
+
+// Fetch retrieves the NAV history page by page
+func (a *FundNAVAdapter) Fetch(ctx context.Context, _ request.Client, req fund.NAVRequest) (fund.NAVResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	params := &eastmoneyfund.FundNAVParams{
+		Code:     fund.NormalizeCode(req.Code),
+		PageSize: eastmoneyfund.MaxNAVPageSize,
+	}
+	if !req.StartTime.IsZero() {
+		params.StartDate = req.StartTime.In(timeLoc).Format("2006-01-02")
+	}
+	if !req.EndTime.IsZero() {
+		params.EndDate = req.EndTime.In(timeLoc).Format("2006-01-02")
+	}
+
+	var items []eastmoneyfund.FundNAVItem
+	for page := 1; page <= maxNAVPages; page++ {
+		params.Page = page
+		result, record, err := a.client.GetFundNAV(ctx, params)
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return fund.NAVResponse{}, trace, err
+		}
+		items = append(items, result.Items...)
+		if page >= result.Pages || len(result.Items) == 0 {
+			break
+		}
+	}
+
+	trace.Finish()
+	return fund.NAVResponse{
+		Code:   params.Code,
+		NAVs:   navs(items),
+		Source: Name,
+	}, trace, nil
+}
+
+// navs converts NAV rows (newest first) to NAVs in ascending date order.
+func navs(items []eastmoneyfund.FundNAVItem) []fund.NAV {
+	out := make([]fund.NAV, 0, len(items))
+	for _, item := range items {
+		date, err := time.ParseInLocation("2006-01-02", item.Date, timeLoc)
+		if err != nil {
+			continue
+		}
+		out = append(out, fund.NAV{
+			Date:       date,
+			UnitNAV:    item.UnitNAV,
+			AccumNAV:   item.AccumNAV,
+			ChangeRate: item.ChangeRate,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Date.Before(out[j].Date)
+	})
+	return out
+}
+
+// FundEstimateAdapter adapts EastMoney intraday NAV estimates to domain fund
+type FundEstimateAdapter struct {
+	client *eastmoneyfund.Client
+}
+
+// NewFundEstimateAdapter creates a new fund estimate adapter
+func NewFundEstimateAdapter(client *eastmoneyfund.Client) *FundEstimateAdapter {
+	return &FundEstimateAdapter{client: client}
+}
+
+func (a *FundEstimateAdapter) Name() string                      { return Name }
+func (a *FundEstimateAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *FundEstimateAdapter) CanHandle(symbol string) bool      { return isFundCode(symbol) }
+
+// Fetch retrieves the estimate of each fund, at most maxEstimateFetches at a
+// time. Funds without an estimate, such as money market funds, are skipped.
+func (a *FundEstimateAdapter) Fetch(ctx context.Context, _ request.Client, req fund.EstimateRequest) (fund.EstimateResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	results := make([]*eastmoneyfund.FundEstimateResult, len(req.Codes))
+	records := make([]*request.Record, len(req.Codes))
+	errs := make([]error, len(req.Codes))
+	sem := make(chan struct{}, maxEstimateFetches)
+	var wg sync.WaitGroup
+	for i, code := range req.Codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], records[i], errs[i] = a.client.GetFundEstimate(ctx, &eastmoneyfund.FundEstimateParams{Code: fund.NormalizeCode(code)})
+		}()
+	}
+	wg.Wait()
+
+	estimates := make([]fund.Estimate, 0, len(req.Codes))
+	var lastErr error
+	for i := range req.Codes {
+		trace.AddRequest(records[i])
+		if errs[i] != nil {
+			lastErr = errs[i]
+			continue
+		}
+		if e, ok := toEstimate(results[i]); ok {
+			estimates = append(estimates, e)
+		}
+	}
+
+	trace.Finish()
+	if len(estimates) == 0 && lastErr != nil {
+		return fund.EstimateResponse{}, trace, lastErr
+	}
+	return fund.EstimateResponse{
+		Estimates: estimates,
+		Source:    Name,
+	}, trace, nil
+}
+
+// toEstimate converts an estimate result; ok is false without an estimate.
+func toEstimate(r *eastmoneyfund.FundEstimateResult) (fund.Estimate, bool) {
+	estNAV, err := strconv.ParseFloat(r.EstNAV, 64)
+	if err != nil {
+		return fund.Estimate{}, false
+	}
+	e := fund.Estimate{
+		Code:   r.Code,
+		Name:   r.Name,
+		EstNAV: estNAV,
+	}
+	e.NAV, _ = strconv.ParseFloat(r.NAV, 64)
+	e.EstChangeRate, _ = strconv.ParseFloat(r.EstChange, 64)
+	e.NAVDate, _ = time.ParseInLocation("2006-01-02", r.NAVDate, timeLoc)
+	e.EstTime, _ = time.ParseInLocation("2006-01-02 15:04", r.EstTime, timeLoc)
+	return e, true
+}
+
+var (
+	_ manager.Provider[fund.ListRequest, fund.ListResponse]         = (*FundListAdapter)(nil)
+	_ manager.Provider[fund.NAVRequest, fund.NAVResponse]           = (*FundNAVAdapter)(nil)
+	_ manager.Provider[fund.EstimateRequest, fund.EstimateResponse] = (*FundEstimateAdapter)(nil)
+)
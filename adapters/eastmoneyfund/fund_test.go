@@ -0,0 +1,126 @@
+package eastmoneyfund
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/souloss/quantds/clients/eastmoneyfund"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/fund"
+	"github.com/souloss/quantds/request"
+)
+
+// countingClient serves a fixed body and counts the requests made.
+type countingClient struct {
+	body  string
+	calls atomic.Int32
+}
+
+func (c *countingClient) Do(_ context.Context, _ request.Request) (request.Response, *request.Record, error) {
+	c.calls.Add(1)
+	return request.Response{StatusCode: 200, Body: []byte(c.body)}, &request.Record{}, nil
+}
+
+func (c *countingClient) Close() {}
+
+func TestNewFundAdapters(t *testing.T) {
+	client := eastmoneyfund.NewClient()
+
+	adapters := []interface {
+		Name() string
+		SupportedMarkets() []domain.Market
+		CanHandle(string) bool
+	}{
+		NewFundListAdapter(client),
+		NewFundNAVAdapter(client),
+		NewFundEstimateAdapter(client),
+	}
+	for _, a := range adapters {
+		if a.Name() != Name {
+			t.Errorf("Expected name '%s', got '%s'", Name, a.Name())
+		}
+		if m := a.SupportedMarkets(); len(m) != 1 || m[0] != domain.MarketCN {
+			t.Errorf("Expected supported markets [%s], got %v", domain.MarketCN, m)
+		}
+		if !a.CanHandle("000001") || !a.CanHandle("110011.OF") || a.CanHandle("AAPL.US") {
+			t.Errorf("%T: CanHandle() should accept 6-digit fund codes only", a)
+		}
+	}
+}
+
+func TestNavs(t *testing.T) {
+	items := []eastmoneyfund.FundNAVItem{
+		{Date: "2024-03-01", UnitNAV: 1.161, AccumNAV: 3.742, ChangeRate: 0.52},
+		{Date: "2024-02-29", UnitNAV: 1.155, AccumNAV: 3.736, ChangeRate: -0.26},
+		{Date: "bad"},
+	}
+
+	got := navs(items)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	if got[0].Date.Day() != 29 || got[0].UnitNAV != 1.155 || got[1].ChangeRate != 0.52 {
+		t.Errorf("navs() = %+v", got)
+	}
+}
+
+func TestToEstimate(t *testing.T) {
+	e, ok := toEstimate(&eastmoneyfund.FundEstimateResult{
+		Code:      "000001",
+		Name:      "华夏成长混合",
+		NAVDate:   "2024-02-29",
+		NAV:       "1.1550",
+		EstNAV:    "1.1610",
+		EstChange: "0.52",
+		EstTime:   "2024-03-01 15:00",
+	})
+	if !ok {
+		t.Fatal("toEstimate() returned no estimate")
+	}
+	if e.NAV != 1.155 || e.EstNAV != 1.161 || e.EstChangeRate != 0.52 {
+		t.Errorf("estimate = %+v", e)
+	}
+	if e.EstTime.Hour() != 15 || e.NAVDate.Format("2006-01-02") != "2024-02-29" || e.EstTime.Location().String() != "Asia/Shanghai" {
+		t.Errorf("times = %v, %v", e.NAVDate, e.EstTime)
+	}
+
+	if _, ok := toEstimate(&eastmoneyfund.FundEstimateResult{Code: "000009"}); ok {
+		t.Error("toEstimate() should skip funds without an estimate")
+	}
+}
+
+func TestFundListAdapter_CachesList(t *testing.T) {
+	http := &countingClient{body: `var r = [["000001","HXCZ","华夏成长","混合型-偏股","华夏成长混合"],["000002","HXCZ","华夏成长C","混合型-偏股","华夏成长混合C"],["000003","ZHKZ","中海可转债A","债券型-混合二级","中海可转债债券A"]];`}
+	adapter := NewFundListAdapter(eastmoneyfund.NewClient(eastmoneyfund.WithHTTPClient(http)))
+
+	for page := 1; page <= 2; page++ {
+		resp, _, err := adapter.Fetch(context.Background(), nil, fund.ListRequest{PageNumber: page, PageSize: 2})
+		if err != nil {
+			t.Fatalf("Fetch(page %d) error = %v", page, err)
+		}
+		if resp.Total != 3 {
+			t.Errorf("page %d: Total = %d, want 3", page, resp.Total)
+		}
+	}
+	if n := http.calls.Load(); n != 1 {
+		t.Errorf("fund list fetched %d times, want 1", n)
+	}
+}
+
+func TestFundEstimateAdapter_FetchAll(t *testing.T) {
+	http := &countingClient{body: `jsonpgz({"fundcode":"000001","name":"华夏成长混合","jzrq":"2024-02-29","dwjz":"1.1550","gsz":"1.1610","gszzl":"0.52","gztime":"2024-03-01 15:00"});`}
+	adapter := NewFundEstimateAdapter(eastmoneyfund.NewClient(eastmoneyfund.WithHTTPClient(http)))
+
+	codes := make([]string, 2*maxEstimateFetches+1)
+	for i := range codes {
+		codes[i] = "000001"
+	}
+	resp, trace, err := adapter.Fetch(context.Background(), nil, fund.EstimateRequest{Codes: codes})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(resp.Estimates) != len(codes) || trace.TotalRequests() != len(codes) {
+		t.Errorf("got %d estimates and %d requests, want %d", len(resp.Estimates), trace.TotalRequests(), len(codes))
+	}
+}
@@ -15,6 +15,9 @@ const Name = "eodhd"
 
 var supportedMarkets = []domain.Market{domain.MarketUS}
 
+// credentials lists the credentials every adapter in this package requires.
+var credentials = []manager.Credential{{Key: manager.CredentialAPIKey, Env: eodhd.EnvAPIKey}}
+
 type KlineAdapter struct {
 	client *eodhd.Client
 }
@@ -25,6 +28,7 @@ func NewKlineAdapter(client *eodhd.Client) *KlineAdapter {
 
 func (a *KlineAdapter) Name() string                      { return Name }
 func (a *KlineAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *KlineAdapter) Credentials() []manager.Credential { return credentials }
 
 func (a *KlineAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
@@ -91,3 +95,4 @@ func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Re
 }
 
 var _ manager.Provider[kline.Request, kline.Response] = (*KlineAdapter)(nil)
+var _ manager.KeyedProvider = (*KlineAdapter)(nil)
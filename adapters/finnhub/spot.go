@@ -20,6 +20,7 @@ func NewSpotAdapter(client *finnhub.Client) *SpotAdapter {
 
 func (a *SpotAdapter) Name() string                      { return Name }
 func (a *SpotAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *SpotAdapter) Credentials() []manager.Credential { return credentials }
 
 func (a *SpotAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
@@ -72,3 +73,4 @@ func (a *SpotAdapter) Fetch(ctx context.Context, _ request.Client, req spot.Requ
 }
 
 var _ manager.Provider[spot.Request, spot.Response] = (*SpotAdapter)(nil)
+var _ manager.KeyedProvider = (*SpotAdapter)(nil)
@@ -2,6 +2,7 @@ package okx
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -55,33 +56,30 @@ func (a *KlineAdapter) CanHandle(symbol string) bool {
 }
 
 // Fetch retrieves K-line data from OKX
+// Without a time range the latest bars are returned. When StartTime and
+// EndTime are set, the history-candles endpoint is paged backwards from
+// EndTime until StartTime is reached.
 func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
 	trace := manager.NewRequestTrace(Name)
 
 	// Convert symbol to OKX format (e.g., "BTCUSDT" → "BTC-USDT")
 	instID := toOKXInstID(req.Symbol)
-
-	params := &okx.CandlestickRequest{
-		InstID: instID,
-		Bar:    toOKXBar(req.Timeframe),
-		Limit:  500,
+	bar := toOKXBar(req.Timeframe)
+
+	var bars []kline.Bar
+	var err error
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		bars, err = a.fetchLatest(ctx, trace, instID, bar)
+	} else {
+		bars, err = a.fetchRange(ctx, trace, instID, bar, req.StartTime, req.EndTime)
 	}
-
-	candles, record, err := a.client.GetCandlesticks(ctx, params)
-	trace.AddRequest(record)
-
 	if err != nil {
 		return kline.Response{}, trace, err
 	}
 
-	bars := make([]kline.Bar, 0, len(candles))
-	for _, c := range candles {
-		bar, ok := parseCandlestick(c)
-		if !ok {
-			continue
-		}
-		bars = append(bars, bar)
-	}
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Timestamp.Before(bars[j].Timestamp)
+	})
 
 	trace.Finish()
 	return kline.Response{
@@ -91,6 +89,69 @@ func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Re
 	}, trace, nil
 }
 
+// fetchLatest retrieves the most recent bars
+func (a *KlineAdapter) fetchLatest(ctx context.Context, trace *manager.RequestTrace, instID, bar string) ([]kline.Bar, error) {
+	candles, record, err := a.client.GetCandlesticks(ctx, &okx.CandlestickRequest{
+		InstID: instID,
+		Bar:    bar,
+		Limit:  okx.MaxCandleLimit,
+	})
+	trace.AddRequest(record)
+	if err != nil {
+		return nil, err
+	}
+
+	bars := make([]kline.Bar, 0, len(candles))
+	for _, c := range candles {
+		if b, ok := parseCandlestick(c); ok {
+			bars = append(bars, b)
+		}
+	}
+	return bars, nil
+}
+
+// fetchRange pages history-candles backwards over [start, end]
+func (a *KlineAdapter) fetchRange(ctx context.Context, trace *manager.RequestTrace, instID, bar string, start, end time.Time) ([]kline.Bar, error) {
+	var bars []kline.Bar
+	after := end.UnixMilli() + 1
+	before := start.UnixMilli() - 1
+
+	for {
+		candles, record, err := a.client.GetHistoryCandlesticks(ctx, &okx.CandlestickRequest{
+			InstID: instID,
+			Bar:    bar,
+			Limit:  okx.MaxHistoryCandleLimit,
+			After:  after,
+			Before: before,
+		})
+		trace.AddRequest(record)
+		if err != nil {
+			return nil, err
+		}
+
+		oldest := after
+		for _, c := range candles {
+			b, ok := parseCandlestick(c)
+			if !ok {
+				continue
+			}
+			if ts := b.Timestamp.UnixMilli(); ts < oldest {
+				oldest = ts
+			}
+			if b.Timestamp.Before(start) || b.Timestamp.After(end) {
+				continue
+			}
+			bars = append(bars, b)
+		}
+
+		// Stop when the page is short or the cursor no longer moves back
+		if len(candles) < okx.MaxHistoryCandleLimit || oldest >= after || oldest <= start.UnixMilli() {
+			return bars, nil
+		}
+		after = oldest
+	}
+}
+
 // parseCandlestick converts OKX candlestick data [ts, o, h, l, c, vol, volCcy, volCcyQuote, confirm]
 func parseCandlestick(c okx.CandlestickResponse) (kline.Bar, bool) {
 	if len(c) < 7 {
@@ -1,7 +1,14 @@
 package okx
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	okxclient "github.com/souloss/quantds/clients/okx"
 	"github.com/souloss/quantds/domain"
@@ -141,3 +148,53 @@ func TestToOKXBar(t *testing.T) {
 		})
 	}
 }
+
+func TestKlineAdapter_Fetch_HistoryRange(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(249 * 24 * time.Hour)
+
+	var cursors []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != okxclient.EndpointHistoryCandles {
+			t.Errorf("path = %s, want %s", r.URL.Path, okxclient.EndpointHistoryCandles)
+		}
+		q := r.URL.Query()
+		cursors = append(cursors, q.Get(okxclient.ParamAfter))
+		after, _ := strconv.ParseInt(q.Get(okxclient.ParamAfter), 10, 64)
+		before, _ := strconv.ParseInt(q.Get(okxclient.ParamBefore), 10, 64)
+		limit, _ := strconv.Atoi(q.Get(okxclient.ParamLimit))
+
+		// Daily candles newest first, strictly between before and after
+		var rows []string
+		for d := end; !d.Before(start.AddDate(0, 0, -10)) && len(rows) < limit; d = d.AddDate(0, 0, -1) {
+			ms := d.UnixMilli()
+			if ms >= after || ms <= before {
+				continue
+			}
+			rows = append(rows, fmt.Sprintf(`["%d","1","2","0.5","1.5","10","15","15","1"]`, ms))
+		}
+		fmt.Fprintf(w, `{"code":"0","msg":"","data":[%s]}`, strings.Join(rows, ","))
+	}))
+	defer ts.Close()
+
+	adapter := NewKlineAdapter(okxclient.NewClient(okxclient.WithBaseURL(ts.URL)))
+	resp, trace, err := adapter.Fetch(context.Background(), nil, kline.Request{
+		Symbol:    "BTCUSDT",
+		Timeframe: kline.Timeframe1d,
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if len(resp.Bars) != 250 {
+		t.Fatalf("len(Bars) = %d, want 250", len(resp.Bars))
+	}
+	if !resp.Bars[0].Timestamp.Equal(start) || !resp.Bars[len(resp.Bars)-1].Timestamp.Equal(end) {
+		t.Errorf("range = [%v, %v], want [%v, %v]", resp.Bars[0].Timestamp, resp.Bars[len(resp.Bars)-1].Timestamp, start, end)
+	}
+	if trace.TotalRequests() != 3 || len(cursors) != 3 {
+		t.Errorf("requests = %d, want 3", trace.TotalRequests())
+	}
+}
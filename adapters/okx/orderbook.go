@@ -0,0 +1,99 @@
+package okx
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/souloss/quantds/clients/okx"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/orderbook"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// defaultBookDepth is requested when the request does not set a depth, as
+// OKX only returns the best level by default
+const defaultBookDepth = 20
+
+// OrderBookAdapter adapts OKX order books to domain orderbook
+type OrderBookAdapter struct {
+	client *okx.Client
+}
+
+// NewOrderBookAdapter creates a new order book adapter
+func NewOrderBookAdapter(client *okx.Client) *OrderBookAdapter {
+	return &OrderBookAdapter{client: client}
+}
+
+// Name returns the adapter name
+func (a *OrderBookAdapter) Name() string {
+	return Name
+}
+
+// SupportedMarkets returns supported markets
+func (a *OrderBookAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+// CanHandle checks if the adapter can handle the symbol
+func (a *OrderBookAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch retrieves an order book snapshot from OKX
+func (a *OrderBookAdapter) Fetch(ctx context.Context, _ request.Client, req orderbook.Request) (orderbook.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	depth := req.Depth
+	if depth <= 0 {
+		depth = defaultBookDepth
+	}
+
+	instID := toOKXInstID(req.Symbol)
+	book, record, err := a.client.GetBooks(ctx, &okx.BooksRequest{
+		InstID: instID,
+		Size:   depth,
+	})
+	trace.AddRequest(record)
+
+	if err != nil {
+		return orderbook.Response{}, trace, err
+	}
+
+	trace.Finish()
+	return orderbook.Response{
+		Book:   toBook(fromOKXInstID(instID), book, req.Depth),
+		Source: Name,
+	}, trace, nil
+}
+
+func toBook(symbol string, book *okx.BookResponse, depth int) orderbook.Book {
+	ts, _ := strconv.ParseInt(book.Ts, 10, 64)
+	return orderbook.NewBook(symbol, toLevels(book.Bids), toLevels(book.Asks), time.UnixMilli(ts), depth)
+}
+
+// toLevels parses [price, size, deprecated, numOrders] levels
+func toLevels(rows [][]string) []orderbook.Level {
+	levels := make([]orderbook.Level, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		price, _ := strconv.ParseFloat(row[0], 64)
+		size, _ := strconv.ParseFloat(row[1], 64)
+		levels = append(levels, orderbook.Level{Price: price, Volume: size})
+	}
+	return levels
+}
+
+var _ manager.Provider[orderbook.Request, orderbook.Response] = (*OrderBookAdapter)(nil)
@@ -0,0 +1,36 @@
+package okx
+
+import (
+	"testing"
+
+	okxclient "github.com/souloss/quantds/clients/okx"
+)
+
+func TestOrderBookAdapter_CanHandle(t *testing.T) {
+	adapter := NewOrderBookAdapter(okxclient.NewClient())
+
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+	if !adapter.CanHandle("BTCUSDT") || adapter.CanHandle("000001.SZ") {
+		t.Error("CanHandle() should accept crypto symbols only")
+	}
+}
+
+func TestToBook(t *testing.T) {
+	book := toBook("BTCUSDT", &okxclient.BookResponse{
+		Asks: [][]string{{"41006.8", "0.60038921", "0", "1"}, {"41007.0", "1.2", "0", "3"}},
+		Bids: [][]string{{"41006.3", "0.30178218", "0", "2"}},
+		Ts:   "1629966436396",
+	}, 1)
+
+	if len(book.Asks) != 1 || len(book.Bids) != 1 {
+		t.Fatalf("book = %+v, want one level per side", book)
+	}
+	if book.Asks[0].Price != 41006.8 || book.Bids[0].Volume != 0.30178218 {
+		t.Errorf("book = %+v", book)
+	}
+	if book.Timestamp.UnixMilli() != 1629966436396 {
+		t.Errorf("Timestamp = %v", book.Timestamp)
+	}
+}
@@ -20,6 +20,7 @@ func NewInstrumentAdapter(client *polygon.Client) *InstrumentAdapter {
 
 func (a *InstrumentAdapter) Name() string                      { return Name }
 func (a *InstrumentAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *InstrumentAdapter) Credentials() []manager.Credential { return credentials }
 
 func (a *InstrumentAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
@@ -88,3 +89,4 @@ func (a *InstrumentAdapter) Fetch(ctx context.Context, _ request.Client, req ins
 }
 
 var _ manager.Provider[instrument.Request, instrument.Response] = (*InstrumentAdapter)(nil)
+var _ manager.KeyedProvider = (*InstrumentAdapter)(nil)
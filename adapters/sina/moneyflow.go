@@ -0,0 +1,104 @@
+package sina
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/souloss/quantds/clients/sina"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/moneyflow"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// defaultMoneyFlowDays is the number of daily flows fetched when the request
+// sets neither a limit nor a start date.
+const defaultMoneyFlowDays = 20
+
+// MoneyFlowAdapter adapts the Sina R0-R3 money flow buckets. Sina only
+// publishes daily flows; the intraday period returns the current day's entry.
+type MoneyFlowAdapter struct {
+	client *sina.Client
+}
+
+func NewMoneyFlowAdapter(client *sina.Client) *MoneyFlowAdapter {
+	return &MoneyFlowAdapter{client: client}
+}
+
+func (a *MoneyFlowAdapter) Name() string {
+	return Name
+}
+
+func (a *MoneyFlowAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+func (a *MoneyFlowAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *MoneyFlowAdapter) Fetch(ctx context.Context, _ request.Client, req moneyflow.Request) (moneyflow.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	count := 1
+	if req.Period == moneyflow.PeriodDaily {
+		count = req.Days(defaultMoneyFlowDays)
+	}
+
+	items, record, err := a.client.GetMoneyFlow(ctx, &sina.MoneyFlowParams{
+		Symbol: req.Symbol,
+		Count:  count,
+	})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return moneyflow.Response{}, trace, err
+	}
+
+	// Ratios are fractions of the turnover
+	flows := make([]moneyflow.Flow, 0, len(items))
+	for _, item := range items {
+		date, _ := time.ParseInLocation("2006-01-02", item.Date, timeLoc)
+		flows = append(flows, moneyflow.Flow{
+			Date:                date,
+			MainNetInflow:       item.NetAmount,
+			SuperLargeNetInflow: item.R0Net,
+			LargeNetInflow:      item.R1Net,
+			MediumNetInflow:     item.R2Net,
+			SmallNetInflow:      item.R3Net,
+			MainNetRatio:        item.RatioAmount * 100,
+			SuperLargeNetRatio:  item.R0Ratio * 100,
+			LargeNetRatio:       item.R1Ratio * 100,
+			MediumNetRatio:      item.R2Ratio * 100,
+			SmallNetRatio:       item.R3Ratio * 100,
+			Close:               item.Trade,
+			ChangeRate:          item.ChangeRatio * 100,
+		})
+	}
+	sort.Slice(flows, func(i, j int) bool {
+		return flows[i].Date.Before(flows[j].Date)
+	})
+	if req.Period == moneyflow.PeriodDaily {
+		flows = req.Filter(flows)
+	}
+
+	trace.Finish()
+	return moneyflow.Response{
+		Symbol: req.Symbol,
+		Period: req.Period,
+		Flows:  flows,
+		Source: Name,
+	}, trace, nil
+}
+
+var _ manager.Provider[moneyflow.Request, moneyflow.Response] = (*MoneyFlowAdapter)(nil)
@@ -0,0 +1,37 @@
+package sina
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/sina"
+)
+
+func TestNewMoneyFlowAdapter(t *testing.T) {
+	client := sina.NewClient()
+	adapter := NewMoneyFlowAdapter(client)
+
+	if adapter == nil {
+		t.Fatal("NewMoneyFlowAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestMoneyFlowAdapter_CanHandle(t *testing.T) {
+	adapter := NewMoneyFlowAdapter(sina.NewClient())
+
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"000001.SZ", true},
+		{"600519.SH", true},
+		{"AAPL", false},
+	}
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/souloss/quantds/clients/tencent"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/moneyflow"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// moneyFlowUnit converts Tencent amounts (万元) to CNY.
+const moneyFlowUnit = 10000
+
+// MoneyFlowAdapter adapts Tencent intraday money flow. Tencent has no daily
+// history, so daily requests fail and fall through to other providers.
+type MoneyFlowAdapter struct {
+	client *tencent.Client
+}
+
+func NewMoneyFlowAdapter(client *tencent.Client) *MoneyFlowAdapter {
+	return &MoneyFlowAdapter{client: client}
+}
+
+func (a *MoneyFlowAdapter) Name() string {
+	return Name
+}
+
+func (a *MoneyFlowAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+func (a *MoneyFlowAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *MoneyFlowAdapter) Fetch(ctx context.Context, _ request.Client, req moneyflow.Request) (moneyflow.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	if req.Period != moneyflow.PeriodIntraday {
+		trace.Finish()
+		return moneyflow.Response{}, trace, fmt.Errorf("tencent: unsupported money flow period %q", req.Period)
+	}
+
+	data, record, err := a.client.GetMoneyFlow(ctx, &tencent.MoneyFlowParams{Symbol: req.Symbol})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return moneyflow.Response{}, trace, err
+	}
+
+	trace.Finish()
+	return moneyflow.Response{
+		Symbol: req.Symbol,
+		Period: req.Period,
+		Flows: []moneyflow.Flow{{
+			Date:                parseMoneyFlowDate(data.Date),
+			MainNetInflow:       data.MainNet * moneyFlowUnit,
+			SuperLargeNetInflow: data.SuperNet * moneyFlowUnit,
+			LargeNetInflow:      data.LargeNet * moneyFlowUnit,
+			MediumNetInflow:     data.MediumNet * moneyFlowUnit,
+			SmallNetInflow:      data.SmallNet * moneyFlowUnit,
+			MainNetRatio:        data.MainRatio,
+			SuperLargeNetRatio:  data.SuperRatio,
+			LargeNetRatio:       data.LargeRatio,
+			MediumNetRatio:      data.MediumRatio,
+			SmallNetRatio:       data.SmallRatio,
+		}},
+		Source: Name,
+	}, trace, nil
+}
+
+// parseMoneyFlowDate parses the trading date, defaulting to today.
+func parseMoneyFlowDate(s string) time.Time {
+	for _, layout := range []string{"20060102", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, timeLoc); err == nil {
+			return t
+		}
+	}
+	now := time.Now().In(timeLoc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, timeLoc)
+}
+
+var _ manager.Provider[moneyflow.Request, moneyflow.Response] = (*MoneyFlowAdapter)(nil)
@@ -0,0 +1,47 @@
+package tencent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/souloss/quantds/clients/tencent"
+	"github.com/souloss/quantds/domain/moneyflow"
+)
+
+func TestNewMoneyFlowAdapter(t *testing.T) {
+	client := tencent.NewClient()
+	adapter := NewMoneyFlowAdapter(client)
+
+	if adapter == nil {
+		t.Fatal("NewMoneyFlowAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestMoneyFlowAdapter_CanHandle(t *testing.T) {
+	adapter := NewMoneyFlowAdapter(tencent.NewClient())
+
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"000001.SZ", true},
+		{"600519.SH", true},
+		{"AAPL", false},
+	}
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyFlowAdapter_DailyUnsupported(t *testing.T) {
+	adapter := NewMoneyFlowAdapter(tencent.NewClient())
+	_, _, err := adapter.Fetch(context.Background(), nil, moneyflow.Request{Symbol: "000001.SZ", Period: moneyflow.PeriodDaily})
+	if err == nil {
+		t.Error("Fetch() should fail for daily money flow")
+	}
+}
@@ -0,0 +1,86 @@
+package tushare
+
+import (
+	"context"
+	"sort"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// AdjFactorAdapter 将 Tushare 复权因子转换为 kline.Factor，供本地复权使用。
+type AdjFactorAdapter struct {
+	client *tushare.Client
+}
+
+// NewAdjFactorAdapter 创建 Tushare 复权因子适配器。
+func NewAdjFactorAdapter(client *tushare.Client) *AdjFactorAdapter {
+	return &AdjFactorAdapter{client: client}
+}
+
+func (a *AdjFactorAdapter) Name() string                      { return Name }
+func (a *AdjFactorAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *AdjFactorAdapter) Credentials() []manager.Credential { return credentials }
+
+func (a *AdjFactorAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AdjFactorAdapter) Fetch(ctx context.Context, _ request.Client, req kline.FactorRequest) (kline.FactorResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	tsCode, err := tushare.ToTushareSymbol(req.Symbol)
+	if err != nil {
+		return kline.FactorResponse{}, trace, err
+	}
+
+	params := &tushare.AdjFactorParams{TSCode: tsCode}
+	if !req.StartTime.IsZero() {
+		params.StartDate = req.StartTime.Format("20060102")
+	}
+	if !req.EndTime.IsZero() {
+		params.EndDate = req.EndTime.Format("20060102")
+	}
+
+	rows, record, err := a.client.GetAdjFactor(ctx, params)
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return kline.FactorResponse{}, trace, err
+	}
+
+	factors := make([]kline.Factor, 0, len(rows))
+	for _, row := range rows {
+		date := parseCompactDate(row.TradeDate)
+		if date.IsZero() || row.AdjFactor <= 0 {
+			continue
+		}
+		factors = append(factors, kline.Factor{Date: date, Value: row.AdjFactor})
+	}
+	// Tushare 按交易日倒序返回
+	sort.Slice(factors, func(i, j int) bool {
+		return factors[i].Date.Before(factors[j].Date)
+	})
+
+	trace.Finish()
+	return kline.FactorResponse{
+		Symbol:  req.Symbol,
+		Factors: factors,
+		Source:  Name,
+	}, trace, nil
+}
+
+var _ manager.Provider[kline.FactorRequest, kline.FactorResponse] = (*AdjFactorAdapter)(nil)
+var _ manager.KeyedProvider = (*AdjFactorAdapter)(nil)
@@ -0,0 +1,37 @@
+package tushare
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/tushare"
+)
+
+func TestNewAdjFactorAdapter(t *testing.T) {
+	adapter := NewAdjFactorAdapter(tushare.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewAdjFactorAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestAdjFactorAdapter_CanHandle(t *testing.T) {
+	adapter := NewAdjFactorAdapter(tushare.NewClient())
+
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"000001.SZ", true},
+		{"600519.SH", true},
+		{"AAPL.US", false},
+		{"BTCUSDT", false},
+	}
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
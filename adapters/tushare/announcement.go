@@ -28,6 +28,10 @@ func (a *AnnouncementAdapter) SupportedMarkets() []domain.Market {
 	return supportedMarkets
 }
 
+func (a *AnnouncementAdapter) Credentials() []manager.Credential {
+	return credentials
+}
+
 func (a *AnnouncementAdapter) CanHandle(symbol string) bool {
 	if symbol == "" {
 		return true // 支持按市场查询
@@ -93,3 +97,4 @@ func (a *AnnouncementAdapter) Fetch(ctx context.Context, _ request.Client, req a
 }
 
 var _ manager.Provider[announcement.Request, announcement.Response] = (*AnnouncementAdapter)(nil)
+var _ manager.KeyedProvider = (*AnnouncementAdapter)(nil)
@@ -0,0 +1,102 @@
+package tushare
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/calendar"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// CalendarAdapter 将 Tushare 交易日历转换为统一的 calendar 域类型。
+type CalendarAdapter struct {
+	client *tushare.Client
+}
+
+// NewCalendarAdapter 创建 Tushare 交易日历适配器。
+func NewCalendarAdapter(client *tushare.Client) *CalendarAdapter {
+	return &CalendarAdapter{client: client}
+}
+
+func (a *CalendarAdapter) Name() string                      { return Name }
+func (a *CalendarAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *CalendarAdapter) Credentials() []manager.Credential { return credentials }
+
+func (a *CalendarAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *CalendarAdapter) Fetch(ctx context.Context, _ request.Client, req calendar.Request) (calendar.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	exchange := toTushareCalExchange(req.Exchange)
+	if exchange == "" {
+		trace.Finish()
+		return calendar.Response{}, trace, fmt.Errorf("tushare: unsupported calendar exchange %q", req.Exchange)
+	}
+
+	params := &tushare.TradeCalParams{Exchange: exchange}
+	if !req.StartTime.IsZero() {
+		params.StartDate = req.StartTime.In(timeLoc).Format("20060102")
+	}
+	if !req.EndTime.IsZero() {
+		params.EndDate = req.EndTime.In(timeLoc).Format("20060102")
+	}
+
+	rows, record, err := a.client.GetTradeCal(ctx, params)
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return calendar.Response{}, trace, err
+	}
+
+	trace.Finish()
+	return calendar.Response{
+		Exchange: req.Exchange,
+		Days:     calendarDays(rows),
+		Source:   Name,
+	}, trace, nil
+}
+
+// toTushareCalExchange 映射交易所代码，北交所与沪深共用同一日历。
+func toTushareCalExchange(ex domain.Exchange) string {
+	switch ex {
+	case domain.ExchangeSH, domain.ExchangeBJ:
+		return "SSE"
+	case domain.ExchangeSZ:
+		return "SZSE"
+	}
+	return ""
+}
+
+// calendarDays 转换日历行并按日期升序排列。
+func calendarDays(rows []tushare.TradeCalRow) []calendar.Day {
+	days := make([]calendar.Day, 0, len(rows))
+	for _, row := range rows {
+		date := parseCompactDate(row.CalDate)
+		if date.IsZero() {
+			continue
+		}
+		days = append(days, calendar.Day{Date: date, IsOpen: row.IsOpen})
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days
+}
+
+var _ manager.Provider[calendar.Request, calendar.Response] = (*CalendarAdapter)(nil)
+var _ manager.KeyedProvider = (*CalendarAdapter)(nil)
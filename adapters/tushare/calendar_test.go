@@ -0,0 +1,52 @@
+package tushare
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+)
+
+func TestNewCalendarAdapter(t *testing.T) {
+	adapter := NewCalendarAdapter(tushare.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewCalendarAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestToTushareCalExchange(t *testing.T) {
+	tests := []struct {
+		exchange domain.Exchange
+		want     string
+	}{
+		{domain.ExchangeSH, "SSE"},
+		{domain.ExchangeSZ, "SZSE"},
+		{domain.ExchangeBJ, "SSE"},
+		{domain.ExchangeNYSE, ""},
+	}
+	for _, tt := range tests {
+		if got := toTushareCalExchange(tt.exchange); got != tt.want {
+			t.Errorf("toTushareCalExchange(%s) = %q, want %q", tt.exchange, got, tt.want)
+		}
+	}
+}
+
+func TestCalendarDays(t *testing.T) {
+	rows := []tushare.TradeCalRow{
+		{Exchange: "SSE", CalDate: "20250129", IsOpen: false},
+		{Exchange: "SSE", CalDate: "20250127", IsOpen: true},
+		{Exchange: "SSE", CalDate: "bad"},
+	}
+
+	days := calendarDays(rows)
+	if len(days) != 2 {
+		t.Fatalf("len(days) = %d, want 2", len(days))
+	}
+	if days[0].Date.Day() != 27 || !days[0].IsOpen || days[1].IsOpen {
+		t.Errorf("days = %+v", days)
+	}
+}
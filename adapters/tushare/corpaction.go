@@ -0,0 +1,139 @@
+package tushare
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/corpaction"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// divProcImplemented 是已实施分红方案的进度值，只有这类方案才有除权除息日。
+const divProcImplemented = "实施"
+
+var timeLoc, _ = time.LoadLocation("Asia/Shanghai")
+
+// CorpActionAdapter 将 Tushare 分红送股数据转换为统一的 corpaction 域类型。
+type CorpActionAdapter struct {
+	client *tushare.Client
+}
+
+// NewCorpActionAdapter 创建 Tushare 公司行为适配器。
+func NewCorpActionAdapter(client *tushare.Client) *CorpActionAdapter {
+	return &CorpActionAdapter{client: client}
+}
+
+func (a *CorpActionAdapter) Name() string                      { return Name }
+func (a *CorpActionAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *CorpActionAdapter) Credentials() []manager.Credential { return credentials }
+
+func (a *CorpActionAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *CorpActionAdapter) Fetch(ctx context.Context, _ request.Client, req corpaction.Request) (corpaction.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	tsCode, err := tushare.ToTushareSymbol(req.Symbol)
+	if err != nil {
+		return corpaction.Response{}, trace, err
+	}
+
+	rows, record, err := a.client.GetDividend(ctx, &tushare.DividendParams{TSCode: tsCode})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return corpaction.Response{}, trace, err
+	}
+
+	trace.Finish()
+	return corpaction.Response{
+		Symbol:  req.Symbol,
+		Actions: req.Filter(dividendActions(req.Symbol, rows)),
+		Source:  Name,
+	}, trace, nil
+}
+
+// dividendActions 将已实施的分红送股方案拆分为现金分红、送股和转增三类事件。
+// 预案和股东大会通过阶段的记录没有除权除息日，不计入。
+func dividendActions(symbol string, rows []tushare.DividendRow) []corpaction.Action {
+	type key struct {
+		typ    corpaction.ActionType
+		exDate string
+	}
+	seen := make(map[key]bool)
+	var actions []corpaction.Action
+	add := func(row tushare.DividendRow, action corpaction.Action) {
+		k := key{action.Type, row.ExDate}
+		if seen[k] {
+			return
+		}
+		seen[k] = true
+		action.Symbol = symbol
+		action.AnnDate = parseCompactDate(row.ImpAnnDate)
+		if action.AnnDate.IsZero() {
+			action.AnnDate = parseCompactDate(row.AnnDate)
+		}
+		action.RecordDate = parseCompactDate(row.RecordDate)
+		action.ExDate = parseCompactDate(row.ExDate)
+		action.Progress = row.DivProc
+		actions = append(actions, action)
+	}
+
+	for _, row := range rows {
+		if row.DivProc != divProcImplemented || row.ExDate == "" {
+			continue
+		}
+		if row.CashDivTax > 0 {
+			add(row, corpaction.Action{
+				Type:    corpaction.ActionCashDividend,
+				Cash:    row.CashDivTax,
+				PayDate: parseCompactDate(row.PayDate),
+			})
+		}
+		stockDiv := row.StkBoRate
+		if stockDiv == 0 && row.StkCoRate == 0 {
+			// 部分记录只给出送转合计
+			stockDiv = row.StkDiv
+		}
+		if stockDiv > 0 {
+			add(row, corpaction.Action{
+				Type:    corpaction.ActionStockDividend,
+				Ratio:   stockDiv,
+				PayDate: parseCompactDate(row.DivListDate),
+			})
+		}
+		if row.StkCoRate > 0 {
+			add(row, corpaction.Action{
+				Type:    corpaction.ActionTransfer,
+				Ratio:   row.StkCoRate,
+				PayDate: parseCompactDate(row.DivListDate),
+			})
+		}
+	}
+	return actions
+}
+
+// parseCompactDate 将 YYYYMMDD 格式日期解析为北京时间零点。
+func parseCompactDate(yyyymmdd string) time.Time {
+	if len(yyyymmdd) != 8 {
+		return time.Time{}
+	}
+	t, _ := time.ParseInLocation("20060102", yyyymmdd, timeLoc)
+	return t
+}
+
+var _ manager.Provider[corpaction.Request, corpaction.Response] = (*CorpActionAdapter)(nil)
+var _ manager.KeyedProvider = (*CorpActionAdapter)(nil)
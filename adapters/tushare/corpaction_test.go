@@ -0,0 +1,67 @@
+package tushare
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain/corpaction"
+)
+
+func TestNewCorpActionAdapter(t *testing.T) {
+	adapter := NewCorpActionAdapter(tushare.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewCorpActionAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestCorpActionAdapter_CanHandle(t *testing.T) {
+	adapter := NewCorpActionAdapter(tushare.NewClient())
+
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"000001.SZ", true},
+		{"600519.SH", true},
+		{"AAPL.US", false},
+		{"BTCUSDT", false},
+	}
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestDividendActions(t *testing.T) {
+	rows := []tushare.DividendRow{
+		{DivProc: "预案", CashDivTax: 0.2, AnnDate: "20240301"},
+		{DivProc: "实施", CashDiv: 0.18, CashDivTax: 0.2, StkBoRate: 0.3, StkCoRate: 0.1, AnnDate: "20240301",
+			ImpAnnDate: "20240601", RecordDate: "20240612", ExDate: "20240613", PayDate: "20240613", DivListDate: "20240614"},
+		// Duplicate implemented row
+		{DivProc: "实施", CashDivTax: 0.2, ExDate: "20240613"},
+	}
+
+	actions := dividendActions("000001.SZ", rows)
+	if len(actions) != 3 {
+		t.Fatalf("expected 3 actions, got %d: %+v", len(actions), actions)
+	}
+
+	cash := actions[0]
+	if cash.Type != corpaction.ActionCashDividend || cash.Cash != 0.2 {
+		t.Errorf("unexpected cash action: %+v", cash)
+	}
+	if cash.ExDate.Format("20060102") != "20240613" || cash.AnnDate.Format("20060102") != "20240601" {
+		t.Errorf("unexpected dates: ex=%v ann=%v", cash.ExDate, cash.AnnDate)
+	}
+	if actions[1].Type != corpaction.ActionStockDividend || actions[1].Ratio != 0.3 {
+		t.Errorf("unexpected stock dividend: %+v", actions[1])
+	}
+	if actions[2].Type != corpaction.ActionTransfer || actions[2].PayDate.Format("20060102") != "20240614" {
+		t.Errorf("unexpected transfer: %+v", actions[2])
+	}
+}
@@ -24,6 +24,7 @@ func NewFinancialAdapter(client *tushare.Client) *FinancialAdapter {
 
 func (a *FinancialAdapter) Name() string                      { return Name }
 func (a *FinancialAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *FinancialAdapter) Credentials() []manager.Credential { return credentials }
 
 func (a *FinancialAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
@@ -228,3 +229,4 @@ func guessReportPeriod(yyyymmdd string) financial.ReportPeriod {
 }
 
 var _ manager.Provider[financial.Request, financial.Response] = (*FinancialAdapter)(nil)
+var _ manager.KeyedProvider = (*FinancialAdapter)(nil)
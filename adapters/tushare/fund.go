@@ -0,0 +1,185 @@
+package tushare
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/fund"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// fundMarkets 是 fund_basic 的市场参数：E 场内，O 场外。接口默认只返回场内基金。
+var fundMarkets = []string{"E", "O"}
+
+// isFundCode 判断是否为 6 位数字基金代码。
+func isFundCode(code string) bool {
+	code = fund.NormalizeCode(code)
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FundListAdapter 将 Tushare 基金列表转换为统一的 fund 域类型。
+type FundListAdapter struct {
+	client *tushare.Client
+}
+
+// NewFundListAdapter 创建 Tushare 基金列表适配器。
+func NewFundListAdapter(client *tushare.Client) *FundListAdapter {
+	return &FundListAdapter{client: client}
+}
+
+func (a *FundListAdapter) Name() string                      { return Name }
+func (a *FundListAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *FundListAdapter) Credentials() []manager.Credential { return credentials }
+func (a *FundListAdapter) CanHandle(symbol string) bool      { return isFundCode(symbol) }
+
+func (a *FundListAdapter) Fetch(ctx context.Context, _ request.Client, req fund.ListRequest) (fund.ListResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var funds []fund.Fund
+	seen := make(map[string]bool)
+	for _, market := range fundMarkets {
+		rows, record, err := a.client.GetFundBasic(ctx, &tushare.FundBasicParams{Market: market, Status: "L"})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return fund.ListResponse{}, trace, err
+		}
+		for _, f := range fundBasicFunds(rows) {
+			if !seen[f.Code] {
+				seen[f.Code] = true
+				funds = append(funds, f)
+			}
+		}
+	}
+	page, total := req.Filter(funds)
+
+	trace.Finish()
+	return fund.ListResponse{
+		Funds:      page,
+		Total:      total,
+		Source:     Name,
+		PageNumber: req.PageNumber,
+		PageSize:   req.PageSize,
+	}, trace, nil
+}
+
+// fundBasicFunds 将 fund_basic 记录转换为基金列表。
+// Tushare 的 fund_type 不区分指数型，被动指数基金通过 invest_type 识别。
+func fundBasicFunds(rows []tushare.FundBasicRow) []fund.Fund {
+	funds := make([]fund.Fund, 0, len(rows))
+	for _, row := range rows {
+		typ := fund.ParseType(row.FundType)
+		if row.InvestType == "被动指数型" && typ == fund.TypeStock {
+			typ = fund.TypeIndex
+		}
+		funds = append(funds, fund.Fund{
+			Code:       fund.NormalizeCode(row.TSCode),
+			Name:       row.Name,
+			Type:       typ,
+			Category:   row.FundType,
+			Management: row.Management,
+			Custodian:  row.Custodian,
+			FoundDate:  parseCompactDate(row.FoundDate),
+			Listed:     row.Market == "E",
+		})
+	}
+	return funds
+}
+
+// FundNAVAdapter 将 Tushare 基金净值转换为统一的 fund 域类型。
+type FundNAVAdapter struct {
+	client *tushare.Client
+}
+
+// NewFundNAVAdapter 创建 Tushare 基金净值适配器。
+func NewFundNAVAdapter(client *tushare.Client) *FundNAVAdapter {
+	return &FundNAVAdapter{client: client}
+}
+
+func (a *FundNAVAdapter) Name() string                      { return Name }
+func (a *FundNAVAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *FundNAVAdapter) Credentials() []manager.Credential { return credentials }
+func (a *FundNAVAdapter) CanHandle(symbol string) bool      { return isFundCode(symbol) }
+
+func (a *FundNAVAdapter) Fetch(ctx context.Context, _ request.Client, req fund.NAVRequest) (fund.NAVResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	params := &tushare.FundNAVParams{TSCode: toFundTSCode(req.Code)}
+	if !req.StartTime.IsZero() {
+		params.StartDate = req.StartTime.In(timeLoc).Format("20060102")
+	}
+	if !req.EndTime.IsZero() {
+		params.EndDate = req.EndTime.In(timeLoc).Format("20060102")
+	}
+
+	rows, record, err := a.client.GetFundNAV(ctx, params)
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return fund.NAVResponse{}, trace, err
+	}
+
+	trace.Finish()
+	return fund.NAVResponse{
+		Code:   fund.NormalizeCode(req.Code),
+		NAVs:   fundNAVs(rows),
+		Source: Name,
+	}, trace, nil
+}
+
+// toFundTSCode 将基金代码转换为 Tushare 代码，未带后缀的代码视为场外基金 (.OF)。
+func toFundTSCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if strings.Contains(code, ".") {
+		return code
+	}
+	return code + ".OF"
+}
+
+// fundNAVs 将净值记录按日期升序排列，并以复权净值计算日增长率。
+func fundNAVs(rows []tushare.FundNAVRow) []fund.NAV {
+	sorted := make([]tushare.FundNAVRow, 0, len(rows))
+	for _, row := range rows {
+		if !parseCompactDate(row.EndDate).IsZero() {
+			sorted = append(sorted, row)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].EndDate < sorted[j].EndDate })
+
+	navs := make([]fund.NAV, 0, len(sorted))
+	for i, row := range sorted {
+		// 同一日期可能有多条公告记录，只保留一条
+		if i > 0 && sorted[i-1].EndDate == row.EndDate {
+			continue
+		}
+		nav := fund.NAV{
+			Date:     parseCompactDate(row.EndDate),
+			UnitNAV:  row.UnitNAV,
+			AccumNAV: row.AccumNAV,
+		}
+		if i > 0 && sorted[i-1].AdjNAV > 0 && row.AdjNAV > 0 {
+			nav.ChangeRate = (row.AdjNAV/sorted[i-1].AdjNAV - 1) * 100
+		}
+		navs = append(navs, nav)
+	}
+	return navs
+}
+
+var (
+	_ manager.Provider[fund.ListRequest, fund.ListResponse] = (*FundListAdapter)(nil)
+	_ manager.Provider[fund.NAVRequest, fund.NAVResponse]   = (*FundNAVAdapter)(nil)
+	_ manager.KeyedProvider                                 = (*FundListAdapter)(nil)
+	_ manager.KeyedProvider                                 = (*FundNAVAdapter)(nil)
+)
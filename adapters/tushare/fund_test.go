@@ -0,0 +1,71 @@
+package tushare
+
+import (
+	"math"
+	"testing"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain/fund"
+)
+
+func TestNewFundAdapters(t *testing.T) {
+	client := tushare.NewClient()
+
+	list := NewFundListAdapter(client)
+	if list.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, list.Name())
+	}
+	nav := NewFundNAVAdapter(client)
+	if nav.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, nav.Name())
+	}
+	if !nav.CanHandle("110011") || !nav.CanHandle("510300.SH") || nav.CanHandle("AAPL.US") {
+		t.Error("CanHandle() should accept 6-digit fund codes only")
+	}
+}
+
+func TestToFundTSCode(t *testing.T) {
+	tests := map[string]string{
+		"110011":    "110011.OF",
+		"510300.sh": "510300.SH",
+		"000001.OF": "000001.OF",
+	}
+	for in, want := range tests {
+		if got := toFundTSCode(in); got != want {
+			t.Errorf("toFundTSCode(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestFundBasicFunds(t *testing.T) {
+	funds := fundBasicFunds([]tushare.FundBasicRow{
+		{TSCode: "510300.SH", Name: "沪深300ETF", FundType: "股票型", InvestType: "被动指数型", FoundDate: "20120504", Market: "E"},
+		{TSCode: "000001.OF", Name: "华夏成长", FundType: "混合型", Market: "O"},
+	})
+	if len(funds) != 2 {
+		t.Fatalf("len = %d, want 2", len(funds))
+	}
+	if f := funds[0]; f.Code != "510300" || f.Type != fund.TypeIndex || !f.Listed || f.FoundDate.Year() != 2012 {
+		t.Errorf("funds[0] = %+v", f)
+	}
+	if f := funds[1]; f.Type != fund.TypeHybrid || f.Listed {
+		t.Errorf("funds[1] = %+v", f)
+	}
+}
+
+func TestFundNAVs(t *testing.T) {
+	navs := fundNAVs([]tushare.FundNAVRow{
+		{EndDate: "20240301", UnitNAV: 1.05, AccumNAV: 3.05, AdjNAV: 1.05},
+		{EndDate: "20240229", UnitNAV: 1.00, AccumNAV: 3.00, AdjNAV: 1.00},
+		{EndDate: "20240229", UnitNAV: 1.00, AccumNAV: 3.00, AdjNAV: 1.00},
+	})
+	if len(navs) != 2 {
+		t.Fatalf("len = %d, want 2", len(navs))
+	}
+	if navs[0].Date.Day() != 29 || navs[0].ChangeRate != 0 {
+		t.Errorf("navs[0] = %+v", navs[0])
+	}
+	if math.Abs(navs[1].ChangeRate-5) > 1e-9 {
+		t.Errorf("navs[1].ChangeRate = %v, want 5", navs[1].ChangeRate)
+	}
+}
@@ -0,0 +1,176 @@
+package tushare
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/index"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// indexMarkets 是 index_basic 的市场参数：上交所、深交所、中证、国证指数。接口默认只返回上交所指数。
+var indexMarkets = []string{"SSE", "SZSE", "CSI", "CNI"}
+
+// indexWeightLookback 是查找权重快照的回溯区间，index_weight 按月更新。
+const indexWeightLookback = 62 * 24 * time.Hour
+
+// isIndexSymbol 判断是否为带交易所后缀的 A 股指数代码。
+func isIndexSymbol(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	return sym.AssetType == domain.AssetTypeIndex
+}
+
+// IndexListAdapter 将 Tushare 指数基本信息转换为统一的 index 域类型。
+type IndexListAdapter struct {
+	client *tushare.Client
+}
+
+// NewIndexListAdapter 创建 Tushare 指数列表适配器。
+func NewIndexListAdapter(client *tushare.Client) *IndexListAdapter {
+	return &IndexListAdapter{client: client}
+}
+
+func (a *IndexListAdapter) Name() string                      { return Name }
+func (a *IndexListAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *IndexListAdapter) Credentials() []manager.Credential { return credentials }
+func (a *IndexListAdapter) CanHandle(symbol string) bool      { return isIndexSymbol(symbol) }
+
+func (a *IndexListAdapter) Fetch(ctx context.Context, _ request.Client, req index.ListRequest) (index.ListResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var indexes []index.Index
+	seen := make(map[string]bool)
+	for _, market := range indexMarkets {
+		rows, record, err := a.client.GetIndexBasic(ctx, &tushare.IndexBasicParams{Market: market})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return index.ListResponse{}, trace, err
+		}
+		for _, idx := range indexBasicIndexes(rows) {
+			if !seen[idx.Symbol] {
+				seen[idx.Symbol] = true
+				indexes = append(indexes, idx)
+			}
+		}
+	}
+	page, total := req.Filter(indexes)
+
+	trace.Finish()
+	return index.ListResponse{
+		Indexes:    page,
+		Total:      total,
+		Source:     Name,
+		PageNumber: req.PageNumber,
+		PageSize:   req.PageSize,
+	}, trace, nil
+}
+
+// indexBasicIndexes 转换 index_basic 记录，只保留在沪深北交易所有行情的指数
+// (如 000300.SH)；.CSI、.SI 等仅由指数公司发布的代码无法在其他数据源查询，不计入。
+func indexBasicIndexes(rows []tushare.IndexBasicRow) []index.Index {
+	indexes := make([]index.Index, 0, len(rows))
+	for _, row := range rows {
+		var sym domain.Symbol
+		if err := sym.Parse(row.TSCode); err != nil || sym.AssetType != domain.AssetTypeIndex {
+			continue
+		}
+		indexes = append(indexes, index.Index{
+			Symbol:    domain.FormatSymbol(sym.Code, sym.Exchange),
+			Name:      row.Name,
+			FullName:  row.Fullname,
+			Exchange:  sym.Exchange,
+			Publisher: row.Publisher,
+			Category:  row.Category,
+			BaseDate:  parseCompactDate(row.BaseDate),
+			BasePoint: row.BasePoint,
+			ListDate:  parseCompactDate(row.ListDate),
+		})
+	}
+	return indexes
+}
+
+// IndexWeightsAdapter 将 Tushare 指数成分权重转换为统一的 index 域类型。
+type IndexWeightsAdapter struct {
+	client *tushare.Client
+}
+
+// NewIndexWeightsAdapter 创建 Tushare 指数权重适配器。
+func NewIndexWeightsAdapter(client *tushare.Client) *IndexWeightsAdapter {
+	return &IndexWeightsAdapter{client: client}
+}
+
+func (a *IndexWeightsAdapter) Name() string                      { return Name }
+func (a *IndexWeightsAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *IndexWeightsAdapter) Credentials() []manager.Credential { return credentials }
+func (a *IndexWeightsAdapter) CanHandle(symbol string) bool      { return isIndexSymbol(symbol) }
+
+func (a *IndexWeightsAdapter) Fetch(ctx context.Context, _ request.Client, req index.WeightsRequest) (index.WeightsResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	tsCode, err := tushare.ToTushareSymbol(req.Symbol)
+	if err != nil {
+		return index.WeightsResponse{}, trace, err
+	}
+	end := req.Date
+	if end.IsZero() {
+		end = time.Now()
+	}
+	end = end.In(timeLoc)
+
+	rows, record, err := a.client.GetIndexWeight(ctx, &tushare.IndexWeightParams{
+		IndexCode: tsCode,
+		StartDate: end.Add(-indexWeightLookback).Format("20060102"),
+		EndDate:   end.Format("20060102"),
+	})
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return index.WeightsResponse{}, trace, err
+	}
+
+	date, weights := latestWeights(rows)
+	trace.Finish()
+	if len(weights) == 0 {
+		return index.WeightsResponse{}, trace, fmt.Errorf("tushare: no index weights for %s before %s", req.Symbol, end.Format("2006-01-02"))
+	}
+	return index.WeightsResponse{
+		Symbol:  req.Symbol,
+		Date:    parseCompactDate(date),
+		Weights: weights,
+		Source:  Name,
+	}, trace, nil
+}
+
+// latestWeights 返回最近一期快照的日期及按权重降序排列的成分股。
+func latestWeights(rows []tushare.IndexWeightRow) (string, []index.Weight) {
+	latest := ""
+	for _, row := range rows {
+		if row.TradeDate > latest {
+			latest = row.TradeDate
+		}
+	}
+	var weights []index.Weight
+	for _, row := range rows {
+		if row.TradeDate == latest {
+			weights = append(weights, index.Weight{Symbol: row.ConCode, Weight: row.Weight})
+		}
+	}
+	sort.SliceStable(weights, func(i, j int) bool { return weights[i].Weight > weights[j].Weight })
+	return latest, weights
+}
+
+var (
+	_ manager.Provider[index.ListRequest, index.ListResponse]       = (*IndexListAdapter)(nil)
+	_ manager.Provider[index.WeightsRequest, index.WeightsResponse] = (*IndexWeightsAdapter)(nil)
+	_ manager.KeyedProvider                                         = (*IndexListAdapter)(nil)
+	_ manager.KeyedProvider                                         = (*IndexWeightsAdapter)(nil)
+)
@@ -0,0 +1,64 @@
+package tushare
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+)
+
+func TestNewIndexAdapters(t *testing.T) {
+	client := tushare.NewClient()
+
+	if a := NewIndexListAdapter(client); a.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, a.Name())
+	}
+
+	weights := NewIndexWeightsAdapter(client)
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"000300.SH", true},
+		{"399001.SZ", true},
+		{"000300.SZ", false},
+		{"600519.SH", false},
+	}
+	for _, tt := range tests {
+		if got := weights.CanHandle(tt.symbol); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestIndexBasicIndexes(t *testing.T) {
+	indexes := indexBasicIndexes([]tushare.IndexBasicRow{
+		{TSCode: "000300.SH", Name: "沪深300", Publisher: "中证指数有限公司", BaseDate: "20041231", BasePoint: 1000},
+		{TSCode: "399001.SZ", Name: "深证成指"},
+		{TSCode: "930050.CSI", Name: "中证A50"},
+	})
+	if len(indexes) != 2 {
+		t.Fatalf("len = %d, want 2", len(indexes))
+	}
+	if idx := indexes[0]; idx.Symbol != "000300.SH" || idx.Exchange != domain.ExchangeSH || idx.BasePoint != 1000 || idx.BaseDate.Year() != 2004 {
+		t.Errorf("indexes[0] = %+v", idx)
+	}
+	if indexes[1].Exchange != domain.ExchangeSZ {
+		t.Errorf("indexes[1] = %+v", indexes[1])
+	}
+}
+
+func TestLatestWeights(t *testing.T) {
+	date, weights := latestWeights([]tushare.IndexWeightRow{
+		{ConCode: "600519.SH", TradeDate: "20240131", Weight: 5.1},
+		{ConCode: "600519.SH", TradeDate: "20240229", Weight: 5.3},
+		{ConCode: "300750.SZ", TradeDate: "20240229", Weight: 3.2},
+		{ConCode: "601318.SH", TradeDate: "20240229", Weight: 4.8},
+	})
+	if date != "20240229" || len(weights) != 3 {
+		t.Fatalf("latestWeights() = %s, %v", date, weights)
+	}
+	if weights[0].Symbol != "600519.SH" || weights[2].Symbol != "300750.SZ" {
+		t.Errorf("weights not in descending order: %v", weights)
+	}
+}
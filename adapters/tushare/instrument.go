@@ -22,6 +22,7 @@ func NewInstrumentAdapter(client *tushare.Client) *InstrumentAdapter {
 
 func (a *InstrumentAdapter) Name() string                      { return Name }
 func (a *InstrumentAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *InstrumentAdapter) Credentials() []manager.Credential { return credentials }
 
 func (a *InstrumentAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
@@ -101,3 +102,4 @@ func (a *InstrumentAdapter) Fetch(ctx context.Context, _ request.Client, req ins
 }
 
 var _ manager.Provider[instrument.Request, instrument.Response] = (*InstrumentAdapter)(nil)
+var _ manager.KeyedProvider = (*InstrumentAdapter)(nil)
@@ -15,6 +15,9 @@ const Name = "tushare"
 // supportedMarkets 定义 Tushare 适配器支持的市场
 var supportedMarkets = []domain.Market{domain.MarketCN}
 
+// credentials 为本包所有适配器必需的凭证，缺少 Token 时不注册。
+var credentials = []manager.Credential{{Key: manager.CredentialToken, Env: tushare.EnvToken}}
+
 type KlineAdapter struct {
 	client *tushare.Client
 }
@@ -31,6 +34,10 @@ func (a *KlineAdapter) SupportedMarkets() []domain.Market {
 	return supportedMarkets
 }
 
+func (a *KlineAdapter) Credentials() []manager.Credential {
+	return credentials
+}
+
 func (a *KlineAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
 	if err := sym.Parse(symbol); err != nil {
@@ -53,6 +60,11 @@ func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Re
 		EndDate:   req.EndTime.Format("20060102"),
 		Period:    tushare.ToPeriod(string(req.Timeframe)),
 	}
+	// 指数 (如 000300.SH) 使用 index_daily 系列接口
+	var sym domain.Symbol
+	if err := sym.Parse(req.Symbol); err == nil && sym.AssetType == domain.AssetTypeIndex {
+		params.Index = true
+	}
 
 	result, record, err := a.client.GetKline(ctx, params)
 	trace.AddRequest(record)
@@ -85,3 +97,4 @@ func (a *KlineAdapter) Fetch(ctx context.Context, _ request.Client, req kline.Re
 }
 
 var _ manager.Provider[kline.Request, kline.Response] = (*KlineAdapter)(nil)
+var _ manager.KeyedProvider = (*KlineAdapter)(nil)
@@ -23,6 +23,7 @@ func NewProfileAdapter(client *tushare.Client) *ProfileAdapter {
 
 func (a *ProfileAdapter) Name() string                      { return Name }
 func (a *ProfileAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *ProfileAdapter) Credentials() []manager.Credential { return credentials }
 
 func (a *ProfileAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
@@ -146,3 +147,4 @@ func formatCompanyDate(yyyymmdd string) string {
 }
 
 var _ manager.Provider[profile.Request, profile.Response] = (*ProfileAdapter)(nil)
+var _ manager.KeyedProvider = (*ProfileAdapter)(nil)
@@ -0,0 +1,250 @@
+package tushare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/sector"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// isTushareSectorCode 判断是否为 Tushare 概念代码或行业、地域名称。
+// Tushare 的概念板块代码以 TS 开头 (如 TS2)；行业与地域板块没有代码，
+// 以 stock_basic 的 industry、area 名称 (如 "银行"、"深圳") 作为板块代码。
+// 这些板块只有分类信息，没有涨跌幅、资金流向等行情数据。
+func isTushareSectorCode(code string) bool {
+	if strings.HasPrefix(code, "TS") && len(code) > 2 {
+		return true
+	}
+	for _, r := range code {
+		if r >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// SectorListAdapter 将 Tushare 概念分类和股票行业、地域转换为统一的 sector 域类型。
+type SectorListAdapter struct {
+	client *tushare.Client
+}
+
+// NewSectorListAdapter 创建 Tushare 板块列表适配器。
+func NewSectorListAdapter(client *tushare.Client) *SectorListAdapter {
+	return &SectorListAdapter{client: client}
+}
+
+func (a *SectorListAdapter) Name() string                      { return Name }
+func (a *SectorListAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *SectorListAdapter) Credentials() []manager.Credential { return credentials }
+func (a *SectorListAdapter) CanHandle(code string) bool        { return isTushareSectorCode(code) }
+
+func (a *SectorListAdapter) Fetch(ctx context.Context, _ request.Client, req sector.ListRequest) (sector.ListResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var sectors []sector.Sector
+	var stocks []tushare.StockBasicRow
+	for _, kind := range req.Kinds() {
+		switch kind {
+		case sector.KindConcept:
+			rows, record, err := a.client.GetConcept(ctx, &tushare.ConceptParams{})
+			trace.AddRequest(record)
+			if err != nil {
+				trace.Finish()
+				return sector.ListResponse{}, trace, err
+			}
+			for _, row := range rows {
+				sectors = append(sectors, sector.Sector{Code: row.Code, Name: row.Name, Kind: sector.KindConcept})
+			}
+		case sector.KindIndustry, sector.KindRegion:
+			if stocks == nil {
+				rows, record, err := a.client.GetStockBasic(ctx, &tushare.StockBasicParams{Status: "L"})
+				trace.AddRequest(record)
+				if err != nil {
+					trace.Finish()
+					return sector.ListResponse{}, trace, err
+				}
+				stocks = rows
+			}
+			sectors = append(sectors, stockBasicSectors(stocks, kind)...)
+		default:
+			trace.Finish()
+			return sector.ListResponse{}, trace, fmt.Errorf("tushare: unsupported sector kind %q", kind)
+		}
+	}
+	page, total := req.Paginate(sectors)
+
+	trace.Finish()
+	return sector.ListResponse{
+		Sectors:    page,
+		Total:      total,
+		Source:     Name,
+		PageNumber: req.PageNumber,
+		PageSize:   req.PageSize,
+	}, trace, nil
+}
+
+// stockBasicSectors 按出现顺序汇总股票的行业或地域，生成板块列表。
+func stockBasicSectors(rows []tushare.StockBasicRow, kind sector.Kind) []sector.Sector {
+	seen := make(map[string]bool)
+	var sectors []sector.Sector
+	for _, row := range rows {
+		name := stockBasicSector(row, kind)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		sectors = append(sectors, sector.Sector{Code: name, Name: name, Kind: kind})
+	}
+	return sectors
+}
+
+// stockBasicSector 返回股票所属的行业或地域名称。
+func stockBasicSector(row tushare.StockBasicRow, kind sector.Kind) string {
+	if kind == sector.KindRegion {
+		return row.Area
+	}
+	return row.Industry
+}
+
+// SectorConstituentsAdapter 将 Tushare 概念明细及同行业、同地域股票转换为板块成分股。
+type SectorConstituentsAdapter struct {
+	client *tushare.Client
+}
+
+// NewSectorConstituentsAdapter 创建 Tushare 板块成分股适配器。
+func NewSectorConstituentsAdapter(client *tushare.Client) *SectorConstituentsAdapter {
+	return &SectorConstituentsAdapter{client: client}
+}
+
+func (a *SectorConstituentsAdapter) Name() string                      { return Name }
+func (a *SectorConstituentsAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *SectorConstituentsAdapter) Credentials() []manager.Credential { return credentials }
+
+// CanHandle 接受 Tushare 概念代码及行业、地域名称。
+func (a *SectorConstituentsAdapter) CanHandle(code string) bool { return isTushareSectorCode(code) }
+
+func (a *SectorConstituentsAdapter) Fetch(ctx context.Context, _ request.Client, req sector.ConstituentsRequest) (sector.ConstituentsResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	var constituents []sector.Constituent
+	if strings.HasPrefix(req.Code, "TS") {
+		rows, record, err := a.client.GetConceptDetail(ctx, &tushare.ConceptDetailParams{ID: req.Code})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return sector.ConstituentsResponse{}, trace, err
+		}
+		for _, row := range rows {
+			if row.OutDate != "" {
+				continue
+			}
+			constituents = append(constituents, sector.Constituent{
+				Symbol: row.TSCode,
+				Name:   row.Name,
+				InDate: parseCompactDate(row.InDate),
+			})
+		}
+	} else {
+		rows, record, err := a.client.GetStockBasic(ctx, &tushare.StockBasicParams{Status: "L"})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return sector.ConstituentsResponse{}, trace, err
+		}
+		for _, row := range rows {
+			if row.Industry == req.Code || row.Area == req.Code {
+				constituents = append(constituents, sector.Constituent{Symbol: row.TSCode, Name: row.Name})
+			}
+		}
+	}
+
+	trace.Finish()
+	return sector.ConstituentsResponse{
+		Code:         req.Code,
+		Constituents: constituents,
+		Source:       Name,
+	}, trace, nil
+}
+
+// SectorMembershipAdapter 查询股票所属的 Tushare 概念、行业和地域板块。
+type SectorMembershipAdapter struct {
+	client *tushare.Client
+}
+
+// NewSectorMembershipAdapter 创建 Tushare 所属板块适配器。
+func NewSectorMembershipAdapter(client *tushare.Client) *SectorMembershipAdapter {
+	return &SectorMembershipAdapter{client: client}
+}
+
+func (a *SectorMembershipAdapter) Name() string                      { return Name }
+func (a *SectorMembershipAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+func (a *SectorMembershipAdapter) Credentials() []manager.Credential { return credentials }
+
+func (a *SectorMembershipAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *SectorMembershipAdapter) Fetch(ctx context.Context, _ request.Client, req sector.MembershipRequest) (sector.MembershipResponse, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	tsCode, err := tushare.ToTushareSymbol(req.Symbol)
+	if err != nil {
+		return sector.MembershipResponse{}, trace, err
+	}
+
+	var sectors []sector.Sector
+	if req.Kind == "" || req.Kind == sector.KindIndustry || req.Kind == sector.KindRegion {
+		rows, record, err := a.client.GetStockBasic(ctx, &tushare.StockBasicParams{TSCode: tsCode})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return sector.MembershipResponse{}, trace, err
+		}
+		sectors = append(sectors, stockBasicSectors(rows, sector.KindIndustry)...)
+		sectors = append(sectors, stockBasicSectors(rows, sector.KindRegion)...)
+	}
+	if req.Kind == "" || req.Kind == sector.KindConcept {
+		rows, record, err := a.client.GetConceptDetail(ctx, &tushare.ConceptDetailParams{TSCode: tsCode})
+		trace.AddRequest(record)
+		if err != nil {
+			trace.Finish()
+			return sector.MembershipResponse{}, trace, err
+		}
+		for _, row := range rows {
+			if row.OutDate == "" {
+				sectors = append(sectors, sector.Sector{Code: row.ID, Name: row.ConceptName, Kind: sector.KindConcept})
+			}
+		}
+	}
+
+	trace.Finish()
+	return sector.MembershipResponse{
+		Symbol:  req.Symbol,
+		Sectors: req.Filter(sectors),
+		Source:  Name,
+	}, trace, nil
+}
+
+var (
+	_ manager.Provider[sector.ListRequest, sector.ListResponse]                 = (*SectorListAdapter)(nil)
+	_ manager.Provider[sector.ConstituentsRequest, sector.ConstituentsResponse] = (*SectorConstituentsAdapter)(nil)
+	_ manager.Provider[sector.MembershipRequest, sector.MembershipResponse]     = (*SectorMembershipAdapter)(nil)
+	_ manager.KeyedProvider                                                     = (*SectorListAdapter)(nil)
+	_ manager.KeyedProvider                                                     = (*SectorConstituentsAdapter)(nil)
+	_ manager.KeyedProvider                                                     = (*SectorMembershipAdapter)(nil)
+)
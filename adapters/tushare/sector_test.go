@@ -0,0 +1,53 @@
+package tushare
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/tushare"
+	"github.com/souloss/quantds/domain/sector"
+)
+
+func TestNewSectorAdapters(t *testing.T) {
+	client := tushare.NewClient()
+
+	if a := NewSectorListAdapter(client); a.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, a.Name())
+	}
+	if a := NewSectorMembershipAdapter(client); !a.CanHandle("600519.SH") || a.CanHandle("AAPL.US") {
+		t.Error("SectorMembershipAdapter should handle CN symbols only")
+	}
+
+	constituents := NewSectorConstituentsAdapter(client)
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"TS2", true},
+		{"银行", true},
+		{"BK0477", false},
+		{"TS", false},
+	}
+	for _, tt := range tests {
+		if got := constituents.CanHandle(tt.code); got != tt.want {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestStockBasicSectors(t *testing.T) {
+	rows := []tushare.StockBasicRow{
+		{TSCode: "000001.SZ", Industry: "银行", Area: "深圳"},
+		{TSCode: "600036.SH", Industry: "银行", Area: "深圳"},
+		{TSCode: "600519.SH", Industry: "白酒", Area: "贵州"},
+		{TSCode: "000002.SZ"},
+	}
+
+	industries := stockBasicSectors(rows, sector.KindIndustry)
+	if len(industries) != 2 || industries[0].Code != "银行" || industries[1].Kind != sector.KindIndustry {
+		t.Errorf("industries = %+v", industries)
+	}
+	regions := stockBasicSectors(rows, sector.KindRegion)
+	if len(regions) != 2 || regions[1].Name != "贵州" || regions[1].Kind != sector.KindRegion {
+		t.Errorf("regions = %+v", regions)
+	}
+}
@@ -31,6 +31,10 @@ func (a *SpotAdapter) SupportedMarkets() []domain.Market {
 	return supportedMarkets
 }
 
+func (a *SpotAdapter) Credentials() []manager.Credential {
+	return credentials
+}
+
 // CanHandle checks if the adapter can handle the symbol
 func (a *SpotAdapter) CanHandle(symbol string) bool {
 	var sym domain.Symbol
@@ -103,3 +107,4 @@ func (a *SpotAdapter) Fetch(ctx context.Context, _ request.Client, req spot.Requ
 }
 
 var _ manager.Provider[spot.Request, spot.Response] = (*SpotAdapter)(nil)
+var _ manager.KeyedProvider = (*SpotAdapter)(nil)
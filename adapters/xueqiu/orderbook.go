@@ -0,0 +1,77 @@
+package xueqiu
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/clients/xueqiu"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/orderbook"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// OrderBookAdapter adapts Xueqiu pankou data to a five-level order book
+type OrderBookAdapter struct {
+	client *xueqiu.Client
+}
+
+// NewOrderBookAdapter creates a new order book adapter
+func NewOrderBookAdapter(client *xueqiu.Client) *OrderBookAdapter {
+	return &OrderBookAdapter{client: client}
+}
+
+func (a *OrderBookAdapter) Name() string                      { return Name }
+func (a *OrderBookAdapter) SupportedMarkets() []domain.Market { return supportedMarkets }
+
+func (a *OrderBookAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *OrderBookAdapter) Fetch(ctx context.Context, _ request.Client, req orderbook.Request) (orderbook.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	data, record, err := a.client.GetPankou(ctx, req.Symbol)
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return orderbook.Response{}, trace, err
+	}
+
+	trace.Finish()
+	return orderbook.Response{
+		Book:   pankouBook(req.Symbol, data, req.Depth),
+		Source: Name,
+	}, trace, nil
+}
+
+// pankouBook converts the five bid (bp/bc) and ask (sp/sc) levels of a
+// pankou. Empty levels, e.g. the ask side at limit up, are dropped.
+func pankouBook(symbol string, d *xueqiu.PankouData, depth int) orderbook.Book {
+	bids := []orderbook.Level{
+		{Price: d.Bp1, Volume: d.Bc1},
+		{Price: d.Bp2, Volume: d.Bc2},
+		{Price: d.Bp3, Volume: d.Bc3},
+		{Price: d.Bp4, Volume: d.Bc4},
+		{Price: d.Bp5, Volume: d.Bc5},
+	}
+	asks := []orderbook.Level{
+		{Price: d.Sp1, Volume: d.Sc1},
+		{Price: d.Sp2, Volume: d.Sc2},
+		{Price: d.Sp3, Volume: d.Sc3},
+		{Price: d.Sp4, Volume: d.Sc4},
+		{Price: d.Sp5, Volume: d.Sc5},
+	}
+	return orderbook.NewBook(symbol, bids, asks, time.UnixMilli(d.Timestamp), depth)
+}
+
+var _ manager.Provider[orderbook.Request, orderbook.Response] = (*OrderBookAdapter)(nil)
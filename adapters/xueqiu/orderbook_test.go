@@ -0,0 +1,38 @@
+package xueqiu
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/xueqiu"
+)
+
+func TestOrderBookAdapter_CanHandle(t *testing.T) {
+	adapter := NewOrderBookAdapter(xueqiu.NewClient())
+
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+	if !adapter.CanHandle("600519.SH") || adapter.CanHandle("BTCUSDT") {
+		t.Error("CanHandle() should accept CN symbols only")
+	}
+}
+
+func TestPankouBook(t *testing.T) {
+	// Limit up: no sellers
+	d := &xueqiu.PankouData{
+		Timestamp: 1700000000000,
+		Bp1:       11.00, Bc1: 120000,
+		Bp2: 10.99, Bc2: 300,
+	}
+
+	book := pankouBook("000001.SZ", d, 0)
+	if len(book.Bids) != 2 || len(book.Asks) != 0 {
+		t.Fatalf("book = %+v, want 2 bids and no asks", book)
+	}
+	if book.Bids[0].Price != 11.00 || book.Timestamp.UnixMilli() != d.Timestamp {
+		t.Errorf("book = %+v", book)
+	}
+	if book.Spread() != 0 {
+		t.Errorf("Spread() = %v, want 0 for a one-sided book", book.Spread())
+	}
+}
@@ -0,0 +1,112 @@
+package yahoo
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/clients/yahoo"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/corpaction"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// CorpActionAdapter adapts Yahoo Finance dividend and split events. Yahoo
+// reports only the ex-date of each event.
+type CorpActionAdapter struct {
+	client *yahoo.Client
+}
+
+// NewCorpActionAdapter creates a new corporate action adapter
+func NewCorpActionAdapter(client *yahoo.Client) *CorpActionAdapter {
+	return &CorpActionAdapter{client: client}
+}
+
+// Name returns the adapter name
+func (a *CorpActionAdapter) Name() string {
+	return Name
+}
+
+// SupportedMarkets returns supported markets
+func (a *CorpActionAdapter) SupportedMarkets() []domain.Market {
+	return supportedMarkets
+}
+
+// CanHandle checks if the adapter can handle the symbol
+func (a *CorpActionAdapter) CanHandle(symbol string) bool {
+	var sym domain.Symbol
+	if err := sym.Parse(symbol); err != nil {
+		return false
+	}
+	for _, m := range supportedMarkets {
+		if sym.Market == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch retrieves dividends and splits
+func (a *CorpActionAdapter) Fetch(ctx context.Context, _ request.Client, req corpaction.Request) (corpaction.Response, *manager.RequestTrace, error) {
+	trace := manager.NewRequestTrace(Name)
+
+	symbol, err := yahoo.ToYahooSymbol(req.Symbol)
+	if err != nil {
+		return corpaction.Response{}, trace, err
+	}
+
+	params := &yahoo.EventsParams{Symbol: symbol}
+	if !req.StartTime.IsZero() && !req.EndTime.IsZero() {
+		params.StartDate = req.StartTime
+		// period2 is exclusive
+		params.EndDate = req.EndTime.AddDate(0, 0, 1)
+	}
+
+	result, record, err := a.client.GetEvents(ctx, params)
+	trace.AddRequest(record)
+	if err != nil {
+		trace.Finish()
+		return corpaction.Response{}, trace, err
+	}
+
+	trace.Finish()
+	return corpaction.Response{
+		Symbol:  req.Symbol,
+		Actions: req.Filter(eventActions(req.Symbol, result)),
+		Source:  Name,
+	}, trace, nil
+}
+
+// eventActions converts Yahoo events into corporate actions
+func eventActions(symbol string, result *yahoo.EventsResult) []corpaction.Action {
+	actions := make([]corpaction.Action, 0, len(result.Dividends)+len(result.Splits))
+	for _, d := range result.Dividends {
+		actions = append(actions, corpaction.Action{
+			Symbol: symbol,
+			Type:   corpaction.ActionCashDividend,
+			ExDate: exDate(d.Date, result.Timezone),
+			Cash:   d.Amount,
+		})
+	}
+	for _, s := range result.Splits {
+		if s.Denominator == 0 {
+			continue
+		}
+		actions = append(actions, corpaction.Action{
+			Symbol: symbol,
+			Type:   corpaction.ActionSplit,
+			ExDate: exDate(s.Date, result.Timezone),
+			Ratio:  s.Numerator / s.Denominator,
+		})
+	}
+	return actions
+}
+
+// exDate truncates an event timestamp, which Yahoo sets to the market open,
+// to midnight in the exchange timezone
+func exDate(ts int64, tz string) time.Time {
+	t := yahoo.ParseTimestamp(ts, tz)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+var _ manager.Provider[corpaction.Request, corpaction.Response] = (*CorpActionAdapter)(nil)
@@ -0,0 +1,59 @@
+package yahoo
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/clients/yahoo"
+	"github.com/souloss/quantds/domain/corpaction"
+)
+
+func TestNewCorpActionAdapter(t *testing.T) {
+	adapter := NewCorpActionAdapter(yahoo.NewClient())
+
+	if adapter == nil {
+		t.Fatal("NewCorpActionAdapter returned nil")
+	}
+	if adapter.Name() != Name {
+		t.Errorf("Expected name '%s', got '%s'", Name, adapter.Name())
+	}
+}
+
+func TestCorpActionAdapter_CanHandle(t *testing.T) {
+	adapter := NewCorpActionAdapter(yahoo.NewClient())
+
+	tests := []struct {
+		symbol    string
+		canHandle bool
+	}{
+		{"AAPL.US", true},
+		{"000001.SZ", false},
+		{"BTCUSDT", false},
+	}
+	for _, tt := range tests {
+		if got := adapter.CanHandle(tt.symbol); got != tt.canHandle {
+			t.Errorf("CanHandle(%s) = %v, want %v", tt.symbol, got, tt.canHandle)
+		}
+	}
+}
+
+func TestEventActions(t *testing.T) {
+	result := &yahoo.EventsResult{
+		Timezone:  "America/New_York",
+		Dividends: []yahoo.DividendEvent{{Date: 1699540200, Amount: 0.24}},
+		Splits:    []yahoo.SplitEvent{{Date: 1598880600, Numerator: 4, Denominator: 1}},
+	}
+
+	actions := eventActions("AAPL.US", result)
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %d", len(actions))
+	}
+	if actions[0].Type != corpaction.ActionCashDividend || actions[0].Cash != 0.24 {
+		t.Errorf("unexpected dividend: %+v", actions[0])
+	}
+	if actions[0].ExDate.Format("2006-01-02") != "2023-11-09" || actions[0].ExDate.Hour() != 0 {
+		t.Errorf("unexpected dividend ex-date: %v", actions[0].ExDate)
+	}
+	if actions[1].Type != corpaction.ActionSplit || actions[1].ShareMultiplier() != 4 {
+		t.Errorf("unexpected split: %+v", actions[1])
+	}
+}
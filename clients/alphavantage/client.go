@@ -1,7 +1,9 @@
 package alphavantage
 
 import (
+	"encoding/json"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go/timeout"
@@ -13,6 +15,9 @@ const (
 	QueryAPI = "/query"
 )
 
+// EnvAPIKey is the environment variable NewClient reads the API key from.
+const EnvAPIKey = "ALPHAVANTAGE_API_KEY"
+
 var DefaultHeaders = map[string]string{
 	"User-Agent": "quantds/1.0",
 	"Accept":     "application/json",
@@ -31,7 +36,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 
 func WithTimeout(d time.Duration) Option {
 	return func(c *Client) {
-		c.http = request.NewClient(request.DefaultConfig(
+		c.http = request.NewClient(DefaultConfig(
 			request.WithTimeout(timeout.New[request.Response](d)),
 		))
 	}
@@ -45,10 +50,21 @@ func WithAPIKey(key string) Option {
 	return func(c *Client) { c.apiKey = key }
 }
 
+// RateLimitPerMinute is the free tier limit of requests per minute.
+const RateLimitPerMinute = 5
+
+// DefaultConfig returns the request configuration NewClient uses: the
+// request defaults with requests spread evenly within RateLimitPerMinute.
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+	return request.DefaultConfig(append([]request.ConfigOption{
+		request.WithRateLimiter(request.SmoothRateLimiter(RateLimitPerMinute, time.Minute, request.DefaultRateLimitWait)),
+	}, opts...)...)
+}
+
 func NewClient(opts ...Option) *Client {
 	c := &Client{
-		http:   request.NewClient(request.DefaultConfig()),
-		apiKey: os.Getenv("ALPHAVANTAGE_API_KEY"),
+		http:   request.NewClient(DefaultConfig()),
+		apiKey: os.Getenv(EnvAPIKey),
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -57,3 +73,48 @@ func NewClient(opts ...Option) *Client {
 }
 
 func (c *Client) Close() { c.http.Close() }
+
+const vendor = "alphavantage"
+
+// checkResponse reports the messages Alpha Vantage answers with instead of
+// data, all with status 200.
+func checkResponse(resp request.Response) error {
+	return checkBody(resp.Body)
+}
+
+// checkBody maps the message fields of an Alpha Vantage response:
+//
+//   - Error Message: an invalid call, which for a valid function means an
+//     unknown symbol, or a missing or invalid apikey
+//   - Note: the per minute call frequency was exceeded
+//   - Information: the daily limit was reached, or the key does not cover
+//     a premium endpoint
+func checkBody(body []byte) error {
+	var msg struct {
+		ErrorMessage string `json:"Error Message"`
+		Note         string `json:"Note"`
+		Information  string `json:"Information"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil
+	}
+	switch {
+	case msg.ErrorMessage != "":
+		if strings.Contains(strings.ToLower(msg.ErrorMessage), "apikey") {
+			return request.NewVendorError(vendor, request.ErrorTypeAuth, "", msg.ErrorMessage)
+		}
+		return request.NewVendorError(vendor, request.ErrorTypeInvalidSymbol, "", msg.ErrorMessage)
+	case msg.Note != "":
+		return request.NewVendorError(vendor, request.ErrorTypeRateLimited, "", "rate limit: "+msg.Note)
+	case msg.Information != "":
+		info := strings.ToLower(msg.Information)
+		switch {
+		case strings.Contains(info, "per day") || strings.Contains(info, "rate limit"):
+			return request.NewVendorError(vendor, request.ErrorTypeQuota, "", msg.Information)
+		case strings.Contains(info, "premium") || strings.Contains(info, "api key") || strings.Contains(info, "apikey"):
+			return request.NewVendorError(vendor, request.ErrorTypeAuth, "", msg.Information)
+		}
+		return request.NewVendorError(vendor, request.ErrorTypeUnknown, "", msg.Information)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package alphavantage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/souloss/quantds/request"
+)
+
+func TestCheckBody(t *testing.T) {
+	tests := []struct {
+		body string
+		want error
+	}{
+		{`{"Global Quote": {"01. symbol": "IBM"}}`, nil},
+		{`symbol,open`, nil},
+		{`{"Error Message": "Invalid API call. Please retry or visit the documentation for TIME_SERIES_DAILY."}`, request.ErrInvalidSymbol},
+		{`{"Error Message": "the parameter apikey is invalid or missing."}`, request.ErrAuth},
+		{`{"Note": "Our standard API call frequency is 5 calls per minute and 500 calls per day."}`, request.ErrRateLimited},
+		{`{"Information": "Our standard API rate limit is 25 requests per day."}`, request.ErrQuota},
+		{`{"Information": "Thank you for using Alpha Vantage! This is a premium endpoint."}`, request.ErrAuth},
+	}
+	for _, tt := range tests {
+		err := checkBody([]byte(tt.body))
+		if (tt.want == nil && err != nil) || (tt.want != nil && !errors.Is(err, tt.want)) {
+			t.Errorf("checkBody(%s) = %v, want %v", tt.body, err, tt.want)
+		}
+	}
+
+	if _, err := parseGlobalQuoteResponse([]byte(`{"Global Quote": {}}`)); !errors.Is(err, request.ErrNoData) {
+		t.Errorf("parseGlobalQuoteResponse(empty) error = %v, want %v", err, request.ErrNoData)
+	}
+}
@@ -31,6 +31,7 @@ func (c *Client) GetForexExchangeRate(ctx context.Context, params *ForexRatePara
 		Method:  "GET",
 		URL:     url,
 		Headers: DefaultHeaders,
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
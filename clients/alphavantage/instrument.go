@@ -36,6 +36,7 @@ func (c *Client) SearchSymbol(ctx context.Context, params *SearchParams) (*Searc
 		Method:  "GET",
 		URL:     url,
 		Headers: DefaultHeaders,
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
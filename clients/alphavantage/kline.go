@@ -50,6 +50,7 @@ func (c *Client) GetDailyTimeSeries(ctx context.Context, params *KlineParams) (*
 		Method:  "GET",
 		URL:     url,
 		Headers: DefaultHeaders,
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -75,11 +76,8 @@ func parseDailyResponse(body []byte, symbol string) (*KlineResult, error) {
 		return nil, err
 	}
 
-	if errMsg, ok := raw["Error Message"]; ok {
-		return nil, fmt.Errorf("API error: %s", string(errMsg))
-	}
-	if note, ok := raw["Note"]; ok {
-		return nil, fmt.Errorf("API rate limit: %s", string(note))
+	if err := checkBody(body); err != nil {
+		return nil, err
 	}
 
 	var tsKey string
@@ -34,6 +34,7 @@ func (c *Client) GetQuote(ctx context.Context, params *QuoteParams) (*QuoteResul
 		Method:  "GET",
 		URL:     url,
 		Headers: DefaultHeaders,
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -63,6 +64,14 @@ func parseGlobalQuoteResponse(body []byte) (*QuoteResult, error) {
 		return nil, err
 	}
 
+	if err := checkBody(body); err != nil {
+		return nil, err
+	}
+	// unknown symbols are answered with an empty Global Quote
+	if len(resp.GlobalQuote) == 0 {
+		return nil, request.NewVendorError(vendor, request.ErrorTypeNoData, "", "empty Global Quote")
+	}
+
 	q := resp.GlobalQuote
 	open, _ := strconv.ParseFloat(q["02. open"], 64)
 	high, _ := strconv.ParseFloat(q["03. high"], 64)
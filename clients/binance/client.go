@@ -15,8 +15,7 @@ package binance
 //   - Multiple periods: 1m, 3m, 5m, 15m, 30m, 1h, 2h, 4h, 6h, 8h, 12h, 1d, 3d, 1w, 1M
 //
 // Limitations:
-//   - Rate limiting: 1200 requests per minute for public endpoints
-//   - Weight-based rate limiting for some endpoints
+//   - Rate limiting: 6000 request weight per minute per IP, see RequestWeight
 //   - APIs may change without notice
 //
 // Example:
@@ -78,9 +77,10 @@ const (
 
 // Rate limit constants
 const (
-        RateLimitPerMinute = 1200
-        MaxKlineLimit      = 1000
-        MaxDepthLimit      = 5000
+        RateLimitPerMinute   = 1200
+        WeightLimitPerMinute = 6000 // REQUEST_WEIGHT limit per IP
+        MaxKlineLimit        = 1000
+        MaxDepthLimit        = 5000
 )
 
 // Client is the Binance API client
@@ -101,7 +101,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 // WithTimeout sets the request timeout
 func WithTimeout(d time.Duration) Option {
         return func(c *Client) {
-                c.http = request.NewClient(request.DefaultConfig(
+                c.http = request.NewClient(DefaultConfig(
                         request.WithTimeout(timeout.New[request.Response](d)),
                 ))
         }
@@ -114,11 +114,23 @@ func WithConfig(cfg *request.Config) Option {
         }
 }
 
+// DefaultConfig returns the request configuration NewClient uses: the
+// request defaults limited to WeightLimitPerMinute, with each request
+// weighted by RequestWeight and the budget tracked from the
+// X-MBX-USED-WEIGHT-1M response header.
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+        return request.DefaultConfig(append([]request.ConfigOption{
+                request.WithRateLimiter(request.BurstyRateLimiter(WeightLimitPerMinute, time.Minute, request.DefaultRateLimitWait)),
+                request.WithWeight(RequestWeight),
+                request.WithUsageLimit(WeightLimitPerMinute),
+        }, opts...)...)
+}
+
 // NewClient creates a new Binance client
 // If no options are provided, it uses the default configuration
 func NewClient(opts ...Option) *Client {
         c := &Client{
-                http: request.NewClient(request.DefaultConfig()),
+                http: request.NewClient(DefaultConfig()),
         }
         for _, opt := range opts {
                 opt(c)
@@ -0,0 +1,98 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/souloss/quantds/request"
+)
+
+// DepthParams represents parameters for order book depth request
+type DepthParams struct {
+	Symbol string // Trading pair symbol (e.g., "BTCUSDT")
+	Limit  int    // Levels per side (max 5000, default 100)
+}
+
+// DepthResult represents the order book depth result
+type DepthResult struct {
+	Symbol       string       // Trading pair symbol
+	LastUpdateID int64        // Order book update ID
+	Bids         []DepthLevel // Bid levels, best first
+	Asks         []DepthLevel // Ask levels, best first
+}
+
+// DepthLevel represents a single price level of the order book
+type DepthLevel struct {
+	Price    float64 // Price
+	Quantity float64 // Quantity (base asset)
+}
+
+// GetDepth retrieves the order book of a trading pair
+func (c *Client) GetDepth(ctx context.Context, params *DepthParams) (*DepthResult, *request.Record, error) {
+	if params.Symbol == "" {
+		return nil, nil, fmt.Errorf("symbol is required")
+	}
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+	if params.Limit > MaxDepthLimit {
+		params.Limit = MaxDepthLimit
+	}
+
+	url := fmt.Sprintf("%s%s?symbol=%s&limit=%d", BaseURL, DepthAPI, params.Symbol, params.Limit)
+
+	req := request.Request{
+		Method:  "GET",
+		URL:     url,
+		Headers: DefaultHeaders,
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	result, err := parseDepthResponse(resp.Body, params.Symbol)
+	if err != nil {
+		return nil, record, err
+	}
+
+	return result, record, nil
+}
+
+// Binance returns depth levels as [price, quantity] string pairs
+type binanceDepthResponse struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+func parseDepthResponse(body []byte, symbol string) (*DepthResult, error) {
+	var resp binanceDepthResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &DepthResult{
+		Symbol:       symbol,
+		LastUpdateID: resp.LastUpdateID,
+		Bids:         depthLevels(resp.Bids),
+		Asks:         depthLevels(resp.Asks),
+	}, nil
+}
+
+func depthLevels(pairs [][2]string) []DepthLevel {
+	levels := make([]DepthLevel, 0, len(pairs))
+	for _, p := range pairs {
+		levels = append(levels, DepthLevel{
+			Price:    parseFloat(p[0]),
+			Quantity: parseFloat(p[1]),
+		})
+	}
+	return levels
+}
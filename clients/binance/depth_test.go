@@ -0,0 +1,45 @@
+package binance
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_GetDepth(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	result, _, err := client.GetDepth(ctx, &DepthParams{
+		Symbol: "BTCUSDT",
+		Limit:  5,
+	})
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("BTCUSDT depth: %d bids, %d asks", len(result.Bids), len(result.Asks))
+
+	if len(result.Bids) == 0 || len(result.Asks) == 0 {
+		t.Fatal("Expected bids and asks")
+	}
+	if result.Bids[0].Price >= result.Asks[0].Price {
+		t.Errorf("Best bid %f should be below best ask %f", result.Bids[0].Price, result.Asks[0].Price)
+	}
+}
+
+func TestParseDepthResponse(t *testing.T) {
+	body := []byte(`{"lastUpdateId":1027024,"bids":[["4.00000000","431.00000000"]],"asks":[["4.00000200","12.00000000"]]}`)
+
+	result, err := parseDepthResponse(body, "BNBBTC")
+	if err != nil {
+		t.Fatalf("parseDepthResponse() error = %v", err)
+	}
+	if result.LastUpdateID != 1027024 || len(result.Bids) != 1 || len(result.Asks) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Bids[0] != (DepthLevel{Price: 4, Quantity: 431}) {
+		t.Errorf("Bids[0] = %+v", result.Bids[0])
+	}
+}
@@ -0,0 +1,50 @@
+package binance
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/souloss/quantds/request"
+)
+
+// RequestWeight returns the request weight Binance charges for req against
+// the REQUEST_WEIGHT limit, following the weights documented per endpoint.
+// Unknown endpoints weigh 1.
+func RequestWeight(req request.Request) uint {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return 1
+	}
+	q := u.Query()
+	switch strings.TrimSuffix(u.Path, "/") {
+	case KlineAPI:
+		return 2
+	case DepthAPI:
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		switch {
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 25
+		case limit <= 1000:
+			return 50
+		default:
+			return 250
+		}
+	case Ticker24hrAPI:
+		if q.Get("symbol") != "" {
+			return 2
+		}
+		return 80
+	case TickerPriceAPI:
+		if q.Get("symbol") != "" {
+			return 2
+		}
+		return 4
+	case ExchangeInfoAPI:
+		return 20
+	default:
+		return 1
+	}
+}
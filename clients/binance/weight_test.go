@@ -0,0 +1,29 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/request"
+)
+
+func TestRequestWeight(t *testing.T) {
+	tests := []struct {
+		url  string
+		want uint
+	}{
+		{BaseURL + KlineAPI + "?symbol=BTCUSDT&interval=1d&limit=500", 2},
+		{BaseURL + DepthAPI + "?symbol=BTCUSDT&limit=20", 5},
+		{BaseURL + DepthAPI + "?symbol=BTCUSDT&limit=500", 25},
+		{BaseURL + DepthAPI + "?symbol=BTCUSDT&limit=5000", 250},
+		{BaseURL + Ticker24hrAPI + "?symbol=BTCUSDT", 2},
+		{BaseURL + Ticker24hrAPI, 80},
+		{BaseURL + TickerPriceAPI, 4},
+		{BaseURL + ExchangeInfoAPI, 20},
+		{BaseURL + "/api/v3/ping", 1},
+	}
+	for _, tt := range tests {
+		if got := RequestWeight(request.Request{Method: "GET", URL: tt.url}); got != tt.want {
+			t.Errorf("RequestWeight(%s) = %d, want %d", tt.url, got, tt.want)
+		}
+	}
+}
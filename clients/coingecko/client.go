@@ -27,7 +27,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 // WithTimeout sets the request timeout
 func WithTimeout(d time.Duration) Option {
         return func(c *Client) {
-                c.http = request.NewClient(request.DefaultConfig(
+                c.http = request.NewClient(DefaultConfig(
                         request.WithTimeout(timeout.New[request.Response](d)),
                 ))
         }
@@ -40,11 +40,23 @@ func WithConfig(cfg *request.Config) Option {
         }
 }
 
+// RateLimitPerMinute is a conservative limit for the keyless public API,
+// which allows between 5 and 30 calls per minute depending on load.
+const RateLimitPerMinute = 10
+
+// DefaultConfig returns the request configuration NewClient uses: the
+// request defaults limited to RateLimitPerMinute.
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+        return request.DefaultConfig(append([]request.ConfigOption{
+                request.WithRateLimiter(request.BurstyRateLimiter(RateLimitPerMinute, time.Minute, request.DefaultRateLimitWait)),
+        }, opts...)...)
+}
+
 // NewClient creates a new CoinGecko client
 // If no options are provided, it uses the default configuration
 func NewClient(opts ...Option) *Client {
         c := &Client{
-                http: request.NewClient(request.DefaultConfig()),
+                http: request.NewClient(DefaultConfig()),
         }
         for _, opt := range opts {
                 opt(c)
@@ -42,6 +42,8 @@
 package eastmoney
 
 import (
+        "encoding/json"
+        "strconv"
         "time"
 
         "github.com/failsafe-go/failsafe-go/timeout"
@@ -98,3 +100,38 @@ func NewClient(opts ...Option) *Client {
 func (c *Client) Close() {
         c.http.Close()
 }
+
+const vendor = "eastmoney"
+
+// pushEnvelope is the envelope of the push2 and push2his quote APIs.
+type pushEnvelope struct {
+        RC   int             `json:"rc"`
+        Data json.RawMessage `json:"data"`
+}
+
+// checkResponse reports an rc other than 0 in the envelope of the push2
+// list APIs, whose data is null for an empty page.
+func checkResponse(resp request.Response) error {
+        var env pushEnvelope
+        if err := json.Unmarshal(resp.Body, &env); err != nil || env.RC == 0 {
+                return nil
+        }
+        return request.NewVendorError(vendor, request.ErrorTypeUnknown, strconv.Itoa(env.RC), "request rejected")
+}
+
+// checkSecurity is checkResponse for the push2 APIs of a single security,
+// which answer data: null when they have nothing for the secid, e.g. an
+// unknown or delisted security.
+func checkSecurity(resp request.Response) error {
+        var env pushEnvelope
+        if err := json.Unmarshal(resp.Body, &env); err != nil {
+                return nil
+        }
+        if env.RC != 0 {
+                return request.NewVendorError(vendor, request.ErrorTypeUnknown, strconv.Itoa(env.RC), "request rejected")
+        }
+        if len(env.Data) == 0 || string(env.Data) == "null" {
+                return request.NewVendorError(vendor, request.ErrorTypeNoData, "", "data is null")
+        }
+        return nil
+}
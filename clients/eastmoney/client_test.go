@@ -0,0 +1,30 @@
+package eastmoney
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/souloss/quantds/request"
+)
+
+func TestCheckSecurity(t *testing.T) {
+	tests := []struct {
+		body      string
+		want      error
+		wantCheck error
+	}{
+		{`{"rc": 0, "data": {"klines": []}}`, nil, nil},
+		{`{"rc": 0, "data": null}`, request.ErrNoData, nil},
+		{`{"rc": 102, "data": null}`, request.NewError(request.ErrorTypeUnknown, "", nil), request.NewError(request.ErrorTypeUnknown, "", nil)},
+		{`jQuery({"rc": 0})`, nil, nil},
+	}
+	for _, tt := range tests {
+		resp := request.Response{StatusCode: 200, Body: []byte(tt.body)}
+		if err := checkSecurity(resp); (tt.want == nil && err != nil) || (tt.want != nil && !errors.Is(err, tt.want)) {
+			t.Errorf("checkSecurity(%s) = %v, want %v", tt.body, err, tt.want)
+		}
+		if err := checkResponse(resp); (tt.wantCheck == nil && err != nil) || (tt.wantCheck != nil && !errors.Is(err, tt.wantCheck)) {
+			t.Errorf("checkResponse(%s) = %v, want %v", tt.body, err, tt.wantCheck)
+		}
+	}
+}
@@ -12,12 +12,30 @@ import (
 
 const (
 	ConceptListAPI = "/api/qt/clist/get"
+	StockBoardsAPI = "/api/qt/slist/get"
+)
+
+// Board types of the sector list
+const (
+	BoardTypeRegion   = "1" // 地域板块
+	BoardTypeIndustry = "2" // 行业板块
+	BoardTypeConcept  = "3" // 概念板块
+)
+
+// Sort fields of the board and constituent lists. The lists are sorted in
+// descending order; page through them by SortByCode, as the change percent
+// moves between requests and pages would overlap.
+const (
+	SortByChange = "f3"  // 涨跌幅
+	SortByCode   = "f12" // 代码
 )
 
 // ConceptListParams represents parameters for concept list request
 type ConceptListParams struct {
-	PageSize int
-	PageNo   int
+	BoardType string // Defaults to BoardTypeConcept
+	SortBy    string // Defaults to SortByChange
+	PageSize  int
+	PageNo    int
 }
 
 // ConceptItem represents a concept/sector
@@ -32,12 +50,20 @@ type ConceptItem struct {
 // ConceptStocksParams represents parameters for stocks in a concept
 type ConceptStocksParams struct {
 	ConceptCode string
+	SortBy      string // Defaults to SortByChange
 	PageSize    int
 	PageNo      int
 }
 
-// GetConceptList retrieves list of concepts (themes)
+// GetConceptList retrieves list of concepts (themes), or of the industry or
+// region boards selected by BoardType
 func (c *Client) GetConceptList(ctx context.Context, params *ConceptListParams) ([]ConceptItem, *request.Record, error) {
+	if params.BoardType == "" {
+		params.BoardType = BoardTypeConcept
+	}
+	if params.SortBy == "" {
+		params.SortBy = SortByChange
+	}
 	if params.PageSize <= 0 {
 		params.PageSize = 100
 	}
@@ -52,8 +78,8 @@ func (c *Client) GetConceptList(ctx context.Context, params *ConceptListParams)
 	query.Set("np", "1")
 	query.Set("fltt", "2")
 	query.Set("invt", "2")
-	query.Set("fid", "f3")
-	query.Set("fs", "m:90+t:2+f:!50") // Concept board
+	query.Set("fid", params.SortBy)
+	query.Set("fs", "m:90+t:"+params.BoardType+"+f:!50")
 	query.Set("fields", "f12,f14,f3,f62,f20") // Code, Name, Change%, NetInflow, MarketCap
 
 	url := fmt.Sprintf("%s%s?%s", PushURL, ConceptListAPI, query.Encode())
@@ -65,6 +91,7 @@ func (c *Client) GetConceptList(ctx context.Context, params *ConceptListParams)
 			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 			"Referer":    "https://quote.eastmoney.com/",
 		},
+		Check: checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -112,11 +139,62 @@ func parseConceptListResponse(body []byte) ([]ConceptItem, error) {
 	return items, nil
 }
 
+// GetStockBoards retrieves the industry, region and concept boards a stock
+// belongs to
+func (c *Client) GetStockBoards(ctx context.Context, symbol string) ([]ConceptItem, *request.Record, error) {
+	secid, err := toEastMoneySecid(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := url.Values{}
+	query.Set("spt", "3")
+	query.Set("secid", secid)
+	query.Set("pi", "0")
+	query.Set("pz", "500")
+	query.Set("po", "1")
+	query.Set("np", "1")
+	query.Set("fltt", "2")
+	query.Set("invt", "2")
+	query.Set("fid", "f3")
+	query.Set("fields", "f12,f14,f3,f62,f20")
+
+	url := fmt.Sprintf("%s%s?%s", PushURL, StockBoardsAPI, query.Encode())
+
+	req := request.Request{
+		Method: "GET",
+		URL:    url,
+		Headers: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
+			"Referer":    "https://quote.eastmoney.com/",
+		},
+		Check: checkResponse,
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	items, err := parseConceptListResponse(resp.Body)
+	if err != nil {
+		return nil, record, err
+	}
+	return items, record, nil
+}
+
 // GetConceptStocks retrieves stocks in a concept
 func (c *Client) GetConceptStocks(ctx context.Context, params *ConceptStocksParams) ([]QuoteData, *request.Record, error) {
 	if params.ConceptCode == "" {
 		return nil, nil, fmt.Errorf("concept code required")
 	}
+	if params.SortBy == "" {
+		params.SortBy = SortByChange
+	}
 	if params.PageSize <= 0 {
 		params.PageSize = 100
 	}
@@ -131,7 +209,7 @@ func (c *Client) GetConceptStocks(ctx context.Context, params *ConceptStocksPara
 	query.Set("np", "1")
 	query.Set("fltt", "2")
 	query.Set("invt", "2")
-	query.Set("fid", "f3")
+	query.Set("fid", params.SortBy)
 	query.Set("fs", fmt.Sprintf("b:%s", params.ConceptCode))
 	query.Set("fields", QuoteFields) // Use QuoteFields from quote.go
 
@@ -144,6 +222,7 @@ func (c *Client) GetConceptStocks(ctx context.Context, params *ConceptStocksPara
 			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 			"Referer":    "https://quote.eastmoney.com/",
 		},
+		Check: checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
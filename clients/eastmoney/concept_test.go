@@ -79,3 +79,21 @@ func TestClient_GetConceptStocks(t *testing.T) {
 			i, stock.Code, stock.Name, stock.Latest, stock.ChangeRate)
 	}
 }
+
+// TestClient_GetStockBoards tests retrieving the boards of a stock
+func TestClient_GetStockBoards(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	result, _, err := client.GetStockBoards(ctx, "600519.SH")
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("Got %d boards for 600519.SH", len(result))
+	for i, board := range result {
+		t.Logf("Board[%d]: code=%s, name=%s, change=%.2f%%", i, board.Code, board.Name, board.ChangePercent)
+	}
+}
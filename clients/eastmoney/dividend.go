@@ -0,0 +1,189 @@
+package eastmoney
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/souloss/quantds/request"
+)
+
+// ReportDividend is the datacenter report of dividend and bonus share plans (分红送配)
+const ReportDividend = "RPT_SHAREBONUS_DET"
+
+// DividendParams represents parameters for dividend plan request
+type DividendParams struct {
+	Symbol   string // Stock symbol (e.g., "000001.SZ")
+	PageSize int    // Number of plans, default 50
+}
+
+// DividendItem represents one dividend and bonus share plan.
+// Ratios and cash amounts are per 10 shares; dates are "2006-01-02 15:04:05".
+type DividendItem struct {
+	Code           string  // 股票代码
+	Name           string  // 股票简称
+	ReportDate     string  // 报告期
+	PlanNoticeDate string  // 预案公告日
+	NoticeDate     string  // 最新公告日
+	RecordDate     string  // 股权登记日
+	ExDate         string  // 除权除息日
+	BonusITRatio   float64 // 送转总比例 (每10股)
+	BonusRatio     float64 // 送股比例 (每10股)
+	ITRatio        float64 // 转增比例 (每10股)
+	PretaxBonusRMB float64 // 现金分红 (每10股, 税前, 元)
+	DividendYield  float64 // 股息率
+	Progress       string  // 方案进度
+	Plan           string  // 分配方案描述
+}
+
+// GetDividends retrieves the dividend and bonus share plans of a stock, most
+// recent first
+func (c *Client) GetDividends(ctx context.Context, params *DividendParams) ([]DividendItem, *request.Record, error) {
+	code, _, ok := parseSymbol(params.Symbol)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid symbol: %s", params.Symbol)
+	}
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+
+	v := url.Values{}
+	v.Set("reportName", ReportDividend)
+	v.Set("columns", "ALL")
+	v.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	v.Set("pageNumber", "1")
+	v.Set("pageSize", strconv.Itoa(pageSize))
+	v.Set("sortColumns", "REPORT_DATE")
+	v.Set("sortTypes", "-1")
+
+	req := request.Request{
+		Method: "GET",
+		URL:    Datacenter + FinancialAPI + "?" + v.Encode(),
+		Headers: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
+			"Referer":    "https://data.eastmoney.com/",
+		},
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	result, err := parseFinancialResponse(resp.Body)
+	if err != nil {
+		return nil, record, err
+	}
+	if !result.Success {
+		return nil, record, fmt.Errorf("eastmoney datacenter error %d: %s", result.Code, result.Message)
+	}
+
+	items := make([]DividendItem, 0, len(result.Data))
+	for _, row := range result.Data {
+		items = append(items, DividendItem{
+			Code:           getString(row, "SECURITY_CODE"),
+			Name:           getString(row, "SECURITY_NAME_ABBR"),
+			ReportDate:     getString(row, "REPORT_DATE"),
+			PlanNoticeDate: getString(row, "PLAN_NOTICE_DATE"),
+			NoticeDate:     getString(row, "NOTICE_DATE"),
+			RecordDate:     getString(row, "EQUITY_RECORD_DATE"),
+			ExDate:         getString(row, "EX_DIVIDEND_DATE"),
+			BonusITRatio:   getFloat(row, "BONUS_IT_RATIO"),
+			BonusRatio:     getFloat(row, "BONUS_RATIO"),
+			ITRatio:        getFloat(row, "IT_RATIO"),
+			PretaxBonusRMB: getFloat(row, "PRETAX_BONUS_RMB"),
+			DividendYield:  getFloat(row, "DIVIDENT_RATIO"),
+			Progress:       getString(row, "ASSIGN_PROGRESS"),
+			Plan:           getString(row, "IMPL_PLAN_PROFILE"),
+		})
+	}
+	return items, record, nil
+}
+
+// ReportRightsIssue is the datacenter report of rights issues (配股)
+const ReportRightsIssue = "RPT_IPO_ALLOTMENT"
+
+// RightsIssueParams represents parameters for rights issue request
+type RightsIssueParams struct {
+	Symbol   string // Stock symbol (e.g., "000001.SZ")
+	PageSize int    // Number of issues, default 50
+}
+
+// RightsIssueItem represents one rights issue.
+// The ratio is per 10 shares; dates are "2006-01-02 15:04:05".
+type RightsIssueItem struct {
+	Code         string  // 股票代码
+	Name         string  // 股票简称
+	NoticeDate   string  // 公告日
+	RecordDate   string  // 股权登记日
+	ExDate       string  // 除权日
+	PlacingRatio float64 // 配股比例 (每10股配股数)
+	IssuePrice   float64 // 配股价 (元)
+}
+
+// GetRightsIssues retrieves the rights issues of a stock, most recent first
+func (c *Client) GetRightsIssues(ctx context.Context, params *RightsIssueParams) ([]RightsIssueItem, *request.Record, error) {
+	code, _, ok := parseSymbol(params.Symbol)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid symbol: %s", params.Symbol)
+	}
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+
+	v := url.Values{}
+	v.Set("reportName", ReportRightsIssue)
+	v.Set("columns", "ALL")
+	v.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	v.Set("pageNumber", "1")
+	v.Set("pageSize", strconv.Itoa(pageSize))
+	v.Set("sortColumns", "EQUITY_RECORD_DATE")
+	v.Set("sortTypes", "-1")
+
+	req := request.Request{
+		Method: "GET",
+		URL:    Datacenter + FinancialAPI + "?" + v.Encode(),
+		Headers: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
+			"Referer":    "https://data.eastmoney.com/",
+		},
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	result, err := parseFinancialResponse(resp.Body)
+	if err != nil {
+		return nil, record, err
+	}
+	if !result.Success {
+		return nil, record, fmt.Errorf("eastmoney datacenter error %d: %s", result.Code, result.Message)
+	}
+
+	items := make([]RightsIssueItem, 0, len(result.Data))
+	for _, row := range result.Data {
+		items = append(items, RightsIssueItem{
+			Code:         getString(row, "SECURITY_CODE"),
+			Name:         getString(row, "SECURITY_NAME_ABBR"),
+			NoticeDate:   getString(row, "NOTICE_DATE"),
+			RecordDate:   getString(row, "EQUITY_RECORD_DATE"),
+			ExDate:       getString(row, "EX_DIVIDEND_DATE"),
+			PlacingRatio: getFloat(row, "PLACING_RATIO"),
+			IssuePrice:   getFloat(row, "ISSUE_PRICE"),
+		})
+	}
+	return items, record, nil
+}
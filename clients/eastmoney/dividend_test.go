@@ -0,0 +1,73 @@
+package eastmoney
+
+import (
+	"context"
+	"testing"
+)
+
+// TestClient_GetDividends tests retrieving dividend and bonus share plans
+// API Rule: No authentication required
+func TestClient_GetDividends(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	items, record, err := client.GetDividends(ctx, &DividendParams{
+		Symbol:   "600519.SH",
+		PageSize: 10,
+	})
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("Dividend Response Status: %d", record.Response.StatusCode)
+	t.Logf("Got %d dividend plans", len(items))
+	if len(items) > 0 {
+		item := items[0]
+		t.Logf("First: report=%s, ex_date=%s, cash=%.2f/10, bonus=%.2f/10, progress=%s",
+			item.ReportDate, item.ExDate, item.PretaxBonusRMB, item.BonusITRatio, item.Progress)
+	}
+}
+
+func TestClient_GetDividends_InvalidSymbol(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	if _, _, err := client.GetDividends(context.Background(), &DividendParams{Symbol: "600519"}); err == nil {
+		t.Error("expected error for symbol without exchange")
+	}
+}
+
+// TestClient_GetRightsIssues tests retrieving rights issues
+// API Rule: No authentication required
+func TestClient_GetRightsIssues(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	items, record, err := client.GetRightsIssues(ctx, &RightsIssueParams{
+		Symbol:   "600030.SH",
+		PageSize: 10,
+	})
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("Rights Issue Response Status: %d", record.Response.StatusCode)
+	t.Logf("Got %d rights issues", len(items))
+	if len(items) > 0 {
+		item := items[0]
+		t.Logf("First: ex_date=%s, ratio=%.2f/10, price=%.2f", item.ExDate, item.PlacingRatio, item.IssuePrice)
+	}
+}
+
+func TestClient_GetRightsIssues_InvalidSymbol(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	if _, _, err := client.GetRightsIssues(context.Background(), &RightsIssueParams{Symbol: "600519"}); err == nil {
+		t.Error("expected error for symbol without exchange")
+	}
+}
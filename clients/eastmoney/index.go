@@ -0,0 +1,75 @@
+package eastmoney
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/souloss/quantds/request"
+)
+
+// indexBoards maps exchanges to the board filter of their index series
+var indexBoards = map[string]string{
+	"SH": "m:1+s:2", // 上证系列指数
+	"SZ": "m:0+t:5", // 深证系列指数
+}
+
+// IndexListParams represents parameters for index list request
+type IndexListParams struct {
+	Exchange string // SH or SZ
+	PageSize int
+	PageNo   int
+}
+
+// GetIndexList retrieves the index series of an exchange with their quotes
+func (c *Client) GetIndexList(ctx context.Context, params *IndexListParams) (*QuoteResult, *request.Record, error) {
+	fs, ok := indexBoards[params.Exchange]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported index exchange: %s", params.Exchange)
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 100
+	}
+	if params.PageNo <= 0 {
+		params.PageNo = 1
+	}
+
+	query := url.Values{}
+	query.Set("pn", strconv.Itoa(params.PageNo))
+	query.Set("pz", strconv.Itoa(params.PageSize))
+	query.Set("po", "1")
+	query.Set("np", "1")
+	query.Set("fltt", "2")
+	query.Set("invt", "2")
+	query.Set("fid", "f12")
+	query.Set("fs", fs)
+	query.Set("fields", "f12,f13,f14,f2,f3,f4,f5,f6,f15,f16,f17,f18")
+
+	apiURL := fmt.Sprintf("%s?%s", QuoteAPI, query.Encode())
+
+	req := request.Request{
+		Method: "GET",
+		URL:    apiURL,
+		Headers: map[string]string{
+			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
+			"Referer":    "https://quote.eastmoney.com/",
+		},
+		Check: checkResponse,
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	result, err := parseQuoteResponse(resp.Body)
+	if err != nil {
+		return nil, record, err
+	}
+	return result, record, nil
+}
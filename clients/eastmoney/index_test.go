@@ -0,0 +1,34 @@
+package eastmoney
+
+import (
+	"context"
+	"testing"
+)
+
+// TestClient_GetIndexList tests retrieving the SSE index series
+// API Rule: No authentication required
+func TestClient_GetIndexList(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	result, _, err := client.GetIndexList(ctx, &IndexListParams{Exchange: "SH", PageSize: 10})
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("Got %d of %d indexes", len(result.Data), result.Total)
+	for i, idx := range result.Data {
+		t.Logf("Index[%d]: code=%s, name=%s, latest=%.2f", i, idx.Code, idx.Name, idx.Latest)
+	}
+}
+
+func TestClient_GetIndexList_UnsupportedExchange(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	if _, _, err := client.GetIndexList(context.Background(), &IndexListParams{Exchange: "HK"}); err == nil {
+		t.Error("GetIndexList() should reject unsupported exchanges")
+	}
+}
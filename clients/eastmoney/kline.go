@@ -13,6 +13,9 @@ import (
 
 const CandleAPI = "/api/qt/stock/kline/get"
 
+// MaxCandleLimit is the maximum number of candles returned by a single request
+const MaxCandleLimit = 500
+
 const FieldCandles = "f51,f52,f53,f54,f55,f56,f57,f58,f59,f60,f61"
 
 // CandleParams represents parameters for candlestick data request
@@ -82,6 +85,7 @@ func (c *Client) GetCandles(ctx context.Context, params *CandleParams) (*CandleR
 			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 			"Referer":    "https://quote.eastmoney.com/",
 		},
+		Check: checkSecurity,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -65,6 +65,7 @@ func (c *Client) GetMoneyFlow(ctx context.Context, params *MoneyFlowParams) (*Mo
 			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 			"Referer":    "https://data.eastmoney.com/",
 		},
+		Check: checkSecurity,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -100,7 +101,7 @@ func parseMoneyFlowResponse(body []byte) (*MoneyFlowData, error) {
 	}
 
 	if raw.Data == nil {
-		return nil, fmt.Errorf("no data found")
+		return nil, request.NewVendorError(vendor, request.ErrorTypeNoData, "", "no data found")
 	}
 
 	return &MoneyFlowData{
@@ -128,6 +129,10 @@ type MoneyFlowHistoryItem struct {
 	LargeNetInflow     float64 `json:"large_net_inflow"`
 	MediumNetInflow    float64 `json:"medium_net_inflow"`
 	SmallNetInflow     float64 `json:"small_net_inflow"`
+	SuperNetRatio      float64 `json:"super_net_ratio"`
+	LargeNetRatio      float64 `json:"large_net_ratio"`
+	MediumNetRatio     float64 `json:"medium_net_ratio"`
+	SmallNetRatio      float64 `json:"small_net_ratio"`
 	ClosePrice         float64 `json:"close_price"`
 	ChangePercent      float64 `json:"change_percent"`
 }
@@ -164,6 +169,7 @@ func (c *Client) GetMoneyFlowHistory(ctx context.Context, params *MoneyFlowHisto
 			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 			"Referer":    "https://data.eastmoney.com/",
 		},
+		Check: checkSecurity,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -194,7 +200,7 @@ func parseMoneyFlowHistoryResponse(body []byte) ([]MoneyFlowHistoryItem, error)
 	}
 
 	if raw.Data == nil {
-		return nil, fmt.Errorf("no data found")
+		return nil, request.NewVendorError(vendor, request.ErrorTypeNoData, "", "no data found")
 	}
 
 	items := make([]MoneyFlowHistoryItem, 0, len(raw.Data.Klines))
@@ -215,6 +221,10 @@ func parseMoneyFlowHistoryResponse(body []byte) ([]MoneyFlowHistoryItem, error)
 			LargeNetInflow:     parseFloat(parts[7]),
 			MediumNetInflow:    parseFloat(parts[9]),
 			SmallNetInflow:     parseFloat(parts[11]),
+			SuperNetRatio:      parseFloat(parts[6]),
+			LargeNetRatio:      parseFloat(parts[8]),
+			MediumNetRatio:     parseFloat(parts[10]),
+			SmallNetRatio:      parseFloat(parts[12]),
 		}
 		items = append(items, item)
 	}
@@ -72,6 +72,7 @@ func (c *Client) GetProfile(ctx context.Context, params *ProfileParams) (*Profil
 			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 			"Referer":    "https://quote.eastmoney.com/",
 		},
+		Check: checkSecurity,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -107,7 +108,7 @@ func parseProfileResponse(body []byte) (*ProfileResult, error) {
 	}
 
 	if resp.Data == nil {
-		return nil, fmt.Errorf("empty data response")
+		return nil, request.NewVendorError(vendor, request.ErrorTypeNoData, "", "empty data response")
 	}
 
 	return &ProfileResult{
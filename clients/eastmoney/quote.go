@@ -99,6 +99,7 @@ func (c *Client) GetQuotes(ctx context.Context, params *QuoteParams) (*QuoteResu
 			"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 			"Referer":    "https://quote.eastmoney.com/",
 		},
+		Check: checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -17,6 +17,10 @@ func checkAPIError(t *testing.T, err error) {
 	}
 	var reqErr *request.RequestError
 	if errors.As(err, &reqErr) {
+		switch reqErr.Type {
+		case request.ErrorTypeAuth, request.ErrorTypeQuota, request.ErrorTypeRateLimited, request.ErrorTypeMaintenance:
+			t.Skipf("Skipping: vendor restriction (%s): %v", reqErr.Type, err)
+		}
 		// 401: Unauthorized
 		// 403: Forbidden (Often WAF block or geo-restriction)
 		// 429: Too Many Requests (Rate limited)
@@ -88,6 +88,8 @@ type FundEstimateParams struct {
 type FundEstimateResult struct {
 	Code      string
 	Name      string
+	NAVDate   string // 最近净值日期 (YYYY-MM-DD)
+	NAV       string // 最近单位净值
 	EstNAV    string
 	EstChange string
 	EstTime   string
@@ -133,6 +135,8 @@ func parseFundEstimateResponse(body []byte) (*FundEstimateResult, error) {
 	return &FundEstimateResult{
 		Code:      data["fundcode"],
 		Name:      data["name"],
+		NAVDate:   data["jzrq"],
+		NAV:       data["dwjz"],
 		EstNAV:    data["gsz"],
 		EstChange: data["gszzl"],
 		EstTime:   data["gztime"],
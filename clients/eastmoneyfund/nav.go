@@ -0,0 +1,133 @@
+package eastmoneyfund
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/souloss/quantds/request"
+)
+
+// MaxNAVPageSize is the largest page the NAV history API serves.
+const MaxNAVPageSize = 49
+
+type FundNAVParams struct {
+	Code      string
+	StartDate string // YYYY-MM-DD
+	EndDate   string // YYYY-MM-DD
+	Page      int
+	PageSize  int
+}
+
+type FundNAVResult struct {
+	Items   []FundNAVItem
+	Records int
+	Pages   int
+	Page    int
+}
+
+// FundNAVItem is one row of the NAV history table. Money market funds report
+// yields instead of NAVs and are not parsed.
+type FundNAVItem struct {
+	Date           string  // 净值日期 (YYYY-MM-DD)
+	UnitNAV        float64 // 单位净值
+	AccumNAV       float64 // 累计净值
+	ChangeRate     float64 // 日增长率 (%)
+	PurchaseStatus string  // 申购状态
+	RedeemStatus   string  // 赎回状态
+	Dividend       string  // 分红送配
+}
+
+// GetFundNAV gets one page of the NAV history of a fund, newest first.
+func (c *Client) GetFundNAV(ctx context.Context, params *FundNAVParams) (*FundNAVResult, *request.Record, error) {
+	if params.Code == "" {
+		return nil, nil, fmt.Errorf("code is required")
+	}
+	page := params.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := params.PageSize
+	if pageSize <= 0 || pageSize > MaxNAVPageSize {
+		pageSize = MaxNAVPageSize
+	}
+
+	q := url.Values{}
+	q.Set("type", "lsjz")
+	q.Set("code", params.Code)
+	q.Set("page", strconv.Itoa(page))
+	q.Set("per", strconv.Itoa(pageSize))
+	q.Set("sdate", params.StartDate)
+	q.Set("edate", params.EndDate)
+
+	req := request.Request{
+		Method:  "GET",
+		URL:     FundNAVURL + "?" + q.Encode(),
+		Headers: DefaultHeaders,
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	result, err := parseFundNAVResponse(resp.Body)
+	if err != nil {
+		return nil, record, err
+	}
+
+	return result, record, nil
+}
+
+var (
+	navContentRe = regexp.MustCompile(`content:"(.*?)",\s*records:(\d+),\s*pages:(\d+),\s*curpage:(\d+)`)
+	navRowRe     = regexp.MustCompile(`(?s)<tr>(.*?)</tr>`)
+	navCellRe    = regexp.MustCompile(`(?s)<td[^>]*>(.*?)</td>`)
+)
+
+func parseFundNAVResponse(body []byte) (*FundNAVResult, error) {
+	matches := navContentRe.FindSubmatch(body)
+	if len(matches) < 5 {
+		return nil, fmt.Errorf("failed to parse fund NAV response")
+	}
+
+	result := &FundNAVResult{}
+	result.Records, _ = strconv.Atoi(string(matches[2]))
+	result.Pages, _ = strconv.Atoi(string(matches[3]))
+	result.Page, _ = strconv.Atoi(string(matches[4]))
+
+	for _, row := range navRowRe.FindAllSubmatch(matches[1], -1) {
+		cells := navCellRe.FindAllSubmatch(row[1], -1)
+		if len(cells) != 7 {
+			continue
+		}
+		text := func(i int) string {
+			return strings.TrimSpace(html.UnescapeString(string(cells[i][1])))
+		}
+		unitNAV, err := strconv.ParseFloat(text(1), 64)
+		if err != nil {
+			continue
+		}
+		accumNAV, _ := strconv.ParseFloat(text(2), 64)
+		changeRate, _ := strconv.ParseFloat(strings.TrimSuffix(text(3), "%"), 64)
+		result.Items = append(result.Items, FundNAVItem{
+			Date:           text(0),
+			UnitNAV:        unitNAV,
+			AccumNAV:       accumNAV,
+			ChangeRate:     changeRate,
+			PurchaseStatus: text(4),
+			RedeemStatus:   text(5),
+			Dividend:       text(6),
+		})
+	}
+
+	return result, nil
+}
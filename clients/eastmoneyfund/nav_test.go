@@ -0,0 +1,54 @@
+package eastmoneyfund
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_GetFundNAV(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, _, err := client.GetFundNAV(ctx, &FundNAVParams{Code: "000001", PageSize: 10})
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("Records: %d, pages: %d", result.Records, result.Pages)
+	for _, item := range result.Items {
+		t.Logf("  %s NAV=%.4f AccumNAV=%.4f Change=%.2f%%", item.Date, item.UnitNAV, item.AccumNAV, item.ChangeRate)
+	}
+}
+
+func TestParseFundNAVResponse(t *testing.T) {
+	body := []byte(`var apidata={ content:"<table class='w782 comm lsjz'><thead><tr><th class='first'>净值日期</th><th>单位净值</th><th>累计净值</th><th>日增长率</th><th>申购状态</th><th>赎回状态</th><th class='tor last'>分红送配</th></tr></thead><tbody>` +
+		`<tr><td>2024-03-01</td><td class='tor bold'>1.1610</td><td class='tor bold'>3.7420</td><td class='tor bold red'>0.52%</td><td>开放申购</td><td>开放赎回</td><td class='red unbold'></td></tr>` +
+		`<tr><td>2024-02-29</td><td class='tor bold'>1.1550</td><td class='tor bold'>3.7360</td><td class='tor bold grn'>-0.26%</td><td>开放申购</td><td>开放赎回</td><td class='red unbold'>每份派现金0.0100元</td></tr>` +
+		`</tbody></table>",records:5000,pages:102,curpage:1};`)
+
+	result, err := parseFundNAVResponse(body)
+	if err != nil {
+		t.Fatalf("parseFundNAVResponse() error = %v", err)
+	}
+	if result.Records != 5000 || result.Pages != 102 || result.Page != 1 {
+		t.Errorf("paging = %d/%d/%d", result.Records, result.Pages, result.Page)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("len(Items) = %d, want 2", len(result.Items))
+	}
+	item := result.Items[1]
+	if item.Date != "2024-02-29" || item.UnitNAV != 1.155 || item.AccumNAV != 3.736 || item.ChangeRate != -0.26 || item.Dividend != "每份派现金0.0100元" {
+		t.Errorf("Items[1] = %+v", item)
+	}
+
+	empty := []byte(`var apidata={ content:"<table><tbody><tr><td colspan='7' align='center'>暂无数据!</td></tr></tbody></table>",records:0,pages:0,curpage:1};`)
+	result, err = parseFundNAVResponse(empty)
+	if err != nil || len(result.Items) != 0 {
+		t.Errorf("empty response = %+v, %v", result, err)
+	}
+}
@@ -20,6 +20,9 @@ const (
 	CryptoSymbolAPI = "/crypto/symbol"
 )
 
+// EnvAPIKey is the environment variable NewClient reads the API key from.
+const EnvAPIKey = "FINNHUB_API_KEY"
+
 var DefaultHeaders = map[string]string{
 	"User-Agent": "quantds/1.0",
 	"Accept":     "application/json",
@@ -51,7 +54,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 
 func WithTimeout(d time.Duration) Option {
 	return func(c *Client) {
-		c.http = request.NewClient(request.DefaultConfig(
+		c.http = request.NewClient(DefaultConfig(
 			request.WithTimeout(timeout.New[request.Response](d)),
 		))
 	}
@@ -69,10 +72,21 @@ func WithAPIKey(key string) Option {
 	}
 }
 
+// RateLimitPerMinute is the free plan limit of requests per minute.
+const RateLimitPerMinute = 60
+
+// DefaultConfig returns the request configuration NewClient uses: the
+// request defaults limited to RateLimitPerMinute.
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+	return request.DefaultConfig(append([]request.ConfigOption{
+		request.WithRateLimiter(request.BurstyRateLimiter(RateLimitPerMinute, time.Minute, request.DefaultRateLimitWait)),
+	}, opts...)...)
+}
+
 func NewClient(opts ...Option) *Client {
 	c := &Client{
-		http:   request.NewClient(request.DefaultConfig()),
-		apiKey: os.Getenv("FINNHUB_API_KEY"),
+		http:   request.NewClient(DefaultConfig()),
+		apiKey: os.Getenv(EnvAPIKey),
 	}
 	for _, opt := range opts {
 		opt(c)
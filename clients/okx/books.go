@@ -0,0 +1,83 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/souloss/quantds/request"
+)
+
+// API Endpoint Constants
+const (
+	EndpointBooks = "/api/v5/market/books"
+
+	// Query Parameters
+	ParamSz = "sz"
+)
+
+// MaxBookDepth is the maximum levels per side of /market/books
+const MaxBookDepth = 400
+
+// BooksRequest represents request parameters for order book
+type BooksRequest struct {
+	InstID string
+	Size   int // Levels per side (max 400, default 1)
+}
+
+// BookResponse represents an OKX order book snapshot
+// Levels are [price, size, deprecated, numOrders], best first
+type BookResponse struct {
+	Asks [][]string `json:"asks"`
+	Bids [][]string `json:"bids"`
+	Ts   string     `json:"ts"`
+}
+
+// GetBooks gets the order book of an instrument
+func (c *Client) GetBooks(ctx context.Context, params *BooksRequest) (*BookResponse, *request.Record, error) {
+	if params.InstID == "" {
+		return nil, nil, fmt.Errorf("instId is required")
+	}
+
+	u, _ := url.Parse(c.BaseURL + EndpointBooks)
+	q := u.Query()
+	q.Add(ParamInstID, params.InstID)
+	if params.Size > 0 {
+		q.Add(ParamSz, fmt.Sprintf("%d", min(params.Size, MaxBookDepth)))
+	}
+
+	req := request.Request{
+		Method: "GET",
+		URL:    u.String() + "?" + q.Encode(),
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result Response
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, record, err
+	}
+
+	if result.Code != "0" {
+		return nil, record, fmt.Errorf("api error: %s (code: %s)", result.Msg, result.Code)
+	}
+
+	var books []BookResponse
+	if err := json.Unmarshal(result.Data, &books); err != nil {
+		return nil, record, err
+	}
+
+	if len(books) == 0 {
+		return nil, record, fmt.Errorf("no order book found")
+	}
+
+	return &books[0], record, nil
+}
@@ -0,0 +1,28 @@
+package okx
+
+import (
+	"context"
+	"testing"
+)
+
+// TestClient_GetBooks tests retrieving the order book
+// API Rule: Rate limit 40 req/2s
+func TestClient_GetBooks(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	book, _, err := client.GetBooks(ctx, &BooksRequest{
+		InstID: "BTC-USDT",
+		Size:   5,
+	})
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("Books: %d bids, %d asks, ts %s", len(book.Bids), len(book.Asks), book.Ts)
+
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		t.Error("Expected bids and asks, got empty book")
+	}
+}
@@ -11,11 +11,20 @@ import (
 
 // API Endpoint Constants
 const (
-	EndpointCandles = "/api/v5/market/candles"
+	EndpointCandles        = "/api/v5/market/candles"
+	EndpointHistoryCandles = "/api/v5/market/history-candles"
 
 	// Query Parameters
-	ParamBar   = "bar"
-	ParamLimit = "limit"
+	ParamBar    = "bar"
+	ParamLimit  = "limit"
+	ParamAfter  = "after"
+	ParamBefore = "before"
+)
+
+// Candle limits per request
+const (
+	MaxCandleLimit        = 300 // /market/candles
+	MaxHistoryCandleLimit = 100 // /market/history-candles
 )
 
 // CandlestickRequest represents request parameters for candlesticks
@@ -23,20 +32,33 @@ type CandlestickRequest struct {
 	InstID string
 	Bar    string
 	Limit  int
+	After  int64 // Return records earlier than this timestamp (Unix ms, exclusive)
+	Before int64 // Return records newer than this timestamp (Unix ms, exclusive)
 }
 
 // CandlestickResponse represents a single K-line bar
 // OKX returns: [ts, o, h, l, c, vol, volCcy, volCcyQuote, confirm]
 type CandlestickResponse []string
 
-// GetCandlesticks gets historical k-line data
+// GetCandlesticks gets recent k-line data (newest first)
 // bar: 1m, 3m, 5m, 15m, 30m, 1H, 2H, 4H, 6H, 12H, 1D, 1W, 1M, 3M
 func (c *Client) GetCandlesticks(ctx context.Context, params *CandlestickRequest) ([]CandlestickResponse, *request.Record, error) {
+	return c.getCandles(ctx, EndpointCandles, params)
+}
+
+// GetHistoryCandlesticks gets historical k-line data (newest first),
+// including data older than the recent-candles window.
+// Use After/Before as cursors to page through a time range.
+func (c *Client) GetHistoryCandlesticks(ctx context.Context, params *CandlestickRequest) ([]CandlestickResponse, *request.Record, error) {
+	return c.getCandles(ctx, EndpointHistoryCandles, params)
+}
+
+func (c *Client) getCandles(ctx context.Context, endpoint string, params *CandlestickRequest) ([]CandlestickResponse, *request.Record, error) {
 	if params.InstID == "" {
 		return nil, nil, fmt.Errorf("instId is required")
 	}
 
-	u, _ := url.Parse(c.BaseURL + EndpointCandles)
+	u, _ := url.Parse(c.BaseURL + endpoint)
 	q := u.Query()
 	q.Add(ParamInstID, params.InstID)
 	if params.Bar != "" {
@@ -45,6 +67,12 @@ func (c *Client) GetCandlesticks(ctx context.Context, params *CandlestickRequest
 	if params.Limit > 0 {
 		q.Add(ParamLimit, fmt.Sprintf("%d", params.Limit))
 	}
+	if params.After > 0 {
+		q.Add(ParamAfter, fmt.Sprintf("%d", params.After))
+	}
+	if params.Before > 0 {
+		q.Add(ParamBefore, fmt.Sprintf("%d", params.Before))
+	}
 
 	req := request.Request{
 		Method: "GET",
@@ -2,7 +2,9 @@ package okx
 
 import (
 	"context"
+	"strconv"
 	"testing"
+	"time"
 )
 
 // TestClient_GetCandlesticks tests retrieving historical K-line data
@@ -40,3 +42,41 @@ func TestClient_GetCandlesticks(t *testing.T) {
 		}
 	}
 }
+
+// TestClient_GetHistoryCandlesticks tests retrieving older K-line data with cursors
+// API Rule: Max 100 data points per request, after/before are exclusive Unix ms cursors
+func TestClient_GetHistoryCandlesticks(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)
+	params := &CandlestickRequest{
+		InstID: "BTC-USDT",
+		Bar:    "1D",
+		Limit:  MaxHistoryCandleLimit,
+		After:  end.UnixMilli() + 1,
+		Before: start.UnixMilli() - 1,
+	}
+
+	candles, _, err := client.GetHistoryCandlesticks(ctx, params)
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("History candlesticks count: %d", len(candles))
+	if len(candles) == 0 {
+		t.Error("Expected candles, got 0")
+	}
+
+	for _, c := range candles {
+		ts, err := strconv.ParseInt(c[0], 10, 64)
+		if err != nil {
+			t.Fatalf("invalid timestamp %q", c[0])
+		}
+		if ts < start.UnixMilli() || ts > end.UnixMilli() {
+			t.Errorf("candle %v outside requested range", time.UnixMilli(ts))
+		}
+	}
+}
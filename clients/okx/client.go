@@ -29,7 +29,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 // WithTimeout sets the request timeout
 func WithTimeout(d time.Duration) Option {
         return func(c *Client) {
-                c.http = request.NewClient(request.DefaultConfig(
+                c.http = request.NewClient(DefaultConfig(
                         request.WithTimeout(timeout.New[request.Response](d)),
                 ))
         }
@@ -49,11 +49,25 @@ func WithBaseURL(url string) Option {
         }
 }
 
+// Public market data endpoints allow RateLimitRequests per RateLimitPeriod per IP.
+const (
+        RateLimitRequests = 20
+        RateLimitPeriod   = 2 * time.Second
+)
+
+// DefaultConfig returns the request configuration NewClient uses: the
+// request defaults limited to RateLimitRequests per RateLimitPeriod.
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+        return request.DefaultConfig(append([]request.ConfigOption{
+                request.WithRateLimiter(request.BurstyRateLimiter(RateLimitRequests, RateLimitPeriod, request.DefaultRateLimitWait)),
+        }, opts...)...)
+}
+
 // NewClient creates a new OKX client
 // If no options are provided, it uses the default configuration
 func NewClient(opts ...Option) *Client {
         c := &Client{
-                http:    request.NewClient(request.DefaultConfig()),
+                http:    request.NewClient(DefaultConfig()),
                 BaseURL: DefaultBaseURL,
         }
         for _, opt := range opts {
@@ -15,6 +15,9 @@ const (
 	SnapshotAPI   = "/v2/snapshot/locale/us/markets/stocks/tickers"
 )
 
+// EnvAPIKey is the environment variable NewClient reads the API key from.
+const EnvAPIKey = "POLYGON_API_KEY"
+
 var DefaultHeaders = map[string]string{
 	"User-Agent": "quantds/1.0",
 	"Accept":     "application/json",
@@ -41,7 +44,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 
 func WithTimeout(d time.Duration) Option {
 	return func(c *Client) {
-		c.http = request.NewClient(request.DefaultConfig(
+		c.http = request.NewClient(DefaultConfig(
 			request.WithTimeout(timeout.New[request.Response](d)),
 		))
 	}
@@ -55,10 +58,21 @@ func WithAPIKey(key string) Option {
 	return func(c *Client) { c.apiKey = key }
 }
 
+// RateLimitPerMinute is the Basic plan limit of requests per minute.
+const RateLimitPerMinute = 5
+
+// DefaultConfig returns the request configuration NewClient uses: the
+// request defaults with requests spread evenly within RateLimitPerMinute.
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+	return request.DefaultConfig(append([]request.ConfigOption{
+		request.WithRateLimiter(request.SmoothRateLimiter(RateLimitPerMinute, time.Minute, request.DefaultRateLimitWait)),
+	}, opts...)...)
+}
+
 func NewClient(opts ...Option) *Client {
 	c := &Client{
-		http:   request.NewClient(request.DefaultConfig()),
-		apiKey: os.Getenv("POLYGON_API_KEY"),
+		http:   request.NewClient(DefaultConfig()),
+		apiKey: os.Getenv(EnvAPIKey),
 	}
 	for _, opt := range opts {
 		opt(c)
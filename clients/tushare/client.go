@@ -14,6 +14,7 @@ import (
         "fmt"
         "os"
         "strconv"
+        "strings"
         "time"
 
         "github.com/failsafe-go/failsafe-go/timeout"
@@ -23,6 +24,12 @@ import (
 // DefaultBaseURL 是 Tushare Pro 的默认 API 地址。
 const DefaultBaseURL = "http://api.tushare.pro"
 
+// NewClient 读取的环境变量。
+const (
+        EnvToken   = "TUSHARE_TOKEN"
+        EnvBaseURL = "TUSHARE_BASE_URL"
+)
+
 // API 名称常量
 const (
         APIStockBasic   = "stock_basic"   // 股票基本信息
@@ -77,7 +84,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 // WithTimeout 设置请求超时。
 func WithTimeout(d time.Duration) Option {
         return func(c *Client) {
-                c.http = request.NewClient(request.DefaultConfig(
+                c.http = request.NewClient(DefaultConfig(
                         request.WithTimeout(timeout.New[request.Response](d)),
                 ))
         }
@@ -98,18 +105,30 @@ func WithBaseURL(url string) Option {
         return func(c *Client) { c.baseURL = url }
 }
 
+// RateLimitPerMinute 是基础积分（120 分）账户每分钟的调用次数上限。
+// 积分更高的账户可通过 request.WithRateLimiter 或 facade 的 rate_limit 配置提高。
+const RateLimitPerMinute = 50
+
+// DefaultConfig 返回 NewClient 使用的请求配置：在默认配置的基础上按
+// RateLimitPerMinute 限流。
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+        return request.DefaultConfig(append([]request.ConfigOption{
+                request.WithRateLimiter(request.BurstyRateLimiter(RateLimitPerMinute, time.Minute, request.DefaultRateLimitWait)),
+        }, opts...)...)
+}
+
 // NewClient 创建 Tushare 客户端。
 // 自动从环境变量 TUSHARE_TOKEN 和 TUSHARE_BASE_URL 读取配置。
 // 如果不传参数，使用默认配置。
 func NewClient(opts ...Option) *Client {
-        baseURL := os.Getenv("TUSHARE_BASE_URL")
+        baseURL := os.Getenv(EnvBaseURL)
         if baseURL == "" {
                 baseURL = DefaultBaseURL
         }
 
         c := &Client{
-                http:    request.NewClient(request.DefaultConfig()),
-                token:   os.Getenv("TUSHARE_TOKEN"),
+                http:    request.NewClient(DefaultConfig()),
+                token:   os.Getenv(EnvToken),
                 baseURL: baseURL,
         }
         for _, opt := range opts {
@@ -160,7 +179,7 @@ type apiResponseData struct {
 // post 发送 Tushare API 请求。
 func (c *Client) post(ctx context.Context, apiName string, params map[string]string, fields string) (*apiResponseData, *request.Record, error) {
         if c.token == "" {
-                return nil, nil, fmt.Errorf("tushare token is required (set TUSHARE_TOKEN env or use WithToken)")
+                return nil, nil, request.NewVendorError(vendor, request.ErrorTypeAuth, "", "token is required (set TUSHARE_TOKEN env or use WithToken)")
         }
 
         reqBody := apiRequest{
@@ -182,7 +201,8 @@ func (c *Client) post(ctx context.Context, apiName string, params map[string]str
                         "Content-Type": "application/json",
                         "Accept":       "application/json",
                 },
-                Body: jsonBytes,
+                Body:  jsonBytes,
+                Check: checkResponse,
         }
 
         resp, record, err := c.http.Do(ctx, req)
@@ -200,16 +220,51 @@ func (c *Client) post(ctx context.Context, apiName string, params map[string]str
         }
 
         if tsResp.Code != 0 {
-                return nil, record, fmt.Errorf("tushare api error: %s (code %d)", tsResp.Msg, tsResp.Code)
+                return nil, record, apiError(tsResp.Code, tsResp.Msg)
         }
 
         if tsResp.Data == nil {
-                return nil, record, fmt.Errorf("empty data response")
+                return nil, record, request.NewVendorError(vendor, request.ErrorTypeNoData, "", "empty data response")
         }
 
         return tsResp.Data, record, nil
 }
 
+// vendor 为 Tushare 错误的来源名称。
+const vendor = "tushare"
+
+// checkResponse 将响应体中 code 非 0 的错误映射为 request.RequestError，
+// 在请求执行过程中调用，使限频错误可被重试。
+func checkResponse(resp request.Response) error {
+        var r struct {
+                Code int    `json:"code"`
+                Msg  string `json:"msg"`
+        }
+        if err := json.Unmarshal(resp.Body, &r); err != nil || r.Code == 0 {
+                return nil
+        }
+        return apiError(r.Code, r.Msg)
+}
+
+// apiError 按错误信息推断 Tushare 错误的类型：同一错误码 (如 40203)
+// 可能表示每分钟限频、每日配额或积分不足，因此以错误信息为准。
+func apiError(code int, msg string) *request.RequestError {
+        typ := request.ErrorTypeUnknown
+        switch {
+        case code == 40101 || strings.Contains(strings.ToLower(msg), "token"):
+                typ = request.ErrorTypeAuth
+        case strings.Contains(msg, "每分钟"):
+                typ = request.ErrorTypeRateLimited
+        case strings.Contains(msg, "每天") || strings.Contains(msg, "每小时"):
+                typ = request.ErrorTypeQuota
+        case strings.Contains(msg, "权限") || strings.Contains(msg, "积分"):
+                typ = request.ErrorTypeAuth
+        case strings.Contains(msg, "维护"):
+                typ = request.ErrorTypeMaintenance
+        }
+        return request.NewVendorError(vendor, typ, strconv.Itoa(code), msg)
+}
+
 // fieldIndex 将字段名列表转换为 name→index 映射，用于快速查找。
 func fieldIndex(fields []string) map[string]int {
         m := make(map[string]int, len(fields))
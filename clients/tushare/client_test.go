@@ -2,10 +2,13 @@ package tushare
 
 import (
 	"context"
+	"errors"
 	"os"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/souloss/quantds/request"
 )
 
 // newTestClient 创建测试用客户端，需要设置 TUSHARE_TOKEN 环境变量。
@@ -276,3 +279,29 @@ func TestNewClient_Options(t *testing.T) {
 		t.Errorf("Expected token from option, got %s", client.token)
 	}
 }
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		body string
+		want request.ErrorType
+	}{
+		{`{"code": 0, "msg": "", "data": {"fields": [], "items": []}}`, request.ErrorTypeNone},
+		{`not json`, request.ErrorTypeNone},
+		{`{"code": 40101, "msg": "您的token不对，请确认。"}`, request.ErrorTypeAuth},
+		{`{"code": 40203, "msg": "抱歉，您每分钟最多访问该接口200次"}`, request.ErrorTypeRateLimited},
+		{`{"code": 40203, "msg": "抱歉，您每天最多访问该接口20000次"}`, request.ErrorTypeQuota},
+		{`{"code": 40203, "msg": "抱歉，您没有访问该接口的权限"}`, request.ErrorTypeAuth},
+		{`{"code": -1, "msg": "系统维护中"}`, request.ErrorTypeMaintenance},
+	}
+	for _, tt := range tests {
+		err := checkResponse(request.Response{StatusCode: 200, Body: []byte(tt.body)})
+		var got request.ErrorType
+		var reqErr *request.RequestError
+		if errors.As(err, &reqErr) {
+			got = reqErr.Type
+		}
+		if got != tt.want {
+			t.Errorf("checkResponse(%s) = %v, want type %q", tt.body, err, tt.want)
+		}
+	}
+}
@@ -48,8 +48,8 @@ type DividendRow struct {
 	StkDiv      float64 // 每股送转 (股)
 	StkBoRate   float64 // 每股送股比例
 	StkCoRate   float64 // 每股转增比例
-	CashDiv     float64 // 每股分红 (税前)
-	CashDivTax  float64 // 每股分红 (税后)
+	CashDiv     float64 // 每股分红 (税后)
+	CashDivTax  float64 // 每股分红 (税前)
 	RecordDate  string  // 股权登记日
 	ExDate      string  // 除权除息日
 	PayDate     string  // 派息日
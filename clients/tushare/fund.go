@@ -76,8 +76,10 @@ func (c *Client) GetFundBasic(ctx context.Context, params *FundBasicParams) ([]F
 }
 
 type FundNAVParams struct {
-	TSCode string
-	Market string
+	TSCode    string
+	Market    string
+	StartDate string // YYYYMMDD
+	EndDate   string // YYYYMMDD
 }
 
 type FundNAVRow struct {
@@ -100,6 +102,12 @@ func (c *Client) GetFundNAV(ctx context.Context, params *FundNAVParams) ([]FundN
 	if params.Market != "" {
 		m["market"] = params.Market
 	}
+	if params.StartDate != "" {
+		m["start_date"] = params.StartDate
+	}
+	if params.EndDate != "" {
+		m["end_date"] = params.EndDate
+	}
 
 	data, record, err := c.post(ctx, APIFundNAV, m, FieldsFundNAV)
 	if err != nil {
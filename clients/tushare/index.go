@@ -7,13 +7,17 @@ import (
 )
 
 const (
-	APIIndexBasic = "index_basic"
-	APIIndexDaily = "index_daily"
+	APIIndexBasic   = "index_basic"
+	APIIndexDaily   = "index_daily"
+	APIIndexWeekly  = "index_weekly"
+	APIIndexMonthly = "index_monthly"
+	APIIndexWeight  = "index_weight"
 )
 
 const (
-	FieldsIndexBasic = "ts_code,name,fullname,market,publisher,index_type,category,base_date,base_point,list_date,weight_rule,desc,exp_date"
-	FieldsIndexDaily = "ts_code,trade_date,close,open,high,low,pre_close,change,pct_chg,vol,amount"
+	FieldsIndexBasic  = "ts_code,name,fullname,market,publisher,index_type,category,base_date,base_point,list_date,weight_rule,desc,exp_date"
+	FieldsIndexDaily  = "ts_code,trade_date,close,open,high,low,pre_close,change,pct_chg,vol,amount"
+	FieldsIndexWeight = "index_code,con_code,trade_date,weight"
 )
 
 type IndexBasicParams struct {
@@ -29,6 +33,7 @@ type IndexBasicRow struct {
 	IndexType string
 	Category  string
 	BaseDate  string
+	BasePoint float64
 	ListDate  string
 }
 
@@ -55,6 +60,7 @@ func (c *Client) GetIndexBasic(ctx context.Context, params *IndexBasicParams) ([
 			IndexType: getStr(idx, item, "index_type"),
 			Category:  getStr(idx, item, "category"),
 			BaseDate:  getStr(idx, item, "base_date"),
+			BasePoint: getFlt(idx, item, "base_point"),
 			ListDate:  getStr(idx, item, "list_date"),
 		})
 	}
@@ -66,6 +72,7 @@ type IndexDailyParams struct {
 	TSCode    string
 	StartDate string
 	EndDate   string
+	Period    string // daily (默认), weekly, monthly
 }
 
 type IndexDailyRow struct {
@@ -94,7 +101,15 @@ func (c *Client) GetIndexDaily(ctx context.Context, params *IndexDailyParams) ([
 		m["end_date"] = params.EndDate
 	}
 
-	data, record, err := c.post(ctx, APIIndexDaily, m, FieldsIndexDaily)
+	apiName := APIIndexDaily
+	switch params.Period {
+	case "weekly":
+		apiName = APIIndexWeekly
+	case "monthly":
+		apiName = APIIndexMonthly
+	}
+
+	data, record, err := c.post(ctx, apiName, m, FieldsIndexDaily)
 	if err != nil {
 		return nil, record, err
 	}
@@ -119,3 +134,52 @@ func (c *Client) GetIndexDaily(ctx context.Context, params *IndexDailyParams) ([
 
 	return rows, record, nil
 }
+
+type IndexWeightParams struct {
+	IndexCode string
+	TradeDate string
+	StartDate string
+	EndDate   string
+}
+
+type IndexWeightRow struct {
+	IndexCode string
+	ConCode   string
+	TradeDate string
+	Weight    float64 // 权重 (%)
+}
+
+// GetIndexWeight 获取指数成分股权重，数据按月更新。
+func (c *Client) GetIndexWeight(ctx context.Context, params *IndexWeightParams) ([]IndexWeightRow, *request.Record, error) {
+	m := make(map[string]string)
+	if params.IndexCode != "" {
+		m["index_code"] = params.IndexCode
+	}
+	if params.TradeDate != "" {
+		m["trade_date"] = params.TradeDate
+	}
+	if params.StartDate != "" {
+		m["start_date"] = params.StartDate
+	}
+	if params.EndDate != "" {
+		m["end_date"] = params.EndDate
+	}
+
+	data, record, err := c.post(ctx, APIIndexWeight, m, FieldsIndexWeight)
+	if err != nil {
+		return nil, record, err
+	}
+
+	idx := fieldIndex(data.Fields)
+	rows := make([]IndexWeightRow, 0, len(data.Items))
+	for _, item := range data.Items {
+		rows = append(rows, IndexWeightRow{
+			IndexCode: getStr(idx, item, "index_code"),
+			ConCode:   getStr(idx, item, "con_code"),
+			TradeDate: getStr(idx, item, "trade_date"),
+			Weight:    getFlt(idx, item, "weight"),
+		})
+	}
+
+	return rows, record, nil
+}
@@ -59,3 +59,27 @@ func TestClient_GetIndexDaily(t *testing.T) {
 			r.TSCode, r.TradeDate, r.Open, r.Close, r.Vol)
 	}
 }
+
+func TestClient_GetIndexWeight(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rows, _, err := client.GetIndexWeight(ctx, &IndexWeightParams{
+		IndexCode: "000300.SH",
+		StartDate: "20240101",
+		EndDate:   "20240131",
+	})
+	skipOnTokenError(t, err)
+	if err != nil {
+		t.Fatalf("GetIndexWeight() error = %v", err)
+	}
+
+	t.Logf("Got %d index_weight rows", len(rows))
+	if len(rows) > 0 {
+		r := rows[0]
+		t.Logf("First: index=%s, con=%s, date=%s, weight=%.4f", r.IndexCode, r.ConCode, r.TradeDate, r.Weight)
+	}
+}
@@ -41,6 +41,7 @@ type KlineParams struct {
 	EndDate   string
 	Period    string
 	Adjust    string
+	Index     bool // 指数行情，使用 index_daily/index_weekly/index_monthly
 }
 
 type KlineResult struct {
@@ -72,6 +73,10 @@ func (c *Client) GetKline(ctx context.Context, params *KlineParams) (*KlineResul
 		return nil, nil, err
 	}
 
+	if params.Index {
+		return c.getIndexKline(ctx, tsCode, params)
+	}
+
 	apiName := APIDaily
 	switch params.Period {
 	case "weekly":
@@ -112,6 +117,37 @@ func (c *Client) GetKline(ctx context.Context, params *KlineParams) (*KlineResul
 	return &KlineResult{Data: bars, Count: len(bars)}, record, nil
 }
 
+func (c *Client) getIndexKline(ctx context.Context, tsCode string, params *KlineParams) (*KlineResult, *request.Record, error) {
+	rows, record, err := c.GetIndexDaily(ctx, &IndexDailyParams{
+		TSCode:    tsCode,
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		Period:    params.Period,
+	})
+	if err != nil {
+		return nil, record, err
+	}
+
+	bars := make([]KlineBar, 0, len(rows))
+	for _, row := range rows {
+		bars = append(bars, KlineBar{
+			TSCode:     row.TSCode,
+			Date:       formatDate(row.TradeDate),
+			Open:       row.Open,
+			High:       row.High,
+			Low:        row.Low,
+			Close:      row.Close,
+			PreClose:   row.PreClose,
+			Change:     row.Change,
+			ChangeRate: row.PctChg,
+			Volume:     row.Vol,
+			Amount:     row.Amount,
+		})
+	}
+
+	return &KlineResult{Data: bars, Count: len(bars)}, record, nil
+}
+
 func ToPeriod(tf string) string {
 	switch tf {
 	case "1w":
@@ -21,6 +21,9 @@ const (
 	BondsAPI       = "/bonds"
 )
 
+// EnvAPIKey is the environment variable NewClient reads the API key from.
+const EnvAPIKey = "TWELVEDATA_API_KEY"
+
 var DefaultHeaders = map[string]string{
 	"User-Agent": "quantds/1.0",
 	"Accept":     "application/json",
@@ -50,7 +53,7 @@ func WithHTTPClient(httpClient request.Client) Option {
 
 func WithTimeout(d time.Duration) Option {
 	return func(c *Client) {
-		c.http = request.NewClient(request.DefaultConfig(
+		c.http = request.NewClient(DefaultConfig(
 			request.WithTimeout(timeout.New[request.Response](d)),
 		))
 	}
@@ -64,10 +67,21 @@ func WithAPIKey(key string) Option {
 	return func(c *Client) { c.apiKey = key }
 }
 
+// RateLimitPerMinute is the Basic plan limit of API credits per minute.
+const RateLimitPerMinute = 8
+
+// DefaultConfig returns the request configuration NewClient uses: the
+// request defaults limited to RateLimitPerMinute.
+func DefaultConfig(opts ...request.ConfigOption) *request.Config {
+	return request.DefaultConfig(append([]request.ConfigOption{
+		request.WithRateLimiter(request.BurstyRateLimiter(RateLimitPerMinute, time.Minute, request.DefaultRateLimitWait)),
+	}, opts...)...)
+}
+
 func NewClient(opts ...Option) *Client {
 	c := &Client{
-		http:   request.NewClient(request.DefaultConfig()),
-		apiKey: os.Getenv("TWELVEDATA_API_KEY"),
+		http:   request.NewClient(DefaultConfig()),
+		apiKey: os.Getenv(EnvAPIKey),
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -1,6 +1,8 @@
 package xueqiu
 
 import (
+        "encoding/json"
+        "strings"
         "time"
 
         "github.com/failsafe-go/failsafe-go/timeout"
@@ -90,3 +92,40 @@ func (c *Client) buildHeaders() map[string]string {
         }
         return headers
 }
+
+const vendor = "xueqiu"
+
+// checkResponse reports the error_code Xueqiu answers with, with status 200
+// or 400. The code is a number or a numeric string depending on the API.
+func checkResponse(resp request.Response) error {
+        var r struct {
+                ErrorCode        json.RawMessage `json:"error_code"`
+                ErrorDescription string          `json:"error_description"`
+        }
+        if err := json.Unmarshal(resp.Body, &r); err != nil {
+                return nil
+        }
+        code := strings.Trim(string(r.ErrorCode), `"`)
+        if code == "" || code == "0" || code == "null" {
+                return nil
+        }
+        return apiError(code, r.ErrorDescription)
+}
+
+// apiError maps a Xueqiu error onto a request error type by its code and
+// description.
+func apiError(code, description string) *request.RequestError {
+        typ := request.ErrorTypeUnknown
+        switch {
+        case code == "400016" || strings.Contains(description, "登录"):
+                // the cookie or token is missing or expired
+                typ = request.ErrorTypeAuth
+        case strings.Contains(description, "频繁"):
+                typ = request.ErrorTypeRateLimited
+        case strings.Contains(description, "不存在"):
+                typ = request.ErrorTypeInvalidSymbol
+        case strings.Contains(description, "维护"):
+                typ = request.ErrorTypeMaintenance
+        }
+        return request.NewVendorError(vendor, typ, code, description)
+}
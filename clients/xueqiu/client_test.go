@@ -0,0 +1,27 @@
+package xueqiu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/souloss/quantds/request"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		body string
+		want error
+	}{
+		{`{"data": {}, "error_code": 0, "error_description": ""}`, nil},
+		{`<html></html>`, nil},
+		{`{"error_code": "400016", "error_description": "遇到错误，请刷新页面或者重新登录帐号后再试"}`, request.ErrAuth},
+		{`{"error_code": 10001, "error_description": "股票不存在"}`, request.ErrInvalidSymbol},
+		{`{"error_code": 10022, "error_description": "访问过于频繁"}`, request.ErrRateLimited},
+	}
+	for _, tt := range tests {
+		err := checkResponse(request.Response{StatusCode: 200, Body: []byte(tt.body)})
+		if (tt.want == nil && err != nil) || (tt.want != nil && !errors.Is(err, tt.want)) {
+			t.Errorf("checkResponse(%s) = %v, want %v", tt.body, err, tt.want)
+		}
+	}
+}
@@ -83,6 +83,7 @@ func (c *Client) getFinancial(ctx context.Context, apiTmpl string, params *Finan
 		Method:  "GET",
 		URL:     reqURL,
 		Headers: c.buildHeaders(),
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
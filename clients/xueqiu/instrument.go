@@ -126,6 +126,7 @@ func (c *Client) GetStockList(ctx context.Context, params *StockListParams) (*St
 		Method:  "GET",
 		URL:     reqURL,
 		Headers: c.buildHeaders(),
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
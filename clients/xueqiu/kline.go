@@ -76,6 +76,7 @@ func (c *Client) GetKline(ctx context.Context, params *KlineParams) (*KlineResul
 		Method:  "GET",
 		URL:     reqURL,
 		Headers: c.buildHeaders(),
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
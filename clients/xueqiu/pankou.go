@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/souloss/quantds/request"
 )
@@ -66,6 +67,7 @@ func (c *Client) GetPankou(ctx context.Context, symbol string) (*PankouData, *re
 		Method:  "GET",
 		URL:     reqURL,
 		Headers: c.buildHeaders(),
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -83,7 +85,7 @@ func (c *Client) GetPankou(ctx context.Context, symbol string) (*PankouData, *re
 	}
 
 	if result.ErrorCode != 0 {
-		return nil, record, fmt.Errorf("xueqiu error: %d %s", result.ErrorCode, result.ErrorDescription)
+		return nil, record, apiError(strconv.Itoa(result.ErrorCode), result.ErrorDescription)
 	}
 
 	return &result.Data, record, nil
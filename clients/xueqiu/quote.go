@@ -124,6 +124,7 @@ func (c *Client) GetQuoteDetail(ctx context.Context, params *QuoteDetailParams)
 		Method:  "GET",
 		URL:     reqURL,
 		Headers: c.buildHeaders(),
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
@@ -71,6 +71,7 @@ func (c *Client) GetSpot(ctx context.Context, params *SpotParams) (*SpotResult,
 		Method:  "GET",
 		URL:     reqURL,
 		Headers: c.buildHeaders(),
+		Check:   checkResponse,
 	}
 
 	resp, record, err := c.http.Do(ctx, req)
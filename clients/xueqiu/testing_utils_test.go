@@ -17,6 +17,10 @@ func checkAPIError(t *testing.T, err error) {
 	}
 	var reqErr *request.RequestError
 	if errors.As(err, &reqErr) {
+		switch reqErr.Type {
+		case request.ErrorTypeAuth, request.ErrorTypeQuota, request.ErrorTypeRateLimited, request.ErrorTypeMaintenance:
+			t.Skipf("Skipping: vendor restriction (%s): %v", reqErr.Type, err)
+		}
 		switch reqErr.StatusCode {
 		case 401, 403, 429, 451, 503:
 			t.Skipf("Skipping test due to API restriction or unavailability (status %d): %v", reqErr.StatusCode, err)
@@ -0,0 +1,150 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/souloss/quantds/request"
+)
+
+// EventsParams represents parameters for a dividend and split events request
+type EventsParams struct {
+	Symbol    string    // Stock symbol (e.g., "AAPL")
+	Range     string    // Time range, defaults to max when no dates are given
+	StartDate time.Time // Start date (optional, used with EndDate)
+	EndDate   time.Time // End date (optional, used with StartDate)
+}
+
+// EventsResult represents the dividend and split history of a symbol
+type EventsResult struct {
+	Symbol    string          // Stock symbol
+	Timezone  string          // Exchange timezone
+	Currency  string          // Trading currency
+	Dividends []DividendEvent // Cash dividends, ascending by ex-date
+	Splits    []SplitEvent    // Splits, ascending by ex-date
+}
+
+// DividendEvent represents a cash dividend
+type DividendEvent struct {
+	Date   int64   // Ex-dividend date as unix timestamp in seconds
+	Amount float64 // Dividend per share
+}
+
+// SplitEvent represents a stock split
+type SplitEvent struct {
+	Date        int64   // Ex-date as unix timestamp in seconds
+	Numerator   float64 // Shares after the split
+	Denominator float64 // Shares before the split
+	Ratio       string  // Split ratio as reported, e.g. "4:1"
+}
+
+// GetEvents retrieves the dividend and split history of a symbol through the
+// chart API. Yahoo only reports the ex-date of each event.
+func (c *Client) GetEvents(ctx context.Context, params *EventsParams) (*EventsResult, *request.Record, error) {
+	if params.Symbol == "" {
+		return nil, nil, fmt.Errorf("symbol required")
+	}
+
+	url := fmt.Sprintf("%s%s/%s", BaseURL, ChartAPI, params.Symbol)
+	query := fmt.Sprintf("?interval=%s&events=div%%7Csplit", Interval1d)
+	if !params.StartDate.IsZero() && !params.EndDate.IsZero() {
+		query += fmt.Sprintf("&period1=%d&period2=%d", params.StartDate.Unix(), params.EndDate.Unix())
+	} else if params.Range != "" {
+		query += fmt.Sprintf("&range=%s", params.Range)
+	} else {
+		query += fmt.Sprintf("&range=%s", RangeMax)
+	}
+
+	req := request.Request{
+		Method:  "GET",
+		URL:     url + query,
+		Headers: DefaultHeaders,
+	}
+
+	resp, record, err := c.http.Do(ctx, req)
+	if err != nil {
+		return nil, record, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, record, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	result, err := parseEventsResponse(resp.Body)
+	if err != nil {
+		return nil, record, err
+	}
+
+	return result, record, nil
+}
+
+// eventsResponse represents the events part of the chart API response
+type eventsResponse struct {
+	Chart struct {
+		Result []struct {
+			Meta struct {
+				Symbol   string `json:"symbol"`
+				Currency string `json:"currency"`
+				Timezone string `json:"exchangeTimezoneName"`
+			} `json:"meta"`
+			Events struct {
+				Dividends map[string]struct {
+					Amount float64 `json:"amount"`
+					Date   int64   `json:"date"`
+				} `json:"dividends"`
+				Splits map[string]struct {
+					Date        int64   `json:"date"`
+					Numerator   float64 `json:"numerator"`
+					Denominator float64 `json:"denominator"`
+					SplitRatio  string  `json:"splitRatio"`
+				} `json:"splits"`
+			} `json:"events"`
+		} `json:"result"`
+		Error interface{} `json:"error"`
+	} `json:"chart"`
+}
+
+func parseEventsResponse(body []byte) (*EventsResult, error) {
+	var resp eventsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Chart.Result) == 0 {
+		return &EventsResult{}, nil
+	}
+
+	data := resp.Chart.Result[0]
+	result := &EventsResult{
+		Symbol:   data.Meta.Symbol,
+		Timezone: data.Meta.Timezone,
+		Currency: data.Meta.Currency,
+	}
+	for _, d := range data.Events.Dividends {
+		result.Dividends = append(result.Dividends, DividendEvent{
+			Date:   d.Date,
+			Amount: d.Amount,
+		})
+	}
+	for _, s := range data.Events.Splits {
+		result.Splits = append(result.Splits, SplitEvent{
+			Date:        s.Date,
+			Numerator:   s.Numerator,
+			Denominator: s.Denominator,
+			Ratio:       s.SplitRatio,
+		})
+	}
+
+	// Events come keyed by timestamp in a JSON object
+	sort.Slice(result.Dividends, func(i, j int) bool {
+		return result.Dividends[i].Date < result.Dividends[j].Date
+	})
+	sort.Slice(result.Splits, func(i, j int) bool {
+		return result.Splits[i].Date < result.Splits[j].Date
+	})
+
+	return result, nil
+}
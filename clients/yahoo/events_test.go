@@ -0,0 +1,50 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseEventsResponse(t *testing.T) {
+	body := []byte(`{"chart":{"result":[{"meta":{"symbol":"AAPL","currency":"USD","exchangeTimezoneName":"America/New_York"},
+		"events":{
+			"dividends":{"1707489000":{"amount":0.24,"date":1707489000},"1699540200":{"amount":0.24,"date":1699540200}},
+			"splits":{"1598880600":{"date":1598880600,"numerator":4,"denominator":1,"splitRatio":"4:1"}}}}],"error":null}}`)
+
+	result, err := parseEventsResponse(body)
+	if err != nil {
+		t.Fatalf("parseEventsResponse failed: %v", err)
+	}
+
+	if result.Symbol != "AAPL" || result.Currency != "USD" {
+		t.Errorf("unexpected meta: %+v", result)
+	}
+	if len(result.Dividends) != 2 {
+		t.Fatalf("expected 2 dividends, got %d", len(result.Dividends))
+	}
+	if result.Dividends[0].Date != 1699540200 || result.Dividends[0].Amount != 0.24 {
+		t.Errorf("dividends not sorted by date: %+v", result.Dividends)
+	}
+	if len(result.Splits) != 1 || result.Splits[0].Numerator != 4 || result.Splits[0].Denominator != 1 {
+		t.Errorf("unexpected splits: %+v", result.Splits)
+	}
+}
+
+func TestClient_GetEvents(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	result, record, err := client.GetEvents(ctx, &EventsParams{Symbol: "AAPL"})
+	if err != nil {
+		checkAPIError(t, err)
+		return
+	}
+
+	t.Logf("Events Response Status: %d", record.Response.StatusCode)
+	t.Logf("Got %d dividends and %d splits", len(result.Dividends), len(result.Splits))
+
+	if len(result.Splits) == 0 {
+		t.Error("expected AAPL split history")
+	}
+}
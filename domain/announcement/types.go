@@ -57,6 +57,14 @@ func (r Request) CacheKey() string {
 	return key
 }
 
+// RequestSymbols returns the symbols targeted by the request.
+func (r Request) RequestSymbols() []string {
+	if r.Symbol == "" {
+		return nil
+	}
+	return []string{r.Symbol}
+}
+
 // Response represents an announcement/news response.
 type Response struct {
 	Symbol     string         // 标的代码
@@ -0,0 +1,247 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// ErrUnsupportedExchange is returned for exchanges without known sessions.
+var ErrUnsupportedExchange = errors.New("calendar: unsupported exchange")
+
+// maxSearchDays bounds the search of NextTradingDay and PrevTradingDay.
+const maxSearchDays = 366
+
+// LoadFunc loads the calendar of an exchange, typically from a provider.
+type LoadFunc func(ctx context.Context, req Request) (Response, error)
+
+// Calendar answers trading-day questions per exchange. Calendars are loaded
+// a year at a time through the loader and cached; when the loader fails or
+// is not set, RuleDays is used and the loader is retried after the retry
+// interval.
+type Calendar struct {
+	load    LoadFunc
+	refresh time.Duration
+	retry   time.Duration
+	now     func() time.Time
+
+	mu    sync.Mutex
+	years map[yearKey]*yearEntry
+}
+
+type yearKey struct {
+	exchange domain.Exchange
+	year     int
+}
+
+type yearEntry struct {
+	open      map[string]bool // YYYYMMDD -> is open
+	source    string
+	expiresAt time.Time
+}
+
+// Option configures a Calendar.
+type Option func(*Calendar)
+
+// WithLoader sets the loader of exchange calendars.
+func WithLoader(load LoadFunc) Option {
+	return func(c *Calendar) {
+		c.load = load
+	}
+}
+
+// WithRefreshInterval sets how long a loaded year stays cached. Default 24h.
+func WithRefreshInterval(d time.Duration) Option {
+	return func(c *Calendar) {
+		c.refresh = d
+	}
+}
+
+// WithRetryInterval sets how long the rule-based fallback is used before the
+// loader is tried again. Default 10m.
+func WithRetryInterval(d time.Duration) Option {
+	return func(c *Calendar) {
+		c.retry = d
+	}
+}
+
+// New creates a Calendar. Without a loader it only uses the built-in rules.
+func New(opts ...Option) *Calendar {
+	c := &Calendar{
+		refresh: 24 * time.Hour,
+		retry:   10 * time.Minute,
+		now:     time.Now,
+		years:   make(map[yearKey]*yearEntry),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// IsTradingDay reports whether the day of t, in the exchange time zone, is a
+// trading day.
+func (c *Calendar) IsTradingDay(ctx context.Context, exchange domain.Exchange, t time.Time) (bool, error) {
+	if !Supported(exchange) {
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedExchange, exchange)
+	}
+	day := dateOf(t, Location(exchange))
+	entry := c.yearOf(ctx, exchange, day.Year())
+	return entry.open[day.Format("20060102")], nil
+}
+
+// NextTradingDay returns the first trading day after the day of t.
+func (c *Calendar) NextTradingDay(ctx context.Context, exchange domain.Exchange, t time.Time) (time.Time, error) {
+	return c.step(ctx, exchange, t, 1)
+}
+
+// PrevTradingDay returns the last trading day before the day of t.
+func (c *Calendar) PrevTradingDay(ctx context.Context, exchange domain.Exchange, t time.Time) (time.Time, error) {
+	return c.step(ctx, exchange, t, -1)
+}
+
+func (c *Calendar) step(ctx context.Context, exchange domain.Exchange, t time.Time, dir int) (time.Time, error) {
+	if !Supported(exchange) {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrUnsupportedExchange, exchange)
+	}
+	day := dateOf(t, Location(exchange))
+	for i := 0; i < maxSearchDays; i++ {
+		day = day.AddDate(0, 0, dir)
+		if c.yearOf(ctx, exchange, day.Year()).open[day.Format("20060102")] {
+			return day, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("calendar: no trading day within %d days of %s", maxSearchDays, t.Format("2006-01-02"))
+}
+
+// TradingDaysBetween returns the trading days from the day of start to the
+// day of end, both inclusive, as local midnights.
+func (c *Calendar) TradingDaysBetween(ctx context.Context, exchange domain.Exchange, start, end time.Time) ([]time.Time, error) {
+	if !Supported(exchange) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedExchange, exchange)
+	}
+	loc := Location(exchange)
+	var days []time.Time
+	for d, last := dateOf(start, loc), dateOf(end, loc); !d.After(last); d = d.AddDate(0, 0, 1) {
+		if c.yearOf(ctx, exchange, d.Year()).open[d.Format("20060102")] {
+			days = append(days, d)
+		}
+	}
+	return days, nil
+}
+
+// Sessions returns the trading sessions of exchange on the day of t, or nil
+// when it is not a trading day.
+func (c *Calendar) Sessions(ctx context.Context, exchange domain.Exchange, t time.Time) ([]SessionTime, error) {
+	open, err := c.IsTradingDay(ctx, exchange, t)
+	if err != nil || !open {
+		return nil, err
+	}
+	return SessionsOn(exchange, t), nil
+}
+
+// IsOpen reports whether exchange is in a trading session at t.
+func (c *Calendar) IsOpen(ctx context.Context, exchange domain.Exchange, t time.Time) (bool, error) {
+	sessions, err := c.Sessions(ctx, exchange, t)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range sessions {
+		if !t.Before(s.Open) && t.Before(s.Close) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// State reports whether exchange is in a trading session at t and when that
+// next changes, i.e. the next session close or open. next is zero for
+// exchanges that never close.
+func (c *Calendar) State(ctx context.Context, exchange domain.Exchange, t time.Time) (open bool, next time.Time, err error) {
+	if h, ok := exchanges[exchange]; ok && h.everyDay && len(h.sessions) == 1 && h.sessions[0] == (Session{Close: 24 * time.Hour}) {
+		return true, time.Time{}, nil
+	}
+	sessions, err := c.Sessions(ctx, exchange, t)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	for _, s := range sessions {
+		if t.Before(s.Open) {
+			return false, s.Open, nil
+		}
+		if t.Before(s.Close) {
+			return true, s.Close, nil
+		}
+	}
+	day, err := c.NextTradingDay(ctx, exchange, t)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	return false, SessionsOn(exchange, day)[0].Open, nil
+}
+
+// Source returns where the cached calendar of exchange for the year of t
+// came from: the loader's provider name or RuleSource.
+func (c *Calendar) Source(ctx context.Context, exchange domain.Exchange, t time.Time) string {
+	if !Supported(exchange) {
+		return ""
+	}
+	return c.yearOf(ctx, exchange, dateOf(t, Location(exchange)).Year()).source
+}
+
+// Invalidate drops all cached years.
+func (c *Calendar) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.years = make(map[yearKey]*yearEntry)
+}
+
+// yearOf returns the cached calendar of exchange for year, loading it when
+// missing or expired.
+func (c *Calendar) yearOf(ctx context.Context, exchange domain.Exchange, year int) *yearEntry {
+	key := yearKey{exchange, year}
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.years[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry
+	}
+
+	loc := Location(exchange)
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, loc)
+
+	entry = nil
+	if c.load != nil {
+		resp, err := c.load(ctx, Request{Exchange: exchange, StartTime: start, EndTime: end})
+		if err == nil && len(resp.Days) > 0 {
+			entry = newYearEntry(resp.Days, resp.Source, now.Add(c.refresh))
+		}
+	}
+	if entry == nil {
+		expires := now.Add(c.retry)
+		if c.load == nil {
+			expires = now.Add(c.refresh)
+		}
+		entry = newYearEntry(RuleDays(exchange, start, end), RuleSource, expires)
+	}
+
+	c.mu.Lock()
+	c.years[key] = entry
+	c.mu.Unlock()
+	return entry
+}
+
+func newYearEntry(days []Day, source string, expiresAt time.Time) *yearEntry {
+	open := make(map[string]bool, len(days))
+	for _, d := range days {
+		open[d.Date.Format("20060102")] = d.IsOpen
+	}
+	return &yearEntry{open: open, source: source, expiresAt: expiresAt}
+}
@@ -0,0 +1,153 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+func day(year int, month time.Month, d int, loc *time.Location) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, loc)
+}
+
+func TestRuleDays_Holidays(t *testing.T) {
+	tests := []struct {
+		exchange domain.Exchange
+		date     time.Time
+		open     bool
+	}{
+		{domain.ExchangeNYSE, day(2024, time.March, 29, locNewYork), false},    // Good Friday
+		{domain.ExchangeNYSE, day(2024, time.November, 28, locNewYork), false}, // Thanksgiving
+		{domain.ExchangeNYSE, day(2026, time.July, 3, locNewYork), false},      // Independence Day observed
+		{domain.ExchangeNYSE, day(2024, time.November, 29, locNewYork), true},
+		{domain.ExchangeSH, day(2025, time.January, 28, locShanghai), false},
+		{domain.ExchangeSH, day(2025, time.January, 26, locShanghai), false}, // make-up working Sunday
+		{domain.ExchangeSH, day(2025, time.February, 5, locShanghai), true},
+		{domain.ExchangeHKEX, day(2025, time.April, 18, locHongKong), false},
+		{domain.ExchangeBinance, day(2025, time.January, 4, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		days := RuleDays(tt.exchange, tt.date, tt.date)
+		if len(days) != 1 || days[0].IsOpen != tt.open {
+			t.Errorf("RuleDays(%s, %s) = %+v, want open %v", tt.exchange, tt.date.Format("2006-01-02"), days, tt.open)
+		}
+	}
+}
+
+func TestCalendar_NextPrev(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	// Friday before the 2024 Memorial Day weekend
+	friday := day(2024, time.May, 24, locNewYork)
+	next, err := c.NextTradingDay(ctx, domain.ExchangeNYSE, friday)
+	if err != nil || !next.Equal(day(2024, time.May, 28, locNewYork)) {
+		t.Errorf("NextTradingDay() = %v, %v", next, err)
+	}
+	prev, err := c.PrevTradingDay(ctx, domain.ExchangeNYSE, next)
+	if err != nil || !prev.Equal(friday) {
+		t.Errorf("PrevTradingDay() = %v, %v", prev, err)
+	}
+
+	// Across the year boundary
+	next, err = c.NextTradingDay(ctx, domain.ExchangeSH, day(2024, time.December, 31, locShanghai))
+	if err != nil || !next.Equal(day(2025, time.January, 2, locShanghai)) {
+		t.Errorf("NextTradingDay() = %v, %v", next, err)
+	}
+
+	days, err := c.TradingDaysBetween(ctx, domain.ExchangeSH, day(2025, time.January, 27, locShanghai), day(2025, time.February, 7, locShanghai))
+	if err != nil || len(days) != 4 {
+		t.Errorf("TradingDaysBetween() = %v, %v, want 4 days", days, err)
+	}
+
+	if _, err := c.IsTradingDay(ctx, domain.Exchange("XX"), friday); !errors.Is(err, ErrUnsupportedExchange) {
+		t.Errorf("IsTradingDay() error = %v, want ErrUnsupportedExchange", err)
+	}
+}
+
+func TestCalendar_IsOpen(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	tests := []struct {
+		at   time.Time
+		open bool
+	}{
+		{time.Date(2025, time.March, 3, 10, 0, 0, 0, locShanghai), true},
+		{time.Date(2025, time.March, 3, 12, 0, 0, 0, locShanghai), false}, // lunch break
+		{time.Date(2025, time.March, 3, 15, 0, 0, 0, locShanghai), false},
+		{time.Date(2025, time.March, 1, 10, 0, 0, 0, locShanghai), false}, // Saturday
+	}
+	for _, tt := range tests {
+		open, err := c.IsOpen(ctx, domain.ExchangeSH, tt.at)
+		if err != nil || open != tt.open {
+			t.Errorf("IsOpen(%v) = %v, %v, want %v", tt.at, open, err, tt.open)
+		}
+	}
+}
+
+func TestCalendar_Loader(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	fail := true
+	c := New(WithLoader(func(_ context.Context, req Request) (Response, error) {
+		calls++
+		if fail {
+			return Response{}, errors.New("unavailable")
+		}
+		// Every day is closed except the requested start
+		days := RuleDays(req.Exchange, req.StartTime, req.EndTime)
+		for i := range days {
+			days[i].IsOpen = i == 0
+		}
+		return Response{Exchange: req.Exchange, Days: days, Source: "test"}, nil
+	}))
+	now := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	date := day(2025, time.March, 3, locShanghai)
+	if open, _ := c.IsTradingDay(ctx, domain.ExchangeSH, date); !open {
+		t.Error("rule fallback: 2025-03-03 should be open")
+	}
+	if src := c.Source(ctx, domain.ExchangeSH, date); src != RuleSource || calls != 1 {
+		t.Errorf("Source() = %q after %d loads, want %q after 1", src, calls, RuleSource)
+	}
+
+	// The loader is retried once the fallback expires
+	fail = false
+	now = now.Add(11 * time.Minute)
+	if open, _ := c.IsTradingDay(ctx, domain.ExchangeSH, date); open {
+		t.Error("loaded calendar: 2025-03-03 should be closed")
+	}
+	if src := c.Source(ctx, domain.ExchangeSH, date); src != "test" || calls != 2 {
+		t.Errorf("Source() = %q after %d loads, want test after 2", src, calls)
+	}
+}
+
+func TestCalendar_State(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+
+	tests := []struct {
+		exchange domain.Exchange
+		at       time.Time
+		open     bool
+		next     time.Time
+	}{
+		{domain.ExchangeSH, time.Date(2025, time.March, 3, 10, 0, 0, 0, locShanghai), true, time.Date(2025, time.March, 3, 11, 30, 0, 0, locShanghai)},
+		{domain.ExchangeSH, time.Date(2025, time.March, 3, 12, 0, 0, 0, locShanghai), false, time.Date(2025, time.March, 3, 13, 0, 0, 0, locShanghai)},
+		// Friday after the close: next open is on Monday
+		{domain.ExchangeNYSE, time.Date(2025, time.March, 7, 17, 0, 0, 0, locNewYork), false, time.Date(2025, time.March, 10, 9, 30, 0, 0, locNewYork)},
+		{domain.ExchangeBinance, time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC), true, time.Time{}},
+	}
+	for _, tt := range tests {
+		open, next, err := c.State(ctx, tt.exchange, tt.at)
+		if err != nil || open != tt.open || !next.Equal(tt.next) {
+			t.Errorf("State(%s, %v) = %v, %v, %v, want %v, %v", tt.exchange, tt.at, open, next, err, tt.open, tt.next)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// RuleSource is the name reported for calendars computed by RuleDays.
+const RuleSource = "rules"
+
+// RuleDays computes the calendar of exchange between start and end
+// (inclusive) from weekends and holiday rules, without any network access.
+//
+// US holidays follow the NYSE rules for any year. CN and HK holidays are set
+// by the government each year; the built-in lists cover the years in
+// cnHolidayList and hkHolidayList, other years only close on weekends.
+func RuleDays(exchange domain.Exchange, start, end time.Time) []Day {
+	h, ok := exchanges[exchange]
+	if !ok {
+		return nil
+	}
+	first, last := dateOf(start, h.location), dateOf(end, h.location)
+
+	holidays := make(map[string]bool)
+	if h.holidaysOf != nil {
+		for year := first.Year(); year <= last.Year(); year++ {
+			for _, d := range h.holidaysOf(year) {
+				holidays[d.Format("20060102")] = true
+			}
+		}
+	}
+
+	var days []Day
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		open := h.everyDay || (!isWeekend(d) && !holidays[d.Format("20060102")])
+		days = append(days, Day{Date: d, IsOpen: open})
+	}
+	return days
+}
+
+func isWeekend(d time.Time) bool {
+	return d.Weekday() == time.Saturday || d.Weekday() == time.Sunday
+}
+
+// cnHolidayList holds the weekdays the SH/SZ/BJ exchanges are closed, as
+// announced by the State Council. Make-up working weekends are not trading days.
+var cnHolidayList = map[int][]string{
+	2024: {
+		"0101",
+		"0209", "0212", "0213", "0214", "0215", "0216",
+		"0404", "0405",
+		"0501", "0502", "0503",
+		"0610",
+		"0916", "0917",
+		"1001", "1002", "1003", "1004", "1007",
+	},
+	2025: {
+		"0101",
+		"0128", "0129", "0130", "0131", "0203", "0204",
+		"0404",
+		"0501", "0502", "0505",
+		"0602",
+		"1001", "1002", "1003", "1006", "1007", "1008",
+	},
+	2026: {
+		"0101", "0102",
+		"0216", "0217", "0218", "0219", "0220", "0223",
+		"0406",
+		"0501", "0504", "0505",
+		"0619",
+		"0925",
+		"1001", "1002", "1005", "1006", "1007",
+	},
+}
+
+// hkHolidayList holds the weekdays HKEX is closed for general holidays.
+var hkHolidayList = map[int][]string{
+	2024: {
+		"0101", "0212", "0213", "0329", "0401", "0404", "0501", "0515",
+		"0610", "0701", "0918", "1001", "1011", "1225", "1226",
+	},
+	2025: {
+		"0101", "0129", "0130", "0131", "0404", "0418", "0421", "0501",
+		"0505", "0701", "1001", "1007", "1029", "1225", "1226",
+	},
+	2026: {
+		"0101", "0217", "0218", "0219", "0403", "0406", "0407", "0501",
+		"0525", "0619", "0701", "1001", "1019", "1225", "1228",
+	},
+}
+
+func cnHolidays(year int) []time.Time {
+	return listedHolidays(cnHolidayList[year], year, locShanghai)
+}
+
+func hkHolidays(year int) []time.Time {
+	return listedHolidays(hkHolidayList[year], year, locHongKong)
+}
+
+func listedHolidays(list []string, year int, loc *time.Location) []time.Time {
+	out := make([]time.Time, 0, len(list))
+	for _, mmdd := range list {
+		d, err := time.Parse("0102", mmdd)
+		if err == nil {
+			out = append(out, time.Date(year, d.Month(), d.Day(), 0, 0, 0, 0, loc))
+		}
+	}
+	return out
+}
+
+// usHolidays returns the NYSE full-day holidays of year.
+func usHolidays(year int) []time.Time {
+	loc := locNewYork
+	date := func(m time.Month, d int) time.Time {
+		return time.Date(year, m, d, 0, 0, 0, 0, loc)
+	}
+	// observed moves Saturday holidays to Friday and Sunday holidays to Monday
+	observed := func(d time.Time) time.Time {
+		switch d.Weekday() {
+		case time.Saturday:
+			return d.AddDate(0, 0, -1)
+		case time.Sunday:
+			return d.AddDate(0, 0, 1)
+		}
+		return d
+	}
+
+	var days []time.Time
+	// New Year's Day on a Saturday is not observed on the preceding Friday
+	if newYear := date(time.January, 1); newYear.Weekday() != time.Saturday {
+		days = append(days, observed(newYear))
+	}
+	days = append(days,
+		nthWeekday(year, time.January, time.Monday, 3, loc),  // Martin Luther King Jr. Day
+		nthWeekday(year, time.February, time.Monday, 3, loc), // Washington's Birthday
+		easter(year, loc).AddDate(0, 0, -2),                  // Good Friday
+		lastWeekday(year, time.May, time.Monday, loc),        // Memorial Day
+	)
+	if year >= 2022 {
+		days = append(days, observed(date(time.June, 19))) // Juneteenth
+	}
+	days = append(days,
+		observed(date(time.July, 4)),                           // Independence Day
+		nthWeekday(year, time.September, time.Monday, 1, loc),  // Labor Day
+		nthWeekday(year, time.November, time.Thursday, 4, loc), // Thanksgiving
+		observed(date(time.December, 25)),                      // Christmas
+	)
+	return days
+}
+
+// nthWeekday returns the nth weekday wd of month.
+func nthWeekday(year int, month time.Month, wd time.Weekday, n int, loc *time.Location) time.Time {
+	d := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	offset := (int(wd) - int(d.Weekday()) + 7) % 7
+	return d.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday returns the last weekday wd of month.
+func lastWeekday(year int, month time.Month, wd time.Weekday, loc *time.Location) time.Time {
+	d := time.Date(year, month+1, 0, 0, 0, 0, 0, loc)
+	offset := (int(d.Weekday()) - int(wd) + 7) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+// easter returns Easter Sunday of year (anonymous Gregorian algorithm).
+func easter(year int, loc *time.Location) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,147 @@
+// Package calendar provides trading calendar domain types.
+//
+// This package defines the request/response types for exchange trading
+// calendars, the trading sessions of each exchange, a rule-based calendar for
+// offline use and a cached Calendar answering trading-day questions.
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// Request represents a trading calendar request.
+type Request struct {
+	Exchange  domain.Exchange // 交易所
+	StartTime time.Time       // 起始日期
+	EndTime   time.Time       // 结束日期
+}
+
+// CacheKey returns the cache key for the request.
+func (r Request) CacheKey() string {
+	return "calendar:" + string(r.Exchange) + ":" + r.StartTime.Format("20060102") + ":" + r.EndTime.Format("20060102")
+}
+
+// RequestMarket returns the market of the requested exchange.
+func (r Request) RequestMarket() domain.Market {
+	return domain.MarketOfExchange(r.Exchange)
+}
+
+// Response represents a trading calendar response.
+type Response struct {
+	Exchange domain.Exchange // 交易所
+	Days     []Day           // 日历, 按日期升序
+	Source   string          // 数据源名称
+}
+
+// Day represents one calendar day of an exchange.
+type Day struct {
+	Date   time.Time // 日期 (交易所当地时间零点)
+	IsOpen bool      // 是否交易日
+}
+
+// Session is a continuous trading period, as offsets from local midnight.
+type Session struct {
+	Open  time.Duration // 开盘时间
+	Close time.Duration // 收盘时间
+}
+
+// SessionTime is a trading session on a given day.
+type SessionTime struct {
+	Open  time.Time // 开盘时刻
+	Close time.Time // 收盘时刻
+}
+
+// Source defines the interface for trading calendar providers.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context, req Request) (Response, error)
+	HealthCheck(ctx context.Context) error
+}
+
+type exchangeHours struct {
+	location   *time.Location
+	sessions   []Session
+	everyDay   bool // 无休市日 (加密货币)
+	holidaysOf func(year int) []time.Time
+}
+
+var (
+	locShanghai, _ = time.LoadLocation("Asia/Shanghai")
+	locHongKong, _ = time.LoadLocation("Asia/Hong_Kong")
+	locNewYork, _  = time.LoadLocation("America/New_York")
+)
+
+var (
+	cnSessions = []Session{
+		{Open: 9*time.Hour + 30*time.Minute, Close: 11*time.Hour + 30*time.Minute},
+		{Open: 13 * time.Hour, Close: 15 * time.Hour},
+	}
+	hkSessions = []Session{
+		{Open: 9*time.Hour + 30*time.Minute, Close: 12 * time.Hour},
+		{Open: 13 * time.Hour, Close: 16 * time.Hour},
+	}
+	usSessions = []Session{
+		{Open: 9*time.Hour + 30*time.Minute, Close: 16 * time.Hour},
+	}
+	cryptoSessions = []Session{
+		{Open: 0, Close: 24 * time.Hour},
+	}
+)
+
+var exchanges = map[domain.Exchange]exchangeHours{
+	domain.ExchangeSH:       {location: locShanghai, sessions: cnSessions, holidaysOf: cnHolidays},
+	domain.ExchangeSZ:       {location: locShanghai, sessions: cnSessions, holidaysOf: cnHolidays},
+	domain.ExchangeBJ:       {location: locShanghai, sessions: cnSessions, holidaysOf: cnHolidays},
+	domain.ExchangeHKEX:     {location: locHongKong, sessions: hkSessions, holidaysOf: hkHolidays},
+	domain.ExchangeNYSE:     {location: locNewYork, sessions: usSessions, holidaysOf: usHolidays},
+	domain.ExchangeNASDAQ:   {location: locNewYork, sessions: usSessions, holidaysOf: usHolidays},
+	domain.ExchangeAMEX:     {location: locNewYork, sessions: usSessions, holidaysOf: usHolidays},
+	domain.ExchangeBinance:  {location: time.UTC, sessions: cryptoSessions, everyDay: true},
+	domain.ExchangeOKX:      {location: time.UTC, sessions: cryptoSessions, everyDay: true},
+	domain.ExchangeCoinbase: {location: time.UTC, sessions: cryptoSessions, everyDay: true},
+	domain.ExchangeBitget:   {location: time.UTC, sessions: cryptoSessions, everyDay: true},
+}
+
+// Supported reports whether the package knows the sessions of exchange.
+func Supported(exchange domain.Exchange) bool {
+	_, ok := exchanges[exchange]
+	return ok
+}
+
+// Location returns the time zone of exchange, or UTC for unknown exchanges.
+func Location(exchange domain.Exchange) *time.Location {
+	if h, ok := exchanges[exchange]; ok {
+		return h.location
+	}
+	return time.UTC
+}
+
+// Sessions returns the regular trading sessions of exchange. Half days are
+// not modelled.
+func Sessions(exchange domain.Exchange) []Session {
+	return exchanges[exchange].sessions
+}
+
+// SessionsOn returns the sessions of exchange on the day of date, regardless
+// of whether that day is a trading day.
+func SessionsOn(exchange domain.Exchange, date time.Time) []SessionTime {
+	h, ok := exchanges[exchange]
+	if !ok {
+		return nil
+	}
+	day := dateOf(date, h.location)
+	out := make([]SessionTime, 0, len(h.sessions))
+	for _, s := range h.sessions {
+		out = append(out, SessionTime{Open: day.Add(s.Open), Close: day.Add(s.Close)})
+	}
+	return out
+}
+
+// dateOf returns local midnight of t's day in loc.
+func dateOf(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
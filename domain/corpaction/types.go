@@ -0,0 +1,136 @@
+// Package corpaction provides corporate action domain types.
+//
+// This package defines the request/response types for corporate actions that
+// change the share count or pay out to shareholders: cash dividends, stock
+// dividends, capitalization issues, splits and rights issues.
+package corpaction
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// ActionType represents the kind of a corporate action.
+type ActionType string
+
+const (
+	ActionCashDividend  ActionType = "cash_dividend"  // 现金分红
+	ActionStockDividend ActionType = "stock_dividend" // 送股
+	ActionTransfer      ActionType = "transfer"       // 资本公积转增股本
+	ActionSplit         ActionType = "split"          // 拆股/合股
+	ActionRights        ActionType = "rights"         // 配股
+)
+
+// Request represents a corporate action request.
+type Request struct {
+	Symbol    string       // 标的代码 (e.g., "000001.SZ", "AAPL.US")
+	Types     []ActionType // 需要的事件类型, 为空表示全部
+	StartTime time.Time    // 除权除息日起始 (可选)
+	EndTime   time.Time    // 除权除息日截止 (可选)
+}
+
+// CacheKey returns the cache key for the request.
+func (r Request) CacheKey() string {
+	key := "corpaction:" + r.Symbol + ":" + r.StartTime.Format("20060102") + ":" + r.EndTime.Format("20060102")
+	for _, t := range r.Types {
+		key += ":" + string(t)
+	}
+	return key
+}
+
+// RequestSymbols returns the symbols targeted by the request.
+func (r Request) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+// Filter keeps the actions matching the request's types and ex-date range and
+// sorts them by ex-date. Actions without an ex-date are dropped when a date
+// range is set.
+func (r Request) Filter(actions []Action) []Action {
+	out := make([]Action, 0, len(actions))
+	for _, a := range actions {
+		if len(r.Types) > 0 && !r.hasType(a.Type) {
+			continue
+		}
+		if !r.StartTime.IsZero() || !r.EndTime.IsZero() {
+			if a.ExDate.IsZero() {
+				continue
+			}
+			if !r.StartTime.IsZero() && a.ExDate.Before(r.StartTime) {
+				continue
+			}
+			if !r.EndTime.IsZero() && a.ExDate.After(r.EndTime) {
+				continue
+			}
+		}
+		out = append(out, a)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ExDate.Before(out[j].ExDate)
+	})
+	return out
+}
+
+func (r Request) hasType(t ActionType) bool {
+	for _, want := range r.Types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Response represents a corporate action response.
+type Response struct {
+	Symbol  string   // 标的代码
+	Actions []Action // 公司行为, 按除权除息日升序
+	Source  string   // 数据源名称
+}
+
+// Action represents a single corporate action. A distribution that pays cash
+// and shares on the same ex-date is reported as one action per type.
+//
+// Ratio is per existing share: the bonus shares of a stock dividend or
+// transfer, the subscribable shares of a rights issue, or the shares after a
+// split per share before (0.5 for a 1-for-2 reverse split).
+type Action struct {
+	Symbol     string     // 标的代码
+	Type       ActionType // 事件类型
+	AnnDate    time.Time  // 公告日
+	RecordDate time.Time  // 股权登记日
+	ExDate     time.Time  // 除权除息日
+	PayDate    time.Time  // 派息日/红股上市日
+	Cash       float64    // 每股现金分红 (税前, 仅现金分红)
+	Ratio      float64    // 每股送/转/配股数, 或拆股后每股股数
+	Price      float64    // 配股价 (仅配股)
+	Progress   string     // 实施进度
+}
+
+// ShareMultiplier returns the shares held after the action per share held
+// before it, or 1 for actions that leave the share count unchanged.
+func (a Action) ShareMultiplier() float64 {
+	switch a.Type {
+	case ActionStockDividend, ActionTransfer, ActionRights:
+		return 1 + a.Ratio
+	case ActionSplit:
+		if a.Ratio > 0 {
+			return a.Ratio
+		}
+	}
+	return 1
+}
+
+// Source defines the interface for corporate action data providers.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context, req Request) (Response, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// ParseSymbol parses a symbol string into code and exchange.
+func ParseSymbol(symbol string) (code string, exchange domain.Exchange, ok bool) {
+	return domain.ParseSymbol(symbol)
+}
@@ -44,6 +44,14 @@ func (r Request) CacheKey() string {
 	return "financial:" + r.Symbol + ":" + string(r.ReportType)
 }
 
+// RequestSymbols returns the symbols targeted by the request
+func (r Request) RequestSymbols() []string {
+	if r.Symbol == "" {
+		return nil
+	}
+	return []string{r.Symbol}
+}
+
 // Response represents a financial data response
 type Response struct {
 	Symbol     string          // Symbol code
@@ -0,0 +1,201 @@
+// Package fund provides mutual fund domain types.
+//
+// This package defines the request/response types for Chinese mutual funds:
+// the fund list, daily NAV history and intraday estimated NAVs. Funds are
+// identified by their 6-digit code.
+package fund
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// Type represents the investment category of a fund.
+type Type string
+
+const (
+	TypeStock  Type = "stock"  // 股票型
+	TypeHybrid Type = "hybrid" // 混合型
+	TypeBond   Type = "bond"   // 债券型
+	TypeIndex  Type = "index"  // 指数型
+	TypeMoney  Type = "money"  // 货币型
+	TypeQDII   Type = "qdii"   // QDII
+	TypeFOF    Type = "fof"    // FOF
+	TypeOther  Type = "other"  // 其他 (商品、REITs 等)
+)
+
+// ParseType maps a Chinese fund category (e.g. "混合型-偏股", "货币市场型")
+// to a Type.
+func ParseType(category string) Type {
+	switch {
+	case category == "":
+		return ""
+	case strings.HasPrefix(category, "QDII"):
+		return TypeQDII
+	case strings.HasPrefix(category, "FOF"):
+		return TypeFOF
+	case strings.Contains(category, "指数"):
+		return TypeIndex
+	case strings.HasPrefix(category, "股票"):
+		return TypeStock
+	case strings.HasPrefix(category, "混合"):
+		return TypeHybrid
+	case strings.Contains(category, "债"):
+		return TypeBond
+	case strings.HasPrefix(category, "货币"):
+		return TypeMoney
+	}
+	return TypeOther
+}
+
+// NormalizeCode returns the 6-digit code of a fund code such as "000001",
+// "000001.OF" or "510300.SH".
+func NormalizeCode(code string) string {
+	code = strings.TrimSpace(code)
+	if i := strings.IndexByte(code, '.'); i >= 0 {
+		code = code[:i]
+	}
+	return code
+}
+
+// ListRequest represents a fund list request.
+type ListRequest struct {
+	Type       Type // 按类型筛选, 为空表示全部
+	PageSize   int  // 分页大小
+	PageNumber int  // 页码
+}
+
+// CacheKey returns the cache key for the request.
+func (r ListRequest) CacheKey() string {
+	return "fund:list:" + string(r.Type) + ":" + strconv.Itoa(r.PageSize) + ":" + strconv.Itoa(r.PageNumber)
+}
+
+// RequestMarket returns the market of the request.
+func (r ListRequest) RequestMarket() domain.Market {
+	return domain.MarketCN
+}
+
+// ListResponse represents a fund list response.
+type ListResponse struct {
+	Funds      []Fund // 基金列表
+	Total      int    // 总数
+	Source     string // 数据源名称
+	PageNumber int    // 页码
+	PageSize   int    // 分页大小
+}
+
+// Fund represents a mutual fund.
+type Fund struct {
+	Code       string    // 基金代码 (6位)
+	Name       string    // 基金简称
+	FullName   string    // 基金全称
+	Type       Type      // 基金类型
+	Category   string    // 数据源原始分类 (e.g., "混合型-偏股")
+	Management string    // 基金管理人
+	Custodian  string    // 基金托管人
+	FoundDate  time.Time // 成立日期
+	Listed     bool      // 是否场内交易
+}
+
+// Filter keeps the funds of the request's type and applies its pagination.
+// It returns the page and the number of matching funds.
+func (r ListRequest) Filter(funds []Fund) ([]Fund, int) {
+	if r.Type != "" {
+		out := make([]Fund, 0, len(funds))
+		for _, f := range funds {
+			if f.Type == r.Type {
+				out = append(out, f)
+			}
+		}
+		funds = out
+	}
+	total := len(funds)
+	if r.PageSize <= 0 {
+		return funds, total
+	}
+	start := 0
+	if r.PageNumber > 1 {
+		start = (r.PageNumber - 1) * r.PageSize
+	}
+	if start >= total {
+		return nil, total
+	}
+	return funds[start:min(start+r.PageSize, total)], total
+}
+
+// NAVRequest represents a fund NAV history request.
+type NAVRequest struct {
+	Code      string    // 基金代码
+	StartTime time.Time // 起始日期 (可选)
+	EndTime   time.Time // 结束日期 (可选)
+}
+
+// CacheKey returns the cache key for the request.
+func (r NAVRequest) CacheKey() string {
+	return "fund:nav:" + NormalizeCode(r.Code) + ":" + r.StartTime.Format("20060102") + ":" + r.EndTime.Format("20060102")
+}
+
+// RequestMarket returns the market of the request.
+func (r NAVRequest) RequestMarket() domain.Market {
+	return domain.MarketCN
+}
+
+// NAVResponse represents a fund NAV history response.
+type NAVResponse struct {
+	Code   string // 基金代码
+	NAVs   []NAV  // 净值, 按日期升序
+	Source string // 数据源名称
+}
+
+// NAV represents the published NAV of a fund on one day.
+type NAV struct {
+	Date       time.Time // 净值日期
+	UnitNAV    float64   // 单位净值
+	AccumNAV   float64   // 累计净值
+	ChangeRate float64   // 日增长率 (%)
+}
+
+// EstimateRequest represents an intraday NAV estimate request.
+type EstimateRequest struct {
+	Codes []string // 基金代码
+}
+
+// CacheKey returns the cache key for the request.
+func (r EstimateRequest) CacheKey() string {
+	return "fund:estimate:" + strings.Join(r.Codes, ",")
+}
+
+// RequestMarket returns the market of the request.
+func (r EstimateRequest) RequestMarket() domain.Market {
+	return domain.MarketCN
+}
+
+// EstimateResponse represents an intraday NAV estimate response.
+type EstimateResponse struct {
+	Estimates []Estimate // 估值
+	Source    string     // 数据源名称
+}
+
+// Estimate represents the intraday estimated NAV of a fund.
+type Estimate struct {
+	Code          string    // 基金代码
+	Name          string    // 基金简称
+	NAVDate       time.Time // 最近净值日期
+	NAV           float64   // 最近单位净值
+	EstNAV        float64   // 估算净值
+	EstChangeRate float64   // 估算涨跌幅 (%)
+	EstTime       time.Time // 估值时间
+}
+
+// Source defines the interface for fund data providers.
+type Source interface {
+	Name() string
+	FetchList(ctx context.Context, req ListRequest) (ListResponse, error)
+	FetchNAV(ctx context.Context, req NAVRequest) (NAVResponse, error)
+	FetchEstimate(ctx context.Context, req EstimateRequest) (EstimateResponse, error)
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,53 @@
+package fund
+
+import "testing"
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		category string
+		want     Type
+	}{
+		{"股票型", TypeStock},
+		{"混合型-偏股", TypeHybrid},
+		{"债券型-长债", TypeBond},
+		{"定开债券", TypeBond},
+		{"指数型-股票", TypeIndex},
+		{"货币型-普通货币", TypeMoney},
+		{"货币市场型", TypeMoney},
+		{"QDII-普通股票", TypeQDII},
+		{"FOF-稳健型", TypeFOF},
+		{"Reits", TypeOther},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseType(tt.category); got != tt.want {
+			t.Errorf("ParseType(%q) = %q, want %q", tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	for _, code := range []string{"000001", "000001.OF", " 000001.SZ"} {
+		if got := NormalizeCode(code); got != "000001" {
+			t.Errorf("NormalizeCode(%q) = %q", code, got)
+		}
+	}
+}
+
+func TestListRequest_Filter(t *testing.T) {
+	funds := []Fund{
+		{Code: "1", Type: TypeStock},
+		{Code: "2", Type: TypeBond},
+		{Code: "3", Type: TypeStock},
+		{Code: "4", Type: TypeStock},
+	}
+
+	page, total := ListRequest{Type: TypeStock, PageSize: 2, PageNumber: 2}.Filter(funds)
+	if total != 3 || len(page) != 1 || page[0].Code != "4" {
+		t.Errorf("Filter() = %+v, %d", page, total)
+	}
+	page, total = ListRequest{PageSize: 2, PageNumber: 3}.Filter(funds)
+	if total != 4 || len(page) != 0 {
+		t.Errorf("Filter() past the end = %+v, %d", page, total)
+	}
+}
@@ -0,0 +1,120 @@
+// Package index provides market index domain types.
+//
+// This package defines the request/response types for index listings and
+// index constituent weights. Index bars are served by the kline domain: an
+// index symbol carries its exchange suffix (e.g. "000300.SH", "399001.SZ")
+// and parses to domain.AssetTypeIndex, so providers fetch index rather than
+// stock data.
+package index
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// Index represents a market index.
+type Index struct {
+	Symbol    string          // 指数代码 (e.g., "000300.SH")
+	Name      string          // 指数简称
+	FullName  string          // 指数全称
+	Exchange  domain.Exchange // 行情所在交易所
+	Publisher string          // 发布机构 (e.g., "中证指数有限公司")
+	Category  string          // 指数类别 (e.g., "规模指数")
+	BaseDate  time.Time       // 基期
+	BasePoint float64         // 基点
+	ListDate  time.Time       // 发布日期
+}
+
+// ListRequest represents an index list request.
+type ListRequest struct {
+	Exchange   domain.Exchange // 按交易所筛选 (SH, SZ, BJ), 为空表示全部
+	PageSize   int             // 分页大小
+	PageNumber int             // 页码
+}
+
+// CacheKey returns the cache key for the request.
+func (r ListRequest) CacheKey() string {
+	return "index:list:" + string(r.Exchange) + ":" + strconv.Itoa(r.PageSize) + ":" + strconv.Itoa(r.PageNumber)
+}
+
+// RequestMarket returns the market of the request.
+func (r ListRequest) RequestMarket() domain.Market {
+	return domain.MarketCN
+}
+
+// Filter keeps the indexes of the request's exchange and applies its
+// pagination. It returns the page and the number of matching indexes.
+func (r ListRequest) Filter(indexes []Index) ([]Index, int) {
+	if r.Exchange != "" {
+		out := make([]Index, 0, len(indexes))
+		for _, idx := range indexes {
+			if idx.Exchange == r.Exchange {
+				out = append(out, idx)
+			}
+		}
+		indexes = out
+	}
+	total := len(indexes)
+	if r.PageSize <= 0 {
+		return indexes, total
+	}
+	start := 0
+	if r.PageNumber > 1 {
+		start = (r.PageNumber - 1) * r.PageSize
+	}
+	if start >= total {
+		return nil, total
+	}
+	return indexes[start:min(start+r.PageSize, total)], total
+}
+
+// ListResponse represents an index list response.
+type ListResponse struct {
+	Indexes    []Index // 指数列表
+	Total      int     // 总数
+	Source     string  // 数据源名称
+	PageNumber int     // 页码
+	PageSize   int     // 分页大小
+}
+
+// WeightsRequest represents an index constituent weights request.
+type WeightsRequest struct {
+	Symbol string    // 指数代码 (e.g., "000300.SH")
+	Date   time.Time // 快照日期, 返回该日及之前最近一期; 为空表示最新
+}
+
+// CacheKey returns the cache key for the request.
+func (r WeightsRequest) CacheKey() string {
+	return "index:weights:" + r.Symbol + ":" + r.Date.Format("20060102")
+}
+
+// RequestSymbols returns the requested index.
+func (r WeightsRequest) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+// WeightsResponse represents the constituent weights of an index on one date.
+type WeightsResponse struct {
+	Symbol  string    // 指数代码
+	Date    time.Time // 快照日期
+	Weights []Weight  // 成分股权重, 按权重降序
+	Source  string    // 数据源名称
+}
+
+// Weight represents the weight of one constituent.
+type Weight struct {
+	Symbol string  // 成分股代码
+	Name   string  // 成分股名称 (可选)
+	Weight float64 // 权重 (%)
+}
+
+// Source defines the interface for index data providers.
+type Source interface {
+	Name() string
+	FetchList(ctx context.Context, req ListRequest) (ListResponse, error)
+	FetchWeights(ctx context.Context, req WeightsRequest) (WeightsResponse, error)
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,23 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/souloss/quantds/domain"
+)
+
+func TestListRequest_Filter(t *testing.T) {
+	indexes := []Index{
+		{Symbol: "000001.SH", Exchange: domain.ExchangeSH},
+		{Symbol: "399001.SZ", Exchange: domain.ExchangeSZ},
+		{Symbol: "000300.SH", Exchange: domain.ExchangeSH},
+	}
+
+	page, total := ListRequest{Exchange: domain.ExchangeSH, PageSize: 1, PageNumber: 2}.Filter(indexes)
+	if total != 2 || len(page) != 1 || page[0].Symbol != "000300.SH" {
+		t.Errorf("Filter() = %v, %d", page, total)
+	}
+	if page, total := (ListRequest{}).Filter(indexes); total != 3 || len(page) != 3 {
+		t.Errorf("Filter() without filters = %v, %d", page, total)
+	}
+}
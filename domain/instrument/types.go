@@ -65,6 +65,14 @@ func (r Request) CacheKey() string {
 	return key
 }
 
+// RequestMarket returns the market implied by the exchange filter, if any.
+func (r Request) RequestMarket() domain.Market {
+	if r.Exchange == "" {
+		return ""
+	}
+	return domain.MarketOfExchange(r.Exchange)
+}
+
 // Response represents a securities list response.
 type Response struct {
 	Data       []Instrument // 证券列表
@@ -0,0 +1,206 @@
+package kline
+
+import (
+	"sort"
+	"time"
+
+	"github.com/souloss/quantds/domain/corpaction"
+)
+
+// AdjustMethod describes how the prices of a response were adjusted.
+type AdjustMethod string
+
+const (
+	AdjustMethodNone       AdjustMethod = ""           // 数据源原样返回, 复权方式未知
+	AdjustMethodFactor     AdjustMethod = "factor"     // 按复权因子本地计算
+	AdjustMethodCorpAction AdjustMethod = "corpaction" // 按公司行为本地计算
+)
+
+// Factor is a cumulative adjustment factor effective from Date on. The
+// backward-adjusted price of a bar is its raw price times the factor.
+type Factor struct {
+	Date  time.Time // 生效日期
+	Value float64   // 累计复权因子
+}
+
+// FactorRequest represents an adjustment factor request.
+type FactorRequest struct {
+	Symbol    string    // 标的代码
+	StartTime time.Time // 起始日期 (可选)
+	EndTime   time.Time // 结束日期 (可选, 为空表示最新)
+}
+
+// CacheKey returns the cache key for the request.
+func (r FactorRequest) CacheKey() string {
+	return "adjfactor:" + r.Symbol + ":" + r.StartTime.Format("20060102") + ":" + r.EndTime.Format("20060102")
+}
+
+// RequestSymbols returns the symbols targeted by the request.
+func (r FactorRequest) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+// FactorResponse represents an adjustment factor response.
+type FactorResponse struct {
+	Symbol  string   // 标的代码
+	Factors []Factor // 复权因子, 按日期升序
+	Source  string   // 数据源名称
+}
+
+// AdjustByFactors returns the bars of resp adjusted with the cumulative
+// factors. Each bar uses the latest factor dated on or before it; bars older
+// than all factors use the first one.
+//
+// Forward adjustment anchors prices at the last factor, so factors should
+// reach the present for forward-adjusted prices to match today's quotes.
+// Prices and Change are scaled, Volume is scaled inversely so that Turnover
+// stays consistent, and rates are left unchanged.
+func AdjustByFactors(resp Response, factors []Factor, adjust AdjustType) Response {
+	if adjust == AdjustNone || len(factors) == 0 {
+		return resp
+	}
+	sorted := append([]Factor(nil), factors...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+	latest := sorted[len(sorted)-1].Value
+	if latest <= 0 {
+		return resp
+	}
+
+	bars := make([]Bar, len(resp.Bars))
+	for i, bar := range resp.Bars {
+		scale := factorAt(sorted, bar.Timestamp)
+		if adjust == AdjustForward {
+			scale /= latest
+		}
+		bars[i] = scaleBar(bar, scale)
+	}
+	resp.Bars = bars
+	resp.Adjust = adjust
+	resp.AdjustMethod = AdjustMethodFactor
+	return resp
+}
+
+// AdjustByActions returns the bars of resp adjusted for the corporate
+// actions. The factors are derived from the close before each ex-date, see
+// FactorsFromActions; the response is tagged with AdjustMethodCorpAction.
+func AdjustByActions(resp Response, actions []corpaction.Action, adjust AdjustType) Response {
+	if adjust == AdjustNone {
+		return resp
+	}
+	adjusted := AdjustByFactors(resp, FactorsFromActions(resp.Bars, actions), adjust)
+	if adjusted.AdjustMethod == AdjustMethodFactor {
+		adjusted.AdjustMethod = AdjustMethodCorpAction
+	}
+	return adjusted
+}
+
+// FactorsFromActions derives cumulative factors from corporate actions, using
+// the close of the last bar before each ex-date as the reference price:
+//
+//	ex-price = (close - cash + rights price × rights ratio) / share multiplier
+//
+// Actions on the same ex-date are combined. The series starts at 1 on the
+// first bar, so backward adjustment is relative to the start of the bars
+// rather than the listing date. Actions after the last bar use its close.
+func FactorsFromActions(bars []Bar, actions []corpaction.Action) []Factor {
+	if len(bars) == 0 {
+		return nil
+	}
+	sortedBars := append([]Bar(nil), bars...)
+	sort.Slice(sortedBars, func(i, j int) bool {
+		return sortedBars[i].Timestamp.Before(sortedBars[j].Timestamp)
+	})
+
+	type exEvent struct {
+		date       time.Time
+		cash       float64
+		rightsCash float64
+		added      float64 // shares added per share by stock dividends, transfers and rights
+		split      float64
+	}
+	events := make(map[string]*exEvent)
+	for _, a := range actions {
+		if a.ExDate.IsZero() || !a.ExDate.After(sortedBars[0].Timestamp) {
+			continue
+		}
+		key := a.ExDate.Format("20060102")
+		ev, ok := events[key]
+		if !ok {
+			ev = &exEvent{date: a.ExDate, split: 1}
+			events[key] = ev
+		}
+		switch a.Type {
+		case corpaction.ActionCashDividend:
+			ev.cash += a.Cash
+		case corpaction.ActionStockDividend, corpaction.ActionTransfer:
+			ev.added += a.Ratio
+		case corpaction.ActionRights:
+			ev.added += a.Ratio
+			ev.rightsCash += a.Ratio * a.Price
+		case corpaction.ActionSplit:
+			if a.Ratio > 0 {
+				ev.split *= a.Ratio
+			}
+		}
+	}
+
+	ordered := make([]*exEvent, 0, len(events))
+	for _, ev := range events {
+		ordered = append(ordered, ev)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].date.Before(ordered[j].date)
+	})
+
+	factors := []Factor{{Date: sortedBars[0].Timestamp, Value: 1}}
+	value := 1.0
+	for _, ev := range ordered {
+		prevClose := closeBefore(sortedBars, ev.date)
+		if prevClose <= 0 {
+			continue
+		}
+		exPrice := (prevClose - ev.cash + ev.rightsCash) / ((1 + ev.added) * ev.split)
+		if exPrice <= 0 {
+			continue
+		}
+		value *= prevClose / exPrice
+		factors = append(factors, Factor{Date: ev.date, Value: value})
+	}
+	return factors
+}
+
+// closeBefore returns the close of the last bar before t.
+func closeBefore(bars []Bar, t time.Time) float64 {
+	i := sort.Search(len(bars), func(i int) bool {
+		return !bars[i].Timestamp.Before(t)
+	})
+	if i == 0 {
+		return 0
+	}
+	return bars[i-1].Close
+}
+
+// factorAt returns the latest factor dated on or before t.
+func factorAt(factors []Factor, t time.Time) float64 {
+	i := sort.Search(len(factors), func(i int) bool {
+		return factors[i].Date.After(t)
+	})
+	if i == 0 {
+		return factors[0].Value
+	}
+	return factors[i-1].Value
+}
+
+func scaleBar(bar Bar, scale float64) Bar {
+	bar.Open *= scale
+	bar.High *= scale
+	bar.Low *= scale
+	bar.Close *= scale
+	bar.Change *= scale
+	if scale > 0 {
+		bar.Volume /= scale
+	}
+	return bar
+}
@@ -0,0 +1,139 @@
+package kline
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/souloss/quantds/domain/corpaction"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func rawBars() Response {
+	return Response{
+		Symbol: "000001.SZ",
+		Bars: []Bar{
+			{Timestamp: day(10), Open: 10, High: 11, Low: 9, Close: 10, Volume: 1000},
+			{Timestamp: day(11), Open: 10, High: 10, Low: 10, Close: 10, Volume: 1000},
+			// Ex-date of a 1-for-1 bonus issue: the price halves
+			{Timestamp: day(12), Open: 5, High: 5.5, Low: 4.5, Close: 5, Volume: 2000},
+			{Timestamp: day(13), Open: 5, High: 5, Low: 5, Close: 5.5, Volume: 2000},
+		},
+	}
+}
+
+func TestAdjustByFactors(t *testing.T) {
+	factors := []Factor{
+		{Date: day(12), Value: 2},
+		{Date: day(1), Value: 1},
+	}
+
+	t.Run("forward", func(t *testing.T) {
+		resp := AdjustByFactors(rawBars(), factors, AdjustForward)
+		if resp.Adjust != AdjustForward || resp.AdjustMethod != AdjustMethodFactor {
+			t.Errorf("unexpected tag: %s/%s", resp.Adjust, resp.AdjustMethod)
+		}
+		want := []float64{5, 5, 5, 5.5}
+		for i, bar := range resp.Bars {
+			if !approx(bar.Close, want[i]) {
+				t.Errorf("bar %d close = %v, want %v", i, bar.Close, want[i])
+			}
+		}
+		if !approx(resp.Bars[0].High, 5.5) || !approx(resp.Bars[0].Volume, 2000) {
+			t.Errorf("unexpected first bar: %+v", resp.Bars[0])
+		}
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		resp := AdjustByFactors(rawBars(), factors, AdjustBack)
+		want := []float64{10, 10, 10, 11}
+		for i, bar := range resp.Bars {
+			if !approx(bar.Close, want[i]) {
+				t.Errorf("bar %d close = %v, want %v", i, bar.Close, want[i])
+			}
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		resp := AdjustByFactors(rawBars(), factors, AdjustNone)
+		if resp.Bars[0].Close != 10 || resp.AdjustMethod != AdjustMethodNone {
+			t.Errorf("AdjustNone changed the response: %+v", resp)
+		}
+	})
+
+	t.Run("does not modify input", func(t *testing.T) {
+		raw := rawBars()
+		AdjustByFactors(raw, factors, AdjustForward)
+		if raw.Bars[0].Close != 10 {
+			t.Error("input bars were modified")
+		}
+	})
+}
+
+func TestFactorsFromActions(t *testing.T) {
+	actions := []corpaction.Action{
+		{Type: corpaction.ActionStockDividend, ExDate: day(12), Ratio: 0.5},
+		{Type: corpaction.ActionTransfer, ExDate: day(12), Ratio: 0.5},
+		// Before the first bar: ignored
+		{Type: corpaction.ActionCashDividend, ExDate: day(1), Cash: 1},
+	}
+
+	factors := FactorsFromActions(rawBars().Bars, actions)
+	if len(factors) != 2 {
+		t.Fatalf("expected 2 factors, got %+v", factors)
+	}
+	if !approx(factors[1].Value, 2) || !factors[1].Date.Equal(day(12)) {
+		t.Errorf("unexpected factor: %+v", factors[1])
+	}
+
+	resp := AdjustByActions(rawBars(), actions, AdjustForward)
+	if resp.AdjustMethod != AdjustMethodCorpAction {
+		t.Errorf("AdjustMethod = %s, want %s", resp.AdjustMethod, AdjustMethodCorpAction)
+	}
+	if !approx(resp.Bars[1].Close, 5) {
+		t.Errorf("forward close before ex-date = %v, want 5", resp.Bars[1].Close)
+	}
+}
+
+func TestFactorsFromActions_CashAndStock(t *testing.T) {
+	bars := []Bar{
+		{Timestamp: day(10), Close: 10},
+		{Timestamp: day(11), Close: 9},
+	}
+	actions := []corpaction.Action{
+		{Type: corpaction.ActionCashDividend, ExDate: day(11), Cash: 1},
+		{Type: corpaction.ActionStockDividend, ExDate: day(11), Ratio: 0.5},
+	}
+
+	// ex-price = (10 - 1) / 1.5
+	factors := FactorsFromActions(bars, actions)
+	want := 10 / (9.0 / 1.5)
+	if len(factors) != 2 || !approx(factors[1].Value, want) {
+		t.Errorf("factors = %+v, want second value %v", factors, want)
+	}
+}
+
+func TestFactorsFromActions_Rights(t *testing.T) {
+	bars := []Bar{
+		{Timestamp: day(10), Close: 10},
+		{Timestamp: day(11), Close: 9},
+	}
+	actions := []corpaction.Action{
+		{Type: corpaction.ActionCashDividend, ExDate: day(11), Cash: 1},
+		{Type: corpaction.ActionRights, ExDate: day(11), Ratio: 0.3, Price: 4},
+	}
+
+	// ex-price = (10 - 1 + 0.3*4) / 1.3
+	factors := FactorsFromActions(bars, actions)
+	want := 10 / (10.2 / 1.3)
+	if len(factors) != 2 || !approx(factors[1].Value, want) {
+		t.Errorf("factors = %+v, want second value %v", factors, want)
+	}
+}
@@ -0,0 +1,245 @@
+package kline
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ConsensusRule selects how disagreeing provider values are merged.
+type ConsensusRule string
+
+const (
+	ConsensusMedian   ConsensusRule = "median"   // 取中位数
+	ConsensusMajority ConsensusRule = "majority" // 取多数一致的值
+)
+
+// ConsensusSource is the Source of a response merged from several providers.
+const ConsensusSource = "consensus"
+
+// Field names used in a consensus report.
+const (
+	FieldOpen         = "open"
+	FieldHigh         = "high"
+	FieldLow          = "low"
+	FieldClose        = "close"
+	FieldVolume       = "volume"
+	FieldTurnover     = "turnover"
+	FieldChange       = "change"
+	FieldChangeRate   = "change_rate"
+	FieldTurnoverRate = "turnover_rate"
+)
+
+// barFields lists the compared bar fields. A zero is treated as not reported,
+// except for the change fields where zero is a real value on a flat bar.
+var barFields = []struct {
+	name      string
+	ptr       func(*Bar) *float64
+	zeroValid bool
+}{
+	{FieldOpen, func(b *Bar) *float64 { return &b.Open }, false},
+	{FieldHigh, func(b *Bar) *float64 { return &b.High }, false},
+	{FieldLow, func(b *Bar) *float64 { return &b.Low }, false},
+	{FieldClose, func(b *Bar) *float64 { return &b.Close }, false},
+	{FieldVolume, func(b *Bar) *float64 { return &b.Volume }, false},
+	{FieldTurnover, func(b *Bar) *float64 { return &b.Turnover }, false},
+	{FieldChange, func(b *Bar) *float64 { return &b.Change }, true},
+	{FieldChangeRate, func(b *Bar) *float64 { return &b.ChangeRate }, true},
+	{FieldTurnoverRate, func(b *Bar) *float64 { return &b.TurnoverRate }, false},
+}
+
+// ConsensusReport describes where providers disagreed while building a consensus response.
+type ConsensusReport struct {
+	Rule          ConsensusRule    // 合并规则
+	Tolerance     float64          // 容忍的相对偏差
+	Providers     []string         // 参与比对的数据源
+	Discrepancies []BarDiscrepancy // 存在分歧的K线
+}
+
+// BarDiscrepancy lists the disagreements for a single bar.
+type BarDiscrepancy struct {
+	Timestamp time.Time          // 时间戳
+	Missing   []string           // 缺少该K线的数据源
+	Fields    []FieldDiscrepancy // 存在分歧的字段
+}
+
+// FieldDiscrepancy lists the values reported for one field of a bar.
+type FieldDiscrepancy struct {
+	Field        string          // 字段名
+	Consensus    float64         // 合并后的值
+	MaxDeviation float64         // 最大相对偏差
+	Values       []ProviderValue // 各数据源的值
+}
+
+// ProviderValue is a single provider's value for a field.
+type ProviderValue struct {
+	Provider  string  // 数据源名称
+	Value     float64 // 数据源给出的值
+	Deviation float64 // 相对合并值的偏差, |v-c|/|c|
+}
+
+// Consensus aligns the bars of responses by timestamp and merges them with
+// rule. The close prices are merged first, and the bar of the provider whose
+// close is nearest to the merged close is taken as a whole, so that the fields
+// of a merged bar always come from the same source; its High and Low are
+// clamped to cover Open and Close. The majority rule falls back to the median
+// when no close is shared by more than half of the providers. Each response is
+// identified by its Source, and earlier responses win ties. Zero values are
+// treated as not reported by that provider, except for Change and ChangeRate.
+// Fields whose values deviate from the merged bar by more than tolerance, and
+// bars missing from some providers, are listed in the report.
+func Consensus(responses []Response, rule ConsensusRule, tolerance float64) (Response, ConsensusReport) {
+	report := ConsensusReport{Rule: rule, Tolerance: tolerance}
+	merged := Response{Source: ConsensusSource}
+
+	byTime := make(map[int64][]int)
+	bars := make([]map[int64]Bar, len(responses))
+	for i, resp := range responses {
+		report.Providers = append(report.Providers, resp.Source)
+		if merged.Symbol == "" {
+			merged.Symbol = resp.Symbol
+		}
+		bars[i] = make(map[int64]Bar, len(resp.Bars))
+		for _, bar := range resp.Bars {
+			ts := bar.Timestamp.UnixNano()
+			if _, dup := bars[i][ts]; !dup {
+				byTime[ts] = append(byTime[ts], i)
+			}
+			bars[i][ts] = bar
+		}
+	}
+
+	timestamps := make([]int64, 0, len(byTime))
+	for ts := range byTime {
+		timestamps = append(timestamps, ts)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+	for _, ts := range timestamps {
+		present := byTime[ts]
+		bar := clampBar(bars[pickBar(bars, present, ts, rule, tolerance)][ts])
+		d := BarDiscrepancy{Timestamp: bar.Timestamp}
+
+		if len(present) < len(responses) {
+			seen := make(map[int]bool, len(present))
+			for _, i := range present {
+				seen[i] = true
+			}
+			for i, resp := range responses {
+				if !seen[i] {
+					d.Missing = append(d.Missing, resp.Source)
+				}
+			}
+		}
+
+		for _, f := range barFields {
+			c := *f.ptr(&bar)
+			fd := FieldDiscrepancy{Field: f.name, Consensus: c}
+			for _, i := range present {
+				b := bars[i][ts]
+				v := *f.ptr(&b)
+				if v == 0 && !f.zeroValid {
+					continue
+				}
+				dev := relativeDeviation(v, c)
+				fd.Values = append(fd.Values, ProviderValue{Provider: responses[i].Source, Value: v, Deviation: dev})
+				fd.MaxDeviation = math.Max(fd.MaxDeviation, dev)
+			}
+			if fd.MaxDeviation > tolerance {
+				d.Fields = append(d.Fields, fd)
+			}
+		}
+
+		merged.Bars = append(merged.Bars, bar)
+		if len(d.Missing) > 0 || len(d.Fields) > 0 {
+			report.Discrepancies = append(report.Discrepancies, d)
+		}
+	}
+
+	return merged, report
+}
+
+// pickBar returns the provider among present whose close at ts is nearest to
+// the close merged with rule; earlier providers win ties.
+func pickBar(bars []map[int64]Bar, present []int, ts int64, rule ConsensusRule, tolerance float64) int {
+	var values []ProviderValue
+	var providers []int
+	for _, i := range present {
+		if v := bars[i][ts].Close; v != 0 {
+			values = append(values, ProviderValue{Value: v})
+			providers = append(providers, i)
+		}
+	}
+	if len(values) == 0 {
+		return present[0]
+	}
+
+	c := consensusValue(values, rule, tolerance)
+	best, bestDiff := providers[0], math.Inf(1)
+	for j, v := range values {
+		if diff := math.Abs(v.Value - c); diff < bestDiff {
+			best, bestDiff = providers[j], diff
+		}
+	}
+	return best
+}
+
+// clampBar widens High and Low to cover Open and Close where they are reported.
+func clampBar(bar Bar) Bar {
+	for _, v := range []float64{bar.Open, bar.Close} {
+		if v == 0 {
+			continue
+		}
+		if bar.High != 0 && bar.High < v {
+			bar.High = v
+		}
+		if bar.Low != 0 && bar.Low > v {
+			bar.Low = v
+		}
+	}
+	return bar
+}
+
+// consensusValue merges the values of one field according to rule.
+func consensusValue(values []ProviderValue, rule ConsensusRule, tolerance float64) float64 {
+	if rule == ConsensusMajority {
+		// Group values agreeing within tolerance and take the largest group;
+		// an earlier provider wins ties.
+		best, bestVotes := values[0].Value, 0
+		for _, candidate := range values {
+			votes := 0
+			for _, v := range values {
+				if relativeDeviation(v.Value, candidate.Value) <= tolerance {
+					votes++
+				}
+			}
+			if votes > bestVotes {
+				best, bestVotes = candidate.Value, votes
+			}
+		}
+		if bestVotes*2 > len(values) {
+			return best
+		}
+	}
+
+	sorted := make([]float64, len(values))
+	for i, v := range values {
+		sorted[i] = v.Value
+	}
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func relativeDeviation(v, c float64) float64 {
+	if c == 0 {
+		if v == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return math.Abs(v-c) / math.Abs(c)
+}
@@ -0,0 +1,108 @@
+package kline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsensus(t *testing.T) {
+	d1 := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	d2 := d1.AddDate(0, 0, 1)
+	responses := []Response{
+		{Symbol: "000001.SZ", Source: "eastmoney", Bars: []Bar{
+			{Timestamp: d1, Open: 10, Close: 10.5, Volume: 100},
+			{Timestamp: d2, Open: 10.5, Close: 11},
+		}},
+		{Symbol: "000001.SZ", Source: "sina", Bars: []Bar{
+			{Timestamp: d1, Open: 10, Close: 10.5, Volume: 100},
+			{Timestamp: d2, Open: 10.5, Close: 11},
+		}},
+		{Symbol: "000001.SZ", Source: "tencent", Bars: []Bar{
+			{Timestamp: d1, Open: 10, Close: 9.5},
+		}},
+	}
+
+	tests := []struct {
+		rule      ConsensusRule
+		wantClose float64
+	}{
+		{ConsensusMajority, 10.5},
+		{ConsensusMedian, 10.5},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			merged, report := Consensus(responses, tt.rule, 0.001)
+			if merged.Source != ConsensusSource || merged.Symbol != "000001.SZ" {
+				t.Errorf("merged = %s/%s", merged.Symbol, merged.Source)
+			}
+			if len(merged.Bars) != 2 {
+				t.Fatalf("len(Bars) = %d, want 2", len(merged.Bars))
+			}
+			if merged.Bars[0].Close != tt.wantClose || merged.Bars[0].Volume != 100 {
+				t.Errorf("Bars[0] = %+v", merged.Bars[0])
+			}
+			if len(report.Providers) != 3 {
+				t.Errorf("Providers = %v", report.Providers)
+			}
+			if len(report.Discrepancies) != 2 {
+				t.Fatalf("len(Discrepancies) = %d, want 2", len(report.Discrepancies))
+			}
+
+			first := report.Discrepancies[0]
+			if len(first.Missing) != 0 || len(first.Fields) != 1 || first.Fields[0].Field != FieldClose {
+				t.Fatalf("Discrepancies[0] = %+v", first)
+			}
+			for _, v := range first.Fields[0].Values {
+				if v.Provider == "tencent" && (v.Deviation < 0.095 || v.Deviation > 0.096) {
+					t.Errorf("tencent deviation = %v, want ~0.0952", v.Deviation)
+				}
+			}
+
+			second := report.Discrepancies[1]
+			if len(second.Missing) != 1 || second.Missing[0] != "tencent" || len(second.Fields) != 0 {
+				t.Errorf("Discrepancies[1] = %+v", second)
+			}
+		})
+	}
+}
+
+func TestConsensus_MajorityFallback(t *testing.T) {
+	ts := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	responses := []Response{
+		{Source: "a", Bars: []Bar{{Timestamp: ts, Close: 10}}},
+		{Source: "b", Bars: []Bar{{Timestamp: ts, Close: 12}}},
+	}
+	merged, _ := Consensus(responses, ConsensusMajority, 0.001)
+	if merged.Bars[0].Close != 10 {
+		t.Errorf("Close = %v, want 10 from the earlier provider nearest to the median", merged.Bars[0].Close)
+	}
+}
+
+func TestConsensus_WholeBar(t *testing.T) {
+	ts := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	responses := []Response{
+		{Source: "a", Bars: []Bar{{Timestamp: ts, Open: 10, High: 10.8, Low: 9.9, Close: 10.6, Volume: 100, Change: 0.6}}},
+		{Source: "b", Bars: []Bar{{Timestamp: ts, Open: 10, High: 10.4, Low: 10.1, Close: 10.5, Volume: 300, Change: 0.5}}},
+		{Source: "c", Bars: []Bar{{Timestamp: ts, Open: 10, High: 10.5, Low: 9.8, Close: 10.5, Volume: 200}}},
+	}
+
+	merged, report := Consensus(responses, ConsensusMedian, 0.001)
+	bar := merged.Bars[0]
+	// b is the first provider with the median close; its High and Low are
+	// widened to cover Open and Close.
+	if bar.Volume != 300 || bar.Change != 0.5 || bar.High != 10.5 || bar.Low != 10 {
+		t.Errorf("bar = %+v, want b's bar clamped to High 10.5 and Low 10", bar)
+	}
+
+	for _, f := range report.Discrepancies[0].Fields {
+		if f.Field != FieldChange {
+			continue
+		}
+		for _, v := range f.Values {
+			if v.Provider == "c" && v.Value == 0 {
+				return
+			}
+		}
+	}
+	t.Error("a zero Change should be compared rather than treated as missing")
+}
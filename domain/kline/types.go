@@ -25,6 +25,29 @@ const (
 	Timeframe1M  Timeframe = "1M"  // 月线
 )
 
+// Duration returns the nominal length of one bar of the timeframe.
+// Unknown or empty timeframes are treated as daily bars.
+func (tf Timeframe) Duration() time.Duration {
+	switch tf {
+	case Timeframe1m:
+		return time.Minute
+	case Timeframe5m:
+		return 5 * time.Minute
+	case Timeframe15m:
+		return 15 * time.Minute
+	case Timeframe30m:
+		return 30 * time.Minute
+	case Timeframe60m:
+		return time.Hour
+	case Timeframe1w:
+		return 7 * 24 * time.Hour
+	case Timeframe1M:
+		return 30 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
 // AdjustType represents the price adjustment type.
 type AdjustType string
 
@@ -58,11 +81,28 @@ func (r Request) CacheKey() string {
 		r.StartTime.Format("20060102") + ":" + r.EndTime.Format("20060102")
 }
 
+// RequestSymbols returns the symbols targeted by the request.
+func (r Request) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+// RequestEnd returns the end of the requested range.
+func (r Request) RequestEnd() time.Time {
+	return r.EndTime
+}
+
+// RequestIntraday reports whether the request is for bars shorter than a day.
+func (r Request) RequestIntraday() bool {
+	return r.Timeframe.Duration() < 24*time.Hour
+}
+
 // Response represents a K-line data response.
 type Response struct {
-	Symbol string // 标的代码
-	Bars   []Bar  // K线数据
-	Source string // 数据源名称
+	Symbol       string       // 标的代码
+	Bars         []Bar        // K线数据
+	Source       string       // 数据源名称
+	Adjust       AdjustType   // 本地复权类型, 仅本地复权时设置
+	AdjustMethod AdjustMethod // 本地复权方式
 }
 
 // Bar represents a single K-line (OHLCV) data point.
@@ -0,0 +1,111 @@
+// Package moneyflow provides capital flow domain types.
+//
+// This package defines the request/response types for money flow data, the
+// net inflow of orders bucketed by size, both intraday and as daily history.
+package moneyflow
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// Period represents the granularity of money flow data.
+type Period string
+
+const (
+	PeriodIntraday Period = ""   // 当日实时
+	PeriodDaily    Period = "1d" // 日线历史
+)
+
+// Request represents a money flow data request.
+type Request struct {
+	Symbol    string    // 标的代码 (e.g., "000001.SZ", "600519.SH")
+	Period    Period    // 数据周期
+	StartTime time.Time // 起始日期 (仅日线)
+	EndTime   time.Time // 结束日期 (仅日线)
+	Limit     int       // 最多返回的日线条数 (仅日线, 0 表示数据源默认)
+}
+
+// CacheKey returns the cache key for the request.
+func (r Request) CacheKey() string {
+	return "moneyflow:" + r.Symbol + ":" + string(r.Period) + ":" +
+		r.StartTime.Format("20060102") + ":" + r.EndTime.Format("20060102") + ":" + strconv.Itoa(r.Limit)
+}
+
+// RequestSymbols returns the symbols targeted by the request.
+func (r Request) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+// Days returns how many trailing days of daily flows cover the request: the
+// calendar days since StartTime, otherwise Limit, otherwise def.
+func (r Request) Days(def int) int {
+	if !r.StartTime.IsZero() {
+		return int(time.Since(r.StartTime).Hours()/24) + 1
+	}
+	if r.Limit > 0 {
+		return r.Limit
+	}
+	return def
+}
+
+// Filter keeps the daily flows within the request's date range and limit,
+// keeping the most recent ones when the limit applies.
+func (r Request) Filter(flows []Flow) []Flow {
+	out := make([]Flow, 0, len(flows))
+	for _, f := range flows {
+		if !r.StartTime.IsZero() && f.Date.Before(r.StartTime) {
+			continue
+		}
+		if !r.EndTime.IsZero() && f.Date.After(r.EndTime) {
+			continue
+		}
+		out = append(out, f)
+	}
+	if r.Limit > 0 && len(out) > r.Limit {
+		out = out[len(out)-r.Limit:]
+	}
+	return out
+}
+
+// Response represents a money flow data response.
+type Response struct {
+	Symbol string // 标的代码
+	Period Period // 数据周期
+	Flows  []Flow // 资金流向数据, 按日期升序
+	Source string // 数据源名称
+}
+
+// Flow represents the money flow of one trading day, or of the current
+// session for intraday data. Amounts are in CNY and ratios are percentages
+// of the turnover.
+type Flow struct {
+	Date                time.Time // 交易日期
+	MainNetInflow       float64   // 主力净流入 (超大单+大单)
+	SuperLargeNetInflow float64   // 超大单净流入
+	LargeNetInflow      float64   // 大单净流入
+	MediumNetInflow     float64   // 中单净流入
+	SmallNetInflow      float64   // 小单净流入
+	MainNetRatio        float64   // 主力净流入占比 (%)
+	SuperLargeNetRatio  float64   // 超大单净流入占比 (%)
+	LargeNetRatio       float64   // 大单净流入占比 (%)
+	MediumNetRatio      float64   // 中单净流入占比 (%)
+	SmallNetRatio       float64   // 小单净流入占比 (%)
+	Close               float64   // 收盘价
+	ChangeRate          float64   // 涨跌幅 (%)
+}
+
+// Source defines the interface for money flow data providers.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context, req Request) (Response, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// ParseSymbol parses a symbol string into code and exchange.
+func ParseSymbol(symbol string) (code string, exchange domain.Exchange, ok bool) {
+	return domain.ParseSymbol(symbol)
+}
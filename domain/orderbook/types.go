@@ -0,0 +1,186 @@
+// Package orderbook provides order book domain types.
+//
+// This package defines the request/response types for order book snapshots
+// (盘口): N price levels per side with a timestamp, and metrics derived from
+// them such as spread, mid price, imbalance and cumulative depth.
+package orderbook
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Request represents an order book request.
+type Request struct {
+	Symbol string // 标的代码
+	Depth  int    // 每侧档位数, 0 表示数据源默认
+}
+
+// CacheKey returns the cache key for the request.
+func (r Request) CacheKey() string {
+	return "orderbook:" + r.Symbol + ":" + strconv.Itoa(r.Depth)
+}
+
+// RequestSymbols returns the symbols targeted by the request.
+func (r Request) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+// Response represents an order book response.
+type Response struct {
+	Book   Book   // 盘口快照
+	Source string // 数据源名称
+}
+
+// Level represents one price level of the book.
+type Level struct {
+	Price  float64 // 价格
+	Volume float64 // 挂单量 (股/币)
+}
+
+// Book represents an order book snapshot.
+type Book struct {
+	Symbol    string    // 标的代码
+	Bids      []Level   // 买盘, 价格从高到低
+	Asks      []Level   // 卖盘, 价格从低到高
+	Timestamp time.Time // 快照时间
+}
+
+// NewBook creates a Book, dropping empty levels and sorting each side from
+// the best price outwards. depth limits the levels per side when positive.
+func NewBook(symbol string, bids, asks []Level, ts time.Time, depth int) Book {
+	b := Book{
+		Symbol:    symbol,
+		Bids:      validLevels(bids),
+		Asks:      validLevels(asks),
+		Timestamp: ts,
+	}
+	sort.SliceStable(b.Bids, func(i, j int) bool { return b.Bids[i].Price > b.Bids[j].Price })
+	sort.SliceStable(b.Asks, func(i, j int) bool { return b.Asks[i].Price < b.Asks[j].Price })
+	if depth > 0 {
+		b.Bids = b.Bids[:min(depth, len(b.Bids))]
+		b.Asks = b.Asks[:min(depth, len(b.Asks))]
+	}
+	return b
+}
+
+func validLevels(levels []Level) []Level {
+	out := make([]Level, 0, len(levels))
+	for _, l := range levels {
+		if l.Price > 0 && l.Volume > 0 {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// BestBid returns the highest bid, or false when there are no bids.
+func (b Book) BestBid() (Level, bool) {
+	if len(b.Bids) == 0 {
+		return Level{}, false
+	}
+	return b.Bids[0], true
+}
+
+// BestAsk returns the lowest ask, or false when there are no asks.
+func (b Book) BestAsk() (Level, bool) {
+	if len(b.Asks) == 0 {
+		return Level{}, false
+	}
+	return b.Asks[0], true
+}
+
+// Spread returns the best ask minus the best bid, or 0 when a side is empty.
+func (b Book) Spread() float64 {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return 0
+	}
+	return ask.Price - bid.Price
+}
+
+// Mid returns the mid price between the best bid and ask, or 0 when a side
+// is empty.
+func (b Book) Mid() float64 {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return 0
+	}
+	return (bid.Price + ask.Price) / 2
+}
+
+// SpreadBps returns the spread in basis points of the mid price.
+func (b Book) SpreadBps() float64 {
+	mid := b.Mid()
+	if mid == 0 {
+		return 0
+	}
+	return b.Spread() / mid * 10000
+}
+
+// Imbalance returns (bid volume - ask volume) / (bid volume + ask volume)
+// over the top levels of each side, in [-1, 1]. Positive values mean more
+// buying interest. levels <= 0 uses the whole book.
+func (b Book) Imbalance(levels int) float64 {
+	bid, ask := volumeOf(b.Bids, levels), volumeOf(b.Asks, levels)
+	if bid+ask == 0 {
+		return 0
+	}
+	return (bid - ask) / (bid + ask)
+}
+
+// BidDepth returns the total bid volume over the top levels, or the whole
+// side when levels <= 0.
+func (b Book) BidDepth(levels int) float64 {
+	return volumeOf(b.Bids, levels)
+}
+
+// AskDepth returns the total ask volume over the top levels, or the whole
+// side when levels <= 0.
+func (b Book) AskDepth(levels int) float64 {
+	return volumeOf(b.Asks, levels)
+}
+
+// CumulativeBids returns the bid levels with Volume replaced by the volume
+// available at that price or better.
+func (b Book) CumulativeBids() []Level {
+	return cumulative(b.Bids)
+}
+
+// CumulativeAsks returns the ask levels with Volume replaced by the volume
+// available at that price or better.
+func (b Book) CumulativeAsks() []Level {
+	return cumulative(b.Asks)
+}
+
+func volumeOf(levels []Level, n int) float64 {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	var total float64
+	for _, l := range levels[:n] {
+		total += l.Volume
+	}
+	return total
+}
+
+func cumulative(levels []Level) []Level {
+	out := make([]Level, len(levels))
+	var total float64
+	for i, l := range levels {
+		total += l.Volume
+		out[i] = Level{Price: l.Price, Volume: total}
+	}
+	return out
+}
+
+// Source defines the interface for order book providers.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context, req Request) (Response, error)
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,53 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewBook(t *testing.T) {
+	b := NewBook("000001.SZ",
+		[]Level{{Price: 10.01, Volume: 100}, {Price: 10.02, Volume: 300}, {Price: 0, Volume: 0}},
+		[]Level{{Price: 10.05, Volume: 200}, {Price: 10.03, Volume: 100}, {Price: 10.04, Volume: 0}},
+		time.Unix(0, 0), 0)
+
+	if len(b.Bids) != 2 || b.Bids[0].Price != 10.02 {
+		t.Errorf("Bids = %+v, want 2 levels best first", b.Bids)
+	}
+	if len(b.Asks) != 2 || b.Asks[0].Price != 10.03 {
+		t.Errorf("Asks = %+v, want 2 levels best first", b.Asks)
+	}
+
+	if got := NewBook("X", b.Bids, b.Asks, time.Time{}, 1); len(got.Bids) != 1 || len(got.Asks) != 1 {
+		t.Errorf("depth 1 book = %+v", got)
+	}
+}
+
+func TestBook_Metrics(t *testing.T) {
+	b := Book{
+		Bids: []Level{{Price: 99, Volume: 3}, {Price: 98, Volume: 5}},
+		Asks: []Level{{Price: 101, Volume: 1}, {Price: 102, Volume: 3}},
+	}
+
+	if b.Spread() != 2 || b.Mid() != 100 || b.SpreadBps() != 200 {
+		t.Errorf("Spread = %v, Mid = %v, SpreadBps = %v", b.Spread(), b.Mid(), b.SpreadBps())
+	}
+	if got := b.Imbalance(1); got != 0.5 {
+		t.Errorf("Imbalance(1) = %v, want 0.5", got)
+	}
+	if got := b.Imbalance(0); math.Abs(got-1.0/3) > 1e-9 {
+		t.Errorf("Imbalance(0) = %v, want 1/3", got)
+	}
+	if b.BidDepth(0) != 8 || b.AskDepth(1) != 1 {
+		t.Errorf("BidDepth = %v, AskDepth(1) = %v", b.BidDepth(0), b.AskDepth(1))
+	}
+	if cum := b.CumulativeAsks(); cum[1] != (Level{Price: 102, Volume: 4}) {
+		t.Errorf("CumulativeAsks = %+v", cum)
+	}
+
+	var empty Book
+	if empty.Spread() != 0 || empty.Mid() != 0 || empty.Imbalance(5) != 0 {
+		t.Error("empty book metrics should be zero")
+	}
+}
@@ -17,6 +17,11 @@ func (r Request) CacheKey() string {
 	return "profile:" + r.Symbol
 }
 
+// RequestSymbols returns the symbols targeted by the request.
+func (r Request) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
 // Response represents a security profile response.
 type Response struct {
 	Data   Profile // 个股档案数据
@@ -0,0 +1,168 @@
+// Package sector provides sector domain types.
+//
+// This package defines the request/response types for industry, concept and
+// region sectors (板块): the sector list with daily performance, the
+// constituents of a sector and the sectors a symbol belongs to. Sector codes
+// are provider specific, e.g. "BK0477" for EastMoney or "TS2" for Tushare.
+package sector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// Kind represents the classification of a sector.
+type Kind string
+
+const (
+	KindIndustry Kind = "industry" // 行业板块
+	KindConcept  Kind = "concept"  // 概念板块
+	KindRegion   Kind = "region"   // 地域板块
+)
+
+// Sector represents a sector with its daily performance.
+type Sector struct {
+	Code          string  // 板块代码
+	Name          string  // 板块名称
+	Kind          Kind    // 板块类型
+	ChangeRate    float64 // 涨跌幅 (%)
+	MainNetInflow float64 // 主力净流入 (元)
+	MarketCap     float64 // 总市值 (元)
+}
+
+// ListRequest represents a sector list request.
+type ListRequest struct {
+	Kind       Kind // 板块类型, 为空表示行业与概念
+	PageSize   int  // 分页大小
+	PageNumber int  // 页码
+}
+
+// CacheKey returns the cache key for the request.
+func (r ListRequest) CacheKey() string {
+	return "sector:list:" + string(r.Kind) + ":" + strconv.Itoa(r.PageSize) + ":" + strconv.Itoa(r.PageNumber)
+}
+
+// RequestMarket returns the market of the request.
+func (r ListRequest) RequestMarket() domain.Market {
+	return domain.MarketCN
+}
+
+// Kinds returns the sector kinds requested.
+func (r ListRequest) Kinds() []Kind {
+	if r.Kind == "" {
+		return []Kind{KindIndustry, KindConcept}
+	}
+	return []Kind{r.Kind}
+}
+
+// Paginate applies the request's pagination to sectors. It returns the page
+// and the total number of sectors.
+func (r ListRequest) Paginate(sectors []Sector) ([]Sector, int) {
+	total := len(sectors)
+	if r.PageSize <= 0 {
+		return sectors, total
+	}
+	start := 0
+	if r.PageNumber > 1 {
+		start = (r.PageNumber - 1) * r.PageSize
+	}
+	if start >= total {
+		return nil, total
+	}
+	return sectors[start:min(start+r.PageSize, total)], total
+}
+
+// ListResponse represents a sector list response.
+type ListResponse struct {
+	Sectors    []Sector // 板块列表
+	Total      int      // 总数
+	Source     string   // 数据源名称
+	PageNumber int      // 页码
+	PageSize   int      // 分页大小
+}
+
+// ConstituentsRequest represents a sector constituents request.
+type ConstituentsRequest struct {
+	Code string // 板块代码
+}
+
+// CacheKey returns the cache key for the request.
+func (r ConstituentsRequest) CacheKey() string {
+	return "sector:constituents:" + r.Code
+}
+
+// RequestSymbols returns the sector code, so the request is only routed to
+// providers that know the code.
+func (r ConstituentsRequest) RequestSymbols() []string {
+	return []string{r.Code}
+}
+
+// RequestMarket returns the market of the request.
+func (r ConstituentsRequest) RequestMarket() domain.Market {
+	return domain.MarketCN
+}
+
+// ConstituentsResponse represents a sector constituents response.
+type ConstituentsResponse struct {
+	Code         string        // 板块代码
+	Constituents []Constituent // 成分股
+	Source       string        // 数据源名称
+}
+
+// Constituent represents a member stock of a sector.
+type Constituent struct {
+	Symbol     string    // 证券代码 (e.g., "600519.SH")
+	Name       string    // 证券名称
+	Price      float64   // 最新价 (无行情的数据源为 0)
+	ChangeRate float64   // 涨跌幅 (%)
+	InDate     time.Time // 纳入日期 (可选)
+}
+
+// MembershipRequest represents a request for the sectors a symbol belongs to.
+type MembershipRequest struct {
+	Symbol string // 证券代码
+	Kind   Kind   // 板块类型, 为空表示全部
+}
+
+// CacheKey returns the cache key for the request.
+func (r MembershipRequest) CacheKey() string {
+	return "sector:membership:" + r.Symbol + ":" + string(r.Kind)
+}
+
+// RequestSymbols returns the requested symbol.
+func (r MembershipRequest) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+// Filter keeps the sectors of the request's kind.
+func (r MembershipRequest) Filter(sectors []Sector) []Sector {
+	if r.Kind == "" {
+		return sectors
+	}
+	out := make([]Sector, 0, len(sectors))
+	for _, s := range sectors {
+		if s.Kind == r.Kind {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// MembershipResponse represents the sectors a symbol belongs to.
+type MembershipResponse struct {
+	Symbol  string   // 证券代码
+	Sectors []Sector // 所属板块
+	Source  string   // 数据源名称
+}
+
+// Source defines the interface for sector data providers.
+type Source interface {
+	Name() string
+	FetchList(ctx context.Context, req ListRequest) (ListResponse, error)
+	FetchConstituents(ctx context.Context, req ConstituentsRequest) (ConstituentsResponse, error)
+	FetchMembership(ctx context.Context, req MembershipRequest) (MembershipResponse, error)
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,38 @@
+package sector
+
+import "testing"
+
+func TestListRequest_Paginate(t *testing.T) {
+	sectors := []Sector{{Code: "BK1"}, {Code: "BK2"}, {Code: "BK3"}}
+
+	page, total := ListRequest{PageSize: 2, PageNumber: 2}.Paginate(sectors)
+	if total != 3 || len(page) != 1 || page[0].Code != "BK3" {
+		t.Errorf("Paginate() = %v, %d", page, total)
+	}
+	if page, _ := (ListRequest{PageSize: 2, PageNumber: 3}).Paginate(sectors); page != nil {
+		t.Errorf("Paginate() past the end = %v, want nil", page)
+	}
+	if page, _ := (ListRequest{}).Paginate(sectors); len(page) != 3 {
+		t.Errorf("Paginate() without page size = %v", page)
+	}
+}
+
+func TestListRequest_Kinds(t *testing.T) {
+	if kinds := (ListRequest{}).Kinds(); len(kinds) != 2 {
+		t.Errorf("Kinds() = %v, want industry and concept", kinds)
+	}
+	if kinds := (ListRequest{Kind: KindRegion}).Kinds(); len(kinds) != 1 || kinds[0] != KindRegion {
+		t.Errorf("Kinds() = %v, want region", kinds)
+	}
+}
+
+func TestMembershipRequest_Filter(t *testing.T) {
+	sectors := []Sector{{Code: "BK1", Kind: KindIndustry}, {Code: "BK2", Kind: KindConcept}}
+
+	if got := (MembershipRequest{Kind: KindConcept}).Filter(sectors); len(got) != 1 || got[0].Code != "BK2" {
+		t.Errorf("Filter() = %v", got)
+	}
+	if got := (MembershipRequest{}).Filter(sectors); len(got) != 2 {
+		t.Errorf("Filter() without kind = %v", got)
+	}
+}
@@ -33,6 +33,11 @@ func (r Request) CacheKey() string {
 	return "spot:" + r.Symbols[0]
 }
 
+// RequestSymbols returns the symbols targeted by the request.
+func (r Request) RequestSymbols() []string {
+	return r.Symbols
+}
+
 // Response represents a real-time quote response.
 type Response struct {
 	Quotes []Quote // 行情列表
@@ -195,6 +195,9 @@ func (s *Symbol) Parse(input string) error {
 		s.Market = Market(parts[1])
 		s.Exchange = Exchange(parts[2])
 		s.AssetType = deriveAssetType(s.Market)
+		if s.Market == MarketCN && IsCNIndex(s.Code, s.Exchange) {
+			s.AssetType = AssetTypeIndex
+		}
 		s.Standard = input
 		return s.Validate()
 	}
@@ -205,6 +208,9 @@ func (s *Symbol) Parse(input string) error {
 		s.Exchange = Exchange(parts[1])
 		s.Market = deriveMarketFromExchange(s.Exchange)
 		s.AssetType = deriveAssetType(s.Market)
+		if s.Market == MarketCN && IsCNIndex(s.Code, s.Exchange) {
+			s.AssetType = AssetTypeIndex
+		}
 		s.Standard = fmt.Sprintf("%s.%s.%s", s.Code, s.Market, s.Exchange)
 		return s.Validate()
 	}
@@ -219,6 +225,9 @@ func (s *Symbol) Parse(input string) error {
 			s.Exchange = Exchange(prefix)
 			s.Market = MarketCN
 			s.AssetType = AssetTypeStock
+			if IsCNIndex(s.Code, s.Exchange) {
+				s.AssetType = AssetTypeIndex
+			}
 			s.Standard = fmt.Sprintf("%s.%s.%s", s.Code, s.Market, s.Exchange)
 			return s.Validate()
 		}
@@ -229,9 +238,31 @@ func (s *Symbol) Parse(input string) error {
 }
 
 // SmartParse 智能识别无后缀代码
+//
+// 6 位数字代码按股票规则推断交易所，只有 399 (深证)、899 (北证) 开头的代码
+// 识别为指数。上证指数与深市股票代码重叠（000300 既是沪深300指数，也落在
+// 深市股票代码段），无后缀时按股票处理，指数需写作 000300.SH。
 func (s *Symbol) SmartParse(code string) error {
 	s.Code = strings.ToUpper(code)
 
+	// A股指数：深证 399、北证 899 开头
+	if isPureDigits(code) && len(code) == 6 {
+		var exchange Exchange
+		switch code[:3] {
+		case "399":
+			exchange = ExchangeSZ
+		case "899":
+			exchange = ExchangeBJ
+		}
+		if exchange != "" {
+			s.Exchange = exchange
+			s.Market = MarketCN
+			s.AssetType = AssetTypeIndex
+			s.Standard = fmt.Sprintf("%s.%s.%s", s.Code, s.Market, s.Exchange)
+			return nil
+		}
+	}
+
 	// A股：6位纯数字
 	if isPureDigits(code) && len(code) == 6 {
 		s.Market = MarketCN
@@ -372,6 +403,24 @@ func inferCNExchange(code string) Exchange {
 	return ExchangeSZ // 默认深交所
 }
 
+// IsCNIndex 判断 A 股代码在指定交易所下是否为指数：上交所 000 开头、
+// 深交所 399 开头、北交所 899 开头。同一代码在不同交易所含义不同，
+// 000300.SH 为沪深300指数，000300.SZ 为深市股票代码。
+func IsCNIndex(code string, exchange Exchange) bool {
+	if len(code) != 6 || !isPureDigits(code) {
+		return false
+	}
+	switch exchange {
+	case ExchangeSH:
+		return strings.HasPrefix(code, "000")
+	case ExchangeSZ:
+		return strings.HasPrefix(code, "399")
+	case ExchangeBJ:
+		return strings.HasPrefix(code, "899")
+	}
+	return false
+}
+
 func isPureDigits(s string) bool {
 	for _, c := range s {
 		if c < '0' || c > '9' {
@@ -432,6 +481,11 @@ func ParseSymbol(input string) (code string, exchange Exchange, ok bool) {
 	return s.Code, s.Exchange, true
 }
 
+// MarketOfExchange 返回交易所所属市场
+func MarketOfExchange(exchange Exchange) Market {
+	return deriveMarketFromExchange(exchange)
+}
+
 func FormatSymbol(code string, exchange Exchange) string {
 	return fmt.Sprintf("%s.%s", code, exchange)
 }
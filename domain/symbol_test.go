@@ -71,3 +71,35 @@ func TestParseAndFormatRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestSymbol_ParseIndex(t *testing.T) {
+	tests := []struct {
+		input     string
+		exchange  Exchange
+		assetType AssetType
+	}{
+		{"000300.SH", ExchangeSH, AssetTypeIndex},
+		{"000300.SZ", ExchangeSZ, AssetTypeStock},
+		{"000001.SH", ExchangeSH, AssetTypeIndex},
+		{"000001.SZ", ExchangeSZ, AssetTypeStock},
+		{"399001.SZ", ExchangeSZ, AssetTypeIndex},
+		{"899050.BJ", ExchangeBJ, AssetTypeIndex},
+		{"SH000905", ExchangeSH, AssetTypeIndex},
+		{"000300.CN.SH", ExchangeSH, AssetTypeIndex},
+		{"399006", ExchangeSZ, AssetTypeIndex},
+		{"600519.SH", ExchangeSH, AssetTypeStock},
+		// 无后缀的 000 开头代码按深市股票处理
+		{"000300", ExchangeSZ, AssetTypeStock},
+	}
+
+	for _, tt := range tests {
+		var sym Symbol
+		if err := sym.Parse(tt.input); err != nil {
+			t.Errorf("Parse(%s) error = %v", tt.input, err)
+			continue
+		}
+		if sym.Market != MarketCN || sym.Exchange != tt.exchange || sym.AssetType != tt.assetType {
+			t.Errorf("Parse(%s) = %s/%s/%s, want CN/%s/%s", tt.input, sym.Market, sym.Exchange, sym.AssetType, tt.exchange, tt.assetType)
+		}
+	}
+}
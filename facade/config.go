@@ -0,0 +1,518 @@
+package facade
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/manager"
+)
+
+// DataType 数据类型，对应 Service 中的一组 Manager。
+type DataType string
+
+const (
+	DataKline              DataType = "kline"
+	DataSpot               DataType = "spot"
+	DataInstrument         DataType = "instrument"
+	DataProfile            DataType = "profile"
+	DataFinancial          DataType = "financial"
+	DataAnnouncement       DataType = "announcement"
+	DataMoneyFlow          DataType = "moneyflow"
+	DataCorpAction         DataType = "corpaction"
+	DataAdjFactor          DataType = "adjfactor"
+	DataCalendar           DataType = "calendar"
+	DataFundList           DataType = "fund_list"
+	DataFundNAV            DataType = "fund_nav"
+	DataFundEstimate       DataType = "fund_estimate"
+	DataSector             DataType = "sector"
+	DataSectorConstituents DataType = "sector_constituents"
+	DataSectorMembership   DataType = "sector_membership"
+	DataIndexList          DataType = "index_list"
+	DataIndexWeight        DataType = "index_weight"
+	DataOrderBook          DataType = "orderbook"
+)
+
+var dataTypes = []DataType{
+	DataKline, DataSpot, DataInstrument, DataProfile, DataFinancial, DataAnnouncement,
+	DataMoneyFlow, DataCorpAction, DataAdjFactor, DataCalendar, DataFundList, DataFundNAV,
+	DataFundEstimate, DataSector, DataSectorConstituents, DataSectorMembership,
+	DataIndexList, DataIndexWeight, DataOrderBook,
+}
+
+// providers 为 Service 内置的全部数据源名称。
+var providers = []string{
+	"alphavantage", "binance", "bse", "cninfo", "coingecko", "eastmoney", "eastmoneyfund",
+	"eastmoneyhk", "eodhd", "finnhub", "okx", "polygon", "sina", "sse", "szse", "tencent",
+	"tushare", "twelvedata", "xueqiu", "yahoo",
+}
+
+// providerCredentials 为需要凭证的数据源声明的凭证名称，与其适配器的
+// Credentials 一致。
+var providerCredentials = map[string][]string{
+	"alphavantage": {CredentialAPIKey},
+	"eodhd":        {CredentialAPIKey},
+	"finnhub":      {CredentialAPIKey},
+	"polygon":      {CredentialAPIKey},
+	"tushare":      {CredentialToken},
+	"twelvedata":   {CredentialAPIKey},
+}
+
+var markets = []domain.Market{
+	domain.MarketCN, domain.MarketUS, domain.MarketHK, domain.MarketCrypto, domain.MarketForex,
+}
+
+// 数据源选择策略。
+const (
+	SelectorPriority = "priority" // 按优先级从高到低尝试 (默认)
+	SelectorWeighted = "weighted" // 按权重随机选择
+)
+
+// 常用凭证名称。
+const (
+	CredentialToken  = manager.CredentialToken
+	CredentialAPIKey = manager.CredentialAPIKey
+)
+
+// EnvPrefix 为覆盖配置的环境变量前缀。
+const EnvPrefix = "QUANTDS_"
+
+// Config 声明式的 Service 配置，可从 YAML、JSON、TOML 文件加载，并由环境变量覆盖。
+// 未配置的部分沿用内置的数据源拓扑、优先级与缓存时长。
+type Config struct {
+	Selector  string                                                `json:"selector,omitempty" yaml:"selector,omitempty" toml:"selector,omitempty"`    // 选择策略: priority 或 weighted
+	Providers map[string]ProviderConfig                             `json:"providers,omitempty" yaml:"providers,omitempty" toml:"providers,omitempty"` // 按数据源名称配置
+	Routes    map[domain.Market]map[DataType]map[string]RouteConfig `json:"routes,omitempty" yaml:"routes,omitempty" toml:"routes,omitempty"`          // 按市场与数据类型覆盖数据源
+	Cache     CacheConfig                                           `json:"cache,omitempty" yaml:"cache,omitempty" toml:"cache,omitempty"`             // 缓存时长
+}
+
+// ProviderConfig 单个数据源的配置，作用于该数据源参与的所有市场与数据类型。
+type ProviderConfig struct {
+	Enabled     *bool             `json:"enabled,omitempty" yaml:"enabled,omitempty" toml:"enabled,omitempty"`             // 是否启用, 默认启用
+	Credentials map[string]string `json:"credentials,omitempty" yaml:"credentials,omitempty" toml:"credentials,omitempty"` // 凭证, 如 token、api_key
+	Timeout     Duration          `json:"timeout,omitempty" yaml:"timeout,omitempty" toml:"timeout,omitempty"`             // 单次请求超时, 0 表示默认
+	RateLimit   *RateLimit        `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty" toml:"rate_limit,omitempty"`    // 请求限流, 为空表示不限流
+}
+
+// RouteConfig 数据源在某一市场与数据类型下的配置。
+type RouteConfig struct {
+	Enabled  *bool `json:"enabled,omitempty" yaml:"enabled,omitempty" toml:"enabled,omitempty"`    // 是否启用, 默认启用
+	Priority int   `json:"priority,omitempty" yaml:"priority,omitempty" toml:"priority,omitempty"` // 优先级, 0 表示内置优先级
+	Weight   int   `json:"weight,omitempty" yaml:"weight,omitempty" toml:"weight,omitempty"`       // weighted 策略下的权重
+}
+
+// CacheConfig 缓存时长配置。
+type CacheConfig struct {
+	TTL          map[DataType]Duration `json:"ttl,omitempty" yaml:"ttl,omitempty" toml:"ttl,omitempty"`                               // 按数据类型覆盖缓存时长 (按交易时段缓存的数据为开盘期间时长)
+	MarketClosed Duration              `json:"market_closed,omitempty" yaml:"market_closed,omitempty" toml:"market_closed,omitempty"` // 休市期间缓存时长
+}
+
+// RateLimit 请求限流配置，文本形式为 "5/1m"，追加 " burst" 表示允许突发。
+type RateLimit struct {
+	Requests int      `json:"requests" yaml:"requests" toml:"requests"`                      // 每个周期允许的请求数
+	Per      Duration `json:"per,omitempty" yaml:"per,omitempty" toml:"per,omitempty"`       // 周期, 默认 1s
+	Burst    bool     `json:"burst,omitempty" yaml:"burst,omitempty" toml:"burst,omitempty"` // 允许在周期内突发, 否则均匀放行
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the forms of
+// ParseRateLimit such as "5/1m".
+func (r *RateLimit) UnmarshalText(text []byte) error {
+	v, err := ParseRateLimit(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// rateLimitFields 用于按字段形式解析 RateLimit，避免递归调用其解析方法。
+type rateLimitFields RateLimit
+
+// UnmarshalJSON implements json.Unmarshaler, accepting both the text form and
+// the object form.
+func (r *RateLimit) UnmarshalJSON(data []byte) error {
+	var text string
+	if json.Unmarshal(data, &text) == nil {
+		return r.UnmarshalText([]byte(text))
+	}
+	return decode(data, "json", (*rateLimitFields)(r))
+}
+
+// UnmarshalTOML implements toml.Unmarshaler, accepting both the text form and
+// the table form.
+func (r *RateLimit) UnmarshalTOML(data any) error {
+	switch v := data.(type) {
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case map[string]any:
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+			return err
+		}
+		return decode(buf.Bytes(), "toml", (*rateLimitFields)(r))
+	default:
+		return fmt.Errorf("invalid rate limit %v: want a string or a table", data)
+	}
+}
+
+// Period 返回限流周期，未设置时为 1 秒。
+func (r RateLimit) Period() time.Duration {
+	if r.Per <= 0 {
+		return time.Second
+	}
+	return time.Duration(r.Per)
+}
+
+// ParseRateLimit 解析 "5/1m"、"100/s" 或 "10/1s burst" 形式的限流配置。
+func ParseRateLimit(s string) (RateLimit, error) {
+	var r RateLimit
+	fields := strings.Fields(s)
+	if len(fields) == 2 && fields[1] == "burst" {
+		r.Burst = true
+	} else if len(fields) != 1 {
+		return r, fmt.Errorf("invalid rate limit %q: want <requests>/<period>", s)
+	}
+	count, period, _ := strings.Cut(fields[0], "/")
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return r, fmt.Errorf("invalid rate limit %q: want <requests>/<period>", s)
+	}
+	r.Requests = n
+	if period != "" {
+		if period[0] < '0' || period[0] > '9' {
+			period = "1" + period
+		}
+		d, err := time.ParseDuration(period)
+		if err != nil {
+			return r, fmt.Errorf("invalid rate limit %q: %w", s, err)
+		}
+		r.Per = Duration(d)
+	}
+	return r, nil
+}
+
+// Duration 支持 "30s"、"5m" 等文本形式的时长。
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// LoadConfig 从文件加载配置，格式由扩展名决定 (.yaml/.yml、.json、.toml)，
+// 然后应用环境变量覆盖并校验。path 为空时仅使用环境变量。
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("facade: read config: %w", err)
+		}
+		if cfg, err = ParseConfig(data, strings.TrimPrefix(filepath.Ext(path), ".")); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.ApplyEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ParseConfig 解析 yaml、json 或 toml 格式的配置。未知字段视为错误。
+func ParseConfig(data []byte, format string) (*Config, error) {
+	cfg := &Config{}
+	if err := decode(data, format, cfg); err != nil {
+		return nil, fmt.Errorf("facade: parse %s config: %w", format, err)
+	}
+	return cfg, nil
+}
+
+// decode 按 format 严格解析 data 到 v，未知字段视为错误。
+func decode(data []byte, format string, v any) error {
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(v); !errors.Is(err, io.EOF) {
+			return err
+		}
+		return nil
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		return dec.Decode(v)
+	case "toml":
+		md, err := toml.Decode(string(data), v)
+		if err != nil {
+			return err
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("unknown field %q", undecoded[0].String())
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// ApplyEnv 应用 KEY=VALUE 形式的环境变量覆盖 (如 os.Environ())，仅处理
+// EnvPrefix 开头的变量，不属于已知数据源的其他变量 (如 QUANTDS_CONFIG) 被忽略：
+//
+//	QUANTDS_SELECTOR=weighted
+//	QUANTDS_CACHE_TTL_<数据类型>=30s, 如 QUANTDS_CACHE_TTL_FUND_NAV
+//	QUANTDS_CACHE_MARKET_CLOSED=2h
+//	QUANTDS_<数据源>_ENABLED=false
+//	QUANTDS_<数据源>_TIMEOUT=10s
+//	QUANTDS_<数据源>_RATE_LIMIT=5/1m
+//	QUANTDS_<数据源>_<凭证>=..., 如 QUANTDS_TUSHARE_TOKEN、QUANTDS_POLYGON_API_KEY
+//
+// 数据源的其他字段 (如拼写错误的 QUANTDS_TUSHARE_TIMEOT) 视为错误。
+func (c *Config) ApplyEnv(environ []string) error {
+	var errs []error
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, EnvPrefix) {
+			continue
+		}
+		if err := c.applyEnv(strings.TrimPrefix(key, EnvPrefix), value); err != nil {
+			errs = append(errs, fmt.Errorf("facade: env %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Config) applyEnv(key, value string) error {
+	switch {
+	case key == "SELECTOR":
+		c.Selector = strings.ToLower(value)
+		return nil
+	case key == "CACHE_MARKET_CLOSED":
+		return c.Cache.MarketClosed.UnmarshalText([]byte(value))
+	case strings.HasPrefix(key, "CACHE_TTL_"):
+		var d Duration
+		if err := d.UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+		if c.Cache.TTL == nil {
+			c.Cache.TTL = make(map[DataType]Duration)
+		}
+		c.Cache.TTL[DataType(strings.ToLower(strings.TrimPrefix(key, "CACHE_TTL_")))] = d
+		return nil
+	}
+
+	name, field, _ := strings.Cut(key, "_")
+	name = strings.ToLower(name)
+	if !isProvider(name) {
+		// 其他程序或工具的变量
+		return nil
+	}
+	if field == "" {
+		return errors.New("want QUANTDS_<PROVIDER>_<FIELD>")
+	}
+	p := c.Providers[name]
+	switch field {
+	case "ENABLED":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		p.Enabled = &enabled
+	case "TIMEOUT":
+		if err := p.Timeout.UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+	case "RATE_LIMIT":
+		r, err := ParseRateLimit(value)
+		if err != nil {
+			return err
+		}
+		p.RateLimit = &r
+	default:
+		cred := strings.ToLower(field)
+		if !isCredential(name, cred) {
+			want := "ENABLED, TIMEOUT or RATE_LIMIT"
+			if creds := providerCredentials[name]; len(creds) > 0 {
+				want = "ENABLED, TIMEOUT, RATE_LIMIT or " + strings.ToUpper(strings.Join(creds, ", "))
+			}
+			return fmt.Errorf("unknown field %q of provider %s, want %s", field, name, want)
+		}
+		if p.Credentials == nil {
+			p.Credentials = make(map[string]string)
+		}
+		p.Credentials[cred] = value
+	}
+	if c.Providers == nil {
+		c.Providers = make(map[string]ProviderConfig)
+	}
+	c.Providers[name] = p
+	return nil
+}
+
+// Validate 校验配置，返回所有问题，每条注明出错的配置路径。
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(path, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("facade: config %s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	switch c.Selector {
+	case "", SelectorPriority, SelectorWeighted:
+	default:
+		fail("selector", "unknown selector %q, want %s or %s", c.Selector, SelectorPriority, SelectorWeighted)
+	}
+
+	for _, name := range sortedKeys(c.Providers) {
+		p := c.Providers[name]
+		path := "providers." + name
+		if !isProvider(name) {
+			fail(path, "unknown provider, want one of %s", strings.Join(providers, ", "))
+			continue
+		}
+		for key := range p.Credentials {
+			if key == "" {
+				fail(path+".credentials", "empty credential name")
+			}
+		}
+		if p.Timeout < 0 {
+			fail(path+".timeout", "must not be negative")
+		}
+		if r := p.RateLimit; r != nil {
+			if r.Requests <= 0 {
+				fail(path+".rate_limit.requests", "must be positive")
+			}
+			if r.Per < 0 {
+				fail(path+".rate_limit.per", "must not be negative")
+			}
+		}
+	}
+
+	for _, market := range sortedKeys(c.Routes) {
+		path := "routes." + string(market)
+		if !isMarket(market) {
+			fail(path, "unknown market")
+			continue
+		}
+		for _, data := range sortedKeys(c.Routes[market]) {
+			path := path + "." + string(data)
+			if !isDataType(data) {
+				fail(path, "unknown data type")
+				continue
+			}
+			for _, name := range sortedKeys(c.Routes[market][data]) {
+				r := c.Routes[market][data][name]
+				path := path + "." + name
+				if !isProvider(name) {
+					fail(path, "unknown provider")
+					continue
+				}
+				if r.Priority < 0 {
+					fail(path+".priority", "must not be negative")
+				}
+				if r.Weight < 0 {
+					fail(path+".weight", "must not be negative")
+				}
+			}
+		}
+	}
+
+	for _, data := range sortedKeys(c.Cache.TTL) {
+		path := "cache.ttl." + string(data)
+		if !isDataType(data) {
+			fail(path, "unknown data type")
+		} else if c.Cache.TTL[data] < 0 {
+			fail(path, "must not be negative")
+		}
+	}
+	if c.Cache.MarketClosed < 0 {
+		fail("cache.market_closed", "must not be negative")
+	}
+	return errors.Join(errs...)
+}
+
+// provider 返回数据源配置，未配置时返回零值。
+func (c *Config) provider(name string) ProviderConfig {
+	if c == nil {
+		return ProviderConfig{}
+	}
+	return c.Providers[name]
+}
+
+// route 返回数据源在 market 与 data 下的配置。
+func (c *Config) route(market domain.Market, data DataType, name string) (RouteConfig, bool) {
+	if c == nil {
+		return RouteConfig{}, false
+	}
+	r, ok := c.Routes[market][data][name]
+	return r, ok
+}
+
+// isCredential 报告 cred 是否为数据源 name 声明的凭证名称。
+func isCredential(name, cred string) bool {
+	for _, c := range providerCredentials[name] {
+		if c == cred {
+			return true
+		}
+	}
+	return false
+}
+
+func isProvider(name string) bool {
+	for _, p := range providers {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isDataType(data DataType) bool {
+	for _, d := range dataTypes {
+		if d == data {
+			return true
+		}
+	}
+	return false
+}
+
+func isMarket(market domain.Market) bool {
+	for _, m := range markets {
+		if m == market {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
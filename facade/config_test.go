@@ -0,0 +1,252 @@
+package facade
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+)
+
+func TestParseConfig_Formats(t *testing.T) {
+	docs := map[string]string{
+		"yaml": `
+selector: weighted
+providers:
+  polygon:
+    credentials: {api_key: secret}
+    timeout: 10s
+    rate_limit: {requests: 5, per: 1m}
+  alphavantage:
+    enabled: false
+routes:
+  US:
+    kline:
+      polygon: {priority: 90, weight: 3}
+cache:
+  ttl: {kline: 1m}
+  market_closed: 2h
+`,
+		"json": `{
+  "selector": "weighted",
+  "providers": {
+    "polygon": {"credentials": {"api_key": "secret"}, "timeout": "10s", "rate_limit": {"requests": 5, "per": "1m"}},
+    "alphavantage": {"enabled": false}
+  },
+  "routes": {"US": {"kline": {"polygon": {"priority": 90, "weight": 3}}}},
+  "cache": {"ttl": {"kline": "1m"}, "market_closed": "2h"}
+}`,
+		"toml": `
+selector = "weighted"
+
+[providers.polygon]
+credentials = { api_key = "secret" }
+timeout = "10s"
+rate_limit = { requests = 5, per = "1m" }
+
+[providers.alphavantage]
+enabled = false
+
+[routes.US.kline.polygon]
+priority = 90
+weight = 3
+
+[cache]
+ttl = { kline = "1m" }
+market_closed = "2h"
+`,
+	}
+
+	var want *Config
+	for _, format := range []string{"yaml", "json", "toml"} {
+		cfg, err := ParseConfig([]byte(docs[format]), format)
+		if err != nil {
+			t.Fatalf("ParseConfig(%s) error = %v", format, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Validate(%s) error = %v", format, err)
+		}
+		if want == nil {
+			want = cfg
+			continue
+		}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("%s config = %+v, want %+v", format, cfg, want)
+		}
+	}
+
+	p := want.Providers["polygon"]
+	if p.Credentials[CredentialAPIKey] != "secret" || p.Timeout != Duration(10*time.Second) || p.RateLimit.Period() != time.Minute {
+		t.Errorf("polygon = %+v", p)
+	}
+	if r, _ := want.route(domain.MarketUS, DataKline, "polygon"); r.Priority != 90 || r.Weight != 3 {
+		t.Errorf("route = %+v", r)
+	}
+}
+
+func TestParseConfig_UnknownField(t *testing.T) {
+	docs := map[string]string{
+		"yaml": "providers:\n  polygon:\n    api_key: x\n",
+		"json": `{"providers": {"polygon": {"api_key": "x"}}}`,
+		"toml": "[providers.polygon]\napi_key = \"x\"\n",
+	}
+	for format, doc := range docs {
+		if _, err := ParseConfig([]byte(doc), format); err == nil {
+			t.Errorf("ParseConfig(%s) accepted an unknown field", format)
+		}
+	}
+	if _, err := ParseConfig(nil, "ini"); err == nil {
+		t.Error("ParseConfig(ini) should fail")
+	}
+}
+
+func TestConfig_ApplyEnv(t *testing.T) {
+	cfg := &Config{Providers: map[string]ProviderConfig{"tushare": {Timeout: Duration(time.Second)}}}
+	err := cfg.ApplyEnv([]string{
+		"HOME=/root",
+		"QUANTDS_TUSHARE_TOKEN=abc",
+		"QUANTDS_POLYGON_API_KEY=key",
+		"QUANTDS_POLYGON_RATE_LIMIT=5/1m burst",
+		"QUANTDS_FINNHUB_ENABLED=false",
+		"QUANTDS_CACHE_TTL_FUND_NAV=30m",
+		"QUANTDS_SELECTOR=weighted",
+	})
+	if err != nil {
+		t.Fatalf("ApplyEnv() error = %v", err)
+	}
+
+	if p := cfg.Providers["tushare"]; p.Credentials[CredentialToken] != "abc" || p.Timeout != Duration(time.Second) {
+		t.Errorf("tushare = %+v", p)
+	}
+	if p := cfg.Providers["polygon"]; p.Credentials[CredentialAPIKey] != "key" || *p.RateLimit != (RateLimit{Requests: 5, Per: Duration(time.Minute), Burst: true}) {
+		t.Errorf("polygon = %+v", p)
+	}
+	if p := cfg.Providers["finnhub"]; p.Enabled == nil || *p.Enabled {
+		t.Errorf("finnhub = %+v", p)
+	}
+	if cfg.Cache.TTL[DataFundNAV] != Duration(30*time.Minute) || cfg.Selector != SelectorWeighted {
+		t.Errorf("cache = %+v, selector = %q", cfg.Cache, cfg.Selector)
+	}
+
+	// Variables of other tools are ignored
+	ignored := &Config{}
+	if err := ignored.ApplyEnv([]string{"QUANTDS_CONFIG=/etc/quantds.yaml", "QUANTDS_LOG_LEVEL=debug", "QUANTDS_NOPE_TOKEN=x"}); err != nil {
+		t.Errorf("ApplyEnv() error = %v, want variables of unknown providers ignored", err)
+	}
+	if len(ignored.Providers) != 0 {
+		t.Errorf("providers = %+v, want none", ignored.Providers)
+	}
+
+	err = (&Config{}).ApplyEnv([]string{"QUANTDS_TUSHARE_TIMEOT=10s", "QUANTDS_YAHOO_TIMEOUT=soon", "QUANTDS_YAHOO_API_KEY=x"})
+	for _, key := range []string{"QUANTDS_TUSHARE_TIMEOT", "QUANTDS_YAHOO_TIMEOUT", "QUANTDS_YAHOO_API_KEY"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("ApplyEnv() error = %v, want %s reported", err, key)
+		}
+	}
+}
+
+func TestRateLimit_Text(t *testing.T) {
+	docs := map[string]string{
+		"yaml": "providers:\n  polygon:\n    rate_limit: 5/1m burst\n",
+		"json": `{"providers": {"polygon": {"rate_limit": "5/1m burst"}}}`,
+		"toml": "[providers.polygon]\nrate_limit = \"5/1m burst\"\n",
+	}
+	want := RateLimit{Requests: 5, Per: Duration(time.Minute), Burst: true}
+	for format, doc := range docs {
+		cfg, err := ParseConfig([]byte(doc), format)
+		if err != nil {
+			t.Fatalf("ParseConfig(%s) error = %v", format, err)
+		}
+		if got := cfg.Providers["polygon"].RateLimit; got == nil || *got != want {
+			t.Errorf("%s rate_limit = %+v, want %+v", format, got, want)
+		}
+	}
+
+	invalid := map[string]string{
+		"yaml": "providers:\n  polygon:\n    rate_limit: often\n",
+		"json": `{"providers": {"polygon": {"rate_limit": {"requests": 5, "every": "1m"}}}}`,
+		"toml": "[providers.polygon]\nrate_limit = { requests = 5, every = \"1m\" }\n",
+	}
+	for format, doc := range invalid {
+		if _, err := ParseConfig([]byte(doc), format); err == nil {
+			t.Errorf("ParseConfig(%s) accepted an invalid rate limit", format)
+		}
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := &Config{
+		Selector: "random",
+		Providers: map[string]ProviderConfig{
+			"polygn": {},
+			"yahoo":  {Timeout: Duration(-time.Second), RateLimit: &RateLimit{}},
+		},
+		Routes: map[domain.Market]map[DataType]map[string]RouteConfig{
+			domain.MarketUS: {"bars": {"yahoo": {}}, DataKline: {"yahoo": {Priority: -1}}},
+		},
+		Cache: CacheConfig{TTL: map[DataType]Duration{"quotes": Duration(time.Second)}},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil")
+	}
+	for _, path := range []string{
+		"selector", "providers.polygn", "providers.yahoo.timeout", "providers.yahoo.rate_limit.requests",
+		"routes.US.bars", "routes.US.kline.yahoo.priority", "cache.ttl.quotes",
+	} {
+		if !strings.Contains(err.Error(), "config "+path+":") {
+			t.Errorf("Validate() error does not report %s:\n%v", path, err)
+		}
+	}
+}
+
+func TestNewServiceFromConfig(t *testing.T) {
+	disabled := false
+	cfg := &Config{
+		Providers: map[string]ProviderConfig{
+			"alphavantage": {Enabled: &disabled},
+			"polygon":      {RateLimit: &RateLimit{Requests: 5, Per: Duration(time.Minute)}},
+		},
+		Routes: map[domain.Market]map[DataType]map[string]RouteConfig{
+			domain.MarketUS: {DataKline: {"eodhd": {Enabled: &disabled}, "polygon": {Priority: PriorityHighest + 1}}},
+		},
+	}
+	keys := StaticCredentials{}
+	for _, name := range []string{"alphavantage", "eodhd", "finnhub", "polygon", "twelvedata"} {
+		keys[name] = map[string]string{CredentialAPIKey: "key"}
+	}
+	svc, err := NewServiceFromConfig(cfg, WithCredentialSource(keys))
+	if err != nil {
+		t.Fatalf("NewServiceFromConfig() error = %v", err)
+	}
+	defer svc.Close()
+
+	got := svc.klineManagers[domain.MarketUS].Providers()
+	sort.Strings(got)
+	if want := []string{"finnhub", "polygon", "twelvedata", "yahoo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("US kline providers = %v, want %v", got, want)
+	}
+	if svc.client("polygon") == svc.client("yahoo") {
+		t.Error("providers should not share a client")
+	}
+	if cfg := svc.requestConfig("yahoo"); cfg.RateLimiter == nil {
+		t.Error("yahoo should use the conservative default rate limit")
+	}
+	if cfg := svc.requestConfig("tushare"); cfg.RateLimiter == nil {
+		t.Error("tushare should default to its per-minute limit")
+	}
+	if cfg := svc.requestConfig("polygon"); cfg.RateLimiter == nil || cfg.CircuitBreaker == nil {
+		t.Error("polygon should keep its breaker and use the configured rate limit")
+	}
+	if cfg := svc.requestConfig("binance"); cfg.RateLimiter == nil || cfg.Weight == nil {
+		t.Error("binance should default to its request weight limit")
+	}
+
+	cfg.Routes[domain.MarketUS][DataKline]["tushare"] = RouteConfig{}
+	if _, err := NewServiceFromConfig(cfg); err == nil || !strings.Contains(err.Error(), "routes.US.kline.tushare") {
+		t.Errorf("NewServiceFromConfig() error = %v, want unserved route reported", err)
+	}
+}
@@ -0,0 +1,91 @@
+package facade
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/souloss/quantds/manager"
+)
+
+// CredentialSource 提供数据源凭证，可替换为密钥管理服务等自定义实现。
+type CredentialSource interface {
+	// Credential 返回数据源 provider 的凭证 cred，不存在时 ok 为 false。
+	Credential(provider string, cred manager.Credential) (value string, ok bool)
+}
+
+// CredentialSourceFunc 将函数适配为 CredentialSource。
+type CredentialSourceFunc func(provider string, cred manager.Credential) (string, bool)
+
+// Credential 实现 CredentialSource。
+func (f CredentialSourceFunc) Credential(provider string, cred manager.Credential) (string, bool) {
+	return f(provider, cred)
+}
+
+// EnvCredentials 从环境变量读取凭证：优先 QUANTDS_<数据源>_<凭证>
+// (如 QUANTDS_POLYGON_API_KEY)，其次为数据源约定的变量 (如 POLYGON_API_KEY)。
+// 未设置 WithCredentialSource 时默认使用。
+func EnvCredentials() CredentialSource {
+	return envCredentials(os.LookupEnv)
+}
+
+func envCredentials(lookup func(string) (string, bool)) CredentialSource {
+	return CredentialSourceFunc(func(provider string, cred manager.Credential) (string, bool) {
+		if v, ok := lookup(EnvPrefix + strings.ToUpper(provider+"_"+cred.Key)); ok && v != "" {
+			return v, true
+		}
+		if cred.Env != "" {
+			if v, ok := lookup(cred.Env); ok && v != "" {
+				return v, true
+			}
+		}
+		return "", false
+	})
+}
+
+// StaticCredentials 以 数据源 -> 凭证名称 -> 值 的形式提供凭证。
+type StaticCredentials map[string]map[string]string
+
+// Credential 实现 CredentialSource。
+func (c StaticCredentials) Credential(provider string, cred manager.Credential) (string, bool) {
+	v, ok := c[provider][cred.Key]
+	return v, ok && v != ""
+}
+
+// FileCredentials 从 YAML、JSON 或 TOML 文件读取凭证，格式由扩展名决定，
+// 内容为 数据源 -> 凭证名称 -> 值，例如:
+//
+//	polygon:
+//	  api_key: xxx
+//	tushare:
+//	  token: xxx
+func FileCredentials(path string) (StaticCredentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("facade: read credentials: %w", err)
+	}
+	creds := StaticCredentials{}
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	if err := decode(data, format, &creds); err != nil {
+		return nil, fmt.Errorf("facade: parse %s credentials: %w", format, err)
+	}
+	for name := range creds {
+		if !isProvider(name) {
+			return nil, fmt.Errorf("facade: credentials %s: unknown provider", name)
+		}
+	}
+	return creds, nil
+}
+
+// ChainCredentials 依次查询 sources，返回第一个找到的凭证。
+func ChainCredentials(sources ...CredentialSource) CredentialSource {
+	return CredentialSourceFunc(func(provider string, cred manager.Credential) (string, bool) {
+		for _, src := range sources {
+			if v, ok := src.Credential(provider, cred); ok {
+				return v, true
+			}
+		}
+		return "", false
+	})
+}
@@ -0,0 +1,146 @@
+package facade
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/manager"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	env := map[string]string{
+		"QUANTDS_POLYGON_API_KEY": "prefixed",
+		"POLYGON_API_KEY":         "legacy",
+		"FINNHUB_API_KEY":         "legacy",
+		"QUANTDS_EODHD_API_KEY":   "",
+	}
+	src := envCredentials(func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	})
+
+	tests := []struct {
+		provider, env string
+		want          string
+		wantOK        bool
+	}{
+		{"polygon", "POLYGON_API_KEY", "prefixed", true},
+		{"finnhub", "FINNHUB_API_KEY", "legacy", true},
+		{"eodhd", "EODHD_API_KEY", "", false},
+		{"twelvedata", "", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := src.Credential(tt.provider, manager.Credential{Key: CredentialAPIKey, Env: tt.env})
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Credential(%s) = %q, %v, want %q, %v", tt.provider, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.yaml")
+	if err := os.WriteFile(path, []byte("polygon:\n  api_key: from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := FileCredentials(path)
+	if err != nil {
+		t.Fatalf("FileCredentials() error = %v", err)
+	}
+
+	src := ChainCredentials(file, StaticCredentials{"polygon": {CredentialAPIKey: "static"}, "tushare": {CredentialToken: "t"}})
+	if v, _ := src.Credential("polygon", manager.Credential{Key: CredentialAPIKey}); v != "from-file" {
+		t.Errorf("polygon api_key = %q, want from-file", v)
+	}
+	if v, _ := src.Credential("tushare", manager.Credential{Key: CredentialToken}); v != "t" {
+		t.Errorf("tushare token = %q, want t", v)
+	}
+
+	bad := filepath.Join(dir, "secrets.json")
+	if err := os.WriteFile(bad, []byte(`{"polygn": {"api_key": "x"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FileCredentials(bad); err == nil || !strings.Contains(err.Error(), "polygn") {
+		t.Errorf("FileCredentials() error = %v, want unknown provider reported", err)
+	}
+}
+
+func TestService_Providers(t *testing.T) {
+	svc := NewService(
+		WithCredentialSource(StaticCredentials{"polygon": {CredentialAPIKey: "key"}}),
+		WithConfig(&Config{Providers: map[string]ProviderConfig{"eodhd": {Credentials: map[string]string{CredentialAPIKey: "key"}}}}),
+	)
+	defer svc.Close()
+
+	got := svc.klineManagers[domain.MarketUS].Providers()
+	sort.Strings(got)
+	if want := []string{"eodhd", "polygon", "yahoo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("US kline providers = %v, want %v", got, want)
+	}
+
+	status := make(map[string]ProviderStatus)
+	for _, st := range svc.Providers() {
+		status[st.Name] = st
+	}
+	if st := status["polygon"]; !st.Active || len(st.Routes) == 0 {
+		t.Errorf("polygon = %+v, want active", st)
+	}
+	for _, name := range []string{"finnhub", "alphavantage", "twelvedata"} {
+		st := status[name]
+		if st.Active || !strings.Contains(st.Reason, "missing credential api_key") || !strings.Contains(st.Reason, strings.ToUpper(name)+"_API_KEY") {
+			t.Errorf("%s = %+v, want inactive for missing api_key", name, st)
+		}
+	}
+	if st := status["eastmoney"]; !st.Active {
+		t.Errorf("eastmoney = %+v, want active", st)
+	}
+	if st := status["tushare"]; st.Active || !strings.Contains(st.Reason, "missing credential token") || !strings.Contains(st.Reason, "TUSHARE_TOKEN") {
+		t.Errorf("tushare = %+v, want inactive for missing token", st)
+	}
+	for _, name := range svc.klineManagers[domain.MarketCN].Providers() {
+		if name == "tushare" {
+			t.Error("tushare registered for CN kline without a token")
+		}
+	}
+	if summary := svc.Providers().String(); !strings.Contains(summary, "inactive finnhub") {
+		t.Errorf("summary = %q", summary)
+	}
+}
+
+func TestService_Providers_TushareToken(t *testing.T) {
+	svc := NewService(WithCredentialSource(StaticCredentials{"tushare": {CredentialToken: "token"}}))
+	defer svc.Close()
+
+	for _, st := range svc.Providers() {
+		if st.Name == "tushare" && (!st.Active || len(st.Routes) == 0) {
+			t.Errorf("tushare = %+v, want active with a token", st)
+		}
+	}
+	if got := svc.klineManagers[domain.MarketCN].Providers(); !slices.Contains(got, "tushare") {
+		t.Errorf("CN kline providers = %v, want tushare", got)
+	}
+}
+
+func TestProviderCredentials(t *testing.T) {
+	svc := NewService(WithCredentialSource(StaticCredentials{}))
+	defer svc.Close()
+
+	// providerCredentials must list exactly the credentials the adapters require
+	for _, st := range svc.Providers() {
+		creds := providerCredentials[st.Name]
+		if missing := strings.Contains(st.Reason, "missing credential"); missing != (len(creds) > 0) {
+			t.Errorf("%s: reason %q, declared credentials %v", st.Name, st.Reason, creds)
+		}
+		for _, cred := range creds {
+			if !strings.Contains(st.Reason, cred+" (set ") {
+				t.Errorf("%s: reason %q does not name credential %s", st.Name, st.Reason, cred)
+			}
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/timeout"
+
+	alphavantageadapter "github.com/souloss/quantds/adapters/alphavantage"
+	binanceadapter "github.com/souloss/quantds/adapters/binance"
+	coingeckoadapter "github.com/souloss/quantds/adapters/coingecko"
+	eodhadadapter "github.com/souloss/quantds/adapters/eodhd"
+	finnhubadapter "github.com/souloss/quantds/adapters/finnhub"
+	okxadapter "github.com/souloss/quantds/adapters/okx"
+	polygonadapter "github.com/souloss/quantds/adapters/polygon"
+	tushareadapter "github.com/souloss/quantds/adapters/tushare"
+	twelvedataadapter "github.com/souloss/quantds/adapters/twelvedata"
+	alphavantageclient "github.com/souloss/quantds/clients/alphavantage"
+	binanceclient "github.com/souloss/quantds/clients/binance"
+	coingeckoclient "github.com/souloss/quantds/clients/coingecko"
+	eodhdclient "github.com/souloss/quantds/clients/eodhd"
+	finnhubclient "github.com/souloss/quantds/clients/finnhub"
+	okxclient "github.com/souloss/quantds/clients/okx"
+	polygonclient "github.com/souloss/quantds/clients/polygon"
+	tushareclient "github.com/souloss/quantds/clients/tushare"
+	twelvedataclient "github.com/souloss/quantds/clients/twelvedata"
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// route 标识某一市场与数据类型下的一个数据源。
+type route struct {
+	market   domain.Market
+	data     DataType
+	provider string
+}
+
+// ProviderStatus 数据源在 Service 中的注册情况。
+type ProviderStatus struct {
+	Name   string   // 数据源名称
+	Active bool     // 是否至少注册了一个路由
+	Reason string   // 未注册的原因, 仅 Active 为 false 时有值
+	Routes []string // 已注册的路由, 形如 US/kline
+}
+
+// ProviderSummary 为按名称排序的数据源注册情况。
+type ProviderSummary []ProviderStatus
+
+// String 返回多行摘要，每行一个数据源。
+func (ps ProviderSummary) String() string {
+	var b strings.Builder
+	for _, p := range ps {
+		if p.Active {
+			fmt.Fprintf(&b, "active   %-13s %s\n", p.Name, strings.Join(p.Routes, " "))
+		} else {
+			fmt.Fprintf(&b, "inactive %-13s %s\n", p.Name, p.Reason)
+		}
+	}
+	return b.String()
+}
+
+// withSource 按配置注册数据源：数据源或路由被禁用、或缺少必需凭证时不注册，
+// 否则使用配置的优先级与权重。未配置时使用内置优先级 priority，权重默认等于优先级。
+func withSource[Req, Resp any](s *Service, market domain.Market, data DataType, priority int, p manager.Provider[Req, Resp]) manager.ManagerOption[Req, Resp] {
+	name := p.Name()
+	s.served[route{market, data, name}] = true
+	st := s.providerStatus(name)
+
+	skip := func(*manager.Manager[Req, Resp]) {}
+	if enabled := s.config.provider(name).Enabled; enabled != nil && !*enabled {
+		st.Reason = "disabled by config"
+		return skip
+	}
+	if keyed, ok := any(p).(manager.KeyedProvider); ok {
+		if missing := s.missingCredentials(name, keyed.Credentials()); missing != "" {
+			st.Reason = missing
+			return skip
+		}
+	}
+	weight := 0
+	if r, ok := s.config.route(market, data, name); ok {
+		if r.Enabled != nil && !*r.Enabled {
+			if st.Reason == "" {
+				st.Reason = "all routes disabled by config"
+			}
+			return skip
+		}
+		if r.Priority > 0 {
+			priority = r.Priority
+		}
+		weight = r.Weight
+	}
+	if weight == 0 {
+		weight = priority
+	}
+	st.Active = true
+	st.Routes = append(st.Routes, string(market)+"/"+string(data))
+	return manager.WithProvider(p, manager.WithPriority(priority), manager.WithWeight(weight))
+}
+
+func (s *Service) providerStatus(name string) *ProviderStatus {
+	st, ok := s.status[name]
+	if !ok {
+		st = &ProviderStatus{Name: name}
+		s.status[name] = st
+	}
+	return st
+}
+
+// missingCredentials 描述 creds 中未提供的凭证，全部提供时返回空字符串。
+func (s *Service) missingCredentials(name string, creds []manager.Credential) string {
+	var missing []string
+	for _, cred := range creds {
+		if s.lookup(name, cred) != "" {
+			continue
+		}
+		vars := EnvPrefix + strings.ToUpper(name+"_"+cred.Key)
+		if cred.Env != "" {
+			vars += " or " + cred.Env
+		}
+		missing = append(missing, fmt.Sprintf("%s (set %s)", cred.Key, vars))
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return "missing credential " + strings.Join(missing, ", ")
+}
+
+// Providers 返回各数据源的注册情况，包括因禁用或缺少凭证而未注册的数据源。
+func (s *Service) Providers() ProviderSummary {
+	out := make(ProviderSummary, 0, len(s.status))
+	for _, name := range sortedKeys(s.status) {
+		st := *s.status[name]
+		if st.Active {
+			st.Reason = ""
+		}
+		st.Routes = append([]string(nil), st.Routes...)
+		out = append(out, st)
+	}
+	return out
+}
+
+// checkRoutes 返回配置中指向不提供该数据的数据源的路由。
+func (s *Service) checkRoutes() error {
+	if s.config == nil {
+		return nil
+	}
+	var errs []error
+	for _, market := range sortedKeys(s.config.Routes) {
+		for _, data := range sortedKeys(s.config.Routes[market]) {
+			for _, name := range sortedKeys(s.config.Routes[market][data]) {
+				if !s.served[route{market, data, name}] {
+					errs = append(errs, fmt.Errorf("facade: config routes.%s.%s.%s: %s does not provide %s for market %s",
+						market, data, name, name, data, market))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// selector 返回基于健康度的选择器，基础策略由配置决定。每个 Manager 使用
+// 独立的选择器，健康度只取决于该 Manager 自身的请求结果，与 WithMetrics 无关。
+func (s *Service) selector() manager.Selector {
+	if s.config != nil && s.config.Selector == SelectorWeighted {
+		return manager.NewHealthSelector(nil, manager.WithBaseSelector(manager.NewWeightedSelector()))
+	}
+	return manager.NewHealthSelector(nil)
+}
+
+// cacheTTL 返回 data 的缓存时长，未配置时为 def。
+func (s *Service) cacheTTL(data DataType, def time.Duration) time.Duration {
+	if s.config != nil {
+		if ttl, ok := s.config.Cache.TTL[data]; ok && ttl > 0 {
+			return time.Duration(ttl)
+		}
+	}
+	return def
+}
+
+// closedTTL 返回休市期间的缓存时长。
+func (s *Service) closedTTL() time.Duration {
+	if s.config != nil && s.config.Cache.MarketClosed > 0 {
+		return time.Duration(s.config.Cache.MarketClosed)
+	}
+	return CacheTTLMarketClosed
+}
+
+// vendorConfigs 为公开了限流规则的数据源的默认请求配置，其余数据源使用
+// request.DefaultConfig 的保守限流。
+var vendorConfigs = map[string]func(...request.ConfigOption) *request.Config{
+	alphavantageadapter.Name: alphavantageclient.DefaultConfig,
+	binanceadapter.Name:      binanceclient.DefaultConfig,
+	coingeckoadapter.Name:    coingeckoclient.DefaultConfig,
+	eodhadadapter.Name:       eodhdclient.DefaultConfig,
+	finnhubadapter.Name:      finnhubclient.DefaultConfig,
+	okxadapter.Name:          okxclient.DefaultConfig,
+	polygonadapter.Name:      polygonclient.DefaultConfig,
+	tushareadapter.Name:      tushareclient.DefaultConfig,
+	twelvedataadapter.Name:   twelvedataclient.DefaultConfig,
+}
+
+// client 返回数据源 name 独立的 HTTP 客户端，其访问的每个域名拥有各自的
+// 熔断、重试、限流与超时，一个数据源故障不会影响其他数据源。
+func (s *Service) client(name string) request.Client {
+	if c, ok := s.providerClients[name]; ok {
+		return c
+	}
+	c := request.NewHostClient(func(string) *request.Config { return s.requestConfig(name) })
+	s.providerClients[name] = c
+	return c
+}
+
+// requestConfig 返回数据源 name 的请求配置：以数据源公开的限流为默认值，
+// 配置中的 timeout 与 rate_limit 覆盖默认值。
+func (s *Service) requestConfig(name string) *request.Config {
+	p := s.config.provider(name)
+	var opts []request.ConfigOption
+	if p.Timeout > 0 {
+		opts = append(opts, request.WithTimeout(timeout.New[request.Response](time.Duration(p.Timeout))))
+	}
+	if r := p.RateLimit; r != nil {
+		if r.Burst {
+			opts = append(opts, request.WithRateLimiter(request.BurstyRateLimiter(uint(r.Requests), r.Period(), request.DefaultRateLimitWait)))
+		} else {
+			opts = append(opts, request.WithRateLimiter(request.SmoothRateLimiter(uint(r.Requests), r.Period(), request.DefaultRateLimitWait)))
+		}
+	}
+	if vendor, ok := vendorConfigs[name]; ok {
+		return vendor(opts...)
+	}
+	return request.DefaultConfig(opts...)
+}
+
+// lookup 返回数据源 name 的凭证 cred：优先使用配置中的凭证，其次为凭证来源。
+func (s *Service) lookup(name string, cred manager.Credential) string {
+	if v := s.config.provider(name).Credentials[cred.Key]; v != "" {
+		return v
+	}
+	if v, ok := s.credentials.Credential(name, cred); ok {
+		return v
+	}
+	return ""
+}
+
+// apiKey 返回数据源 name 的 API Key，env 为其约定的环境变量。
+func (s *Service) apiKey(name, env string) string {
+	return s.lookup(name, manager.Credential{Key: CredentialAPIKey, Env: env})
+}
+
+// tushareClient 创建 tushare 客户端。
+func (s *Service) tushareClient() *tushareclient.Client {
+	token := s.lookup(tushareadapter.Name, manager.Credential{Key: CredentialToken, Env: tushareclient.EnvToken})
+	return tushareclient.NewClient(
+		tushareclient.WithHTTPClient(s.client(tushareadapter.Name)),
+		tushareclient.WithToken(token),
+	)
+}
+
+// 以下为带 API Key 的美股数据源，API Key 由 lookup 解析，缺失时对应的适配器不会注册。
+
+func (s *Service) polygonClient() *polygonclient.Client {
+	return polygonclient.NewClient(
+		polygonclient.WithHTTPClient(s.client(polygonadapter.Name)),
+		polygonclient.WithAPIKey(s.apiKey(polygonadapter.Name, polygonclient.EnvAPIKey)),
+	)
+}
+
+func (s *Service) finnhubClient() *finnhubclient.Client {
+	return finnhubclient.NewClient(
+		finnhubclient.WithHTTPClient(s.client(finnhubadapter.Name)),
+		finnhubclient.WithAPIKey(s.apiKey(finnhubadapter.Name, finnhubclient.EnvAPIKey)),
+	)
+}
+
+func (s *Service) alphavantageClient() *alphavantageclient.Client {
+	return alphavantageclient.NewClient(
+		alphavantageclient.WithHTTPClient(s.client(alphavantageadapter.Name)),
+		alphavantageclient.WithAPIKey(s.apiKey(alphavantageadapter.Name, alphavantageclient.EnvAPIKey)),
+	)
+}
+
+func (s *Service) twelvedataClient() *twelvedataclient.Client {
+	return twelvedataclient.NewClient(
+		twelvedataclient.WithHTTPClient(s.client(twelvedataadapter.Name)),
+		twelvedataclient.WithAPIKey(s.apiKey(twelvedataadapter.Name, twelvedataclient.EnvAPIKey)),
+	)
+}
+
+func (s *Service) eodhdClient() *eodhdclient.Client {
+	return eodhdclient.NewClient(
+		eodhdclient.WithHTTPClient(s.client(eodhadadapter.Name)),
+		eodhdclient.WithAPIKey(s.apiKey(eodhadadapter.Name, eodhdclient.EnvAPIKey)),
+	)
+}
@@ -2,14 +2,21 @@ package facade
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
 	"time"
 
 	alphavantageadapter "github.com/souloss/quantds/adapters/alphavantage"
 	binanceadapter "github.com/souloss/quantds/adapters/binance"
 	bseadapter "github.com/souloss/quantds/adapters/bse"
 	cninfoadapter "github.com/souloss/quantds/adapters/cninfo"
+	coingeckoadapter "github.com/souloss/quantds/adapters/coingecko"
 	eastmoneyadapter "github.com/souloss/quantds/adapters/eastmoney"
+	eastmoneyfundadapter "github.com/souloss/quantds/adapters/eastmoneyfund"
 	eastmoneyhkadapter "github.com/souloss/quantds/adapters/eastmoneyhk"
 	eodhadadapter "github.com/souloss/quantds/adapters/eodhd"
 	finnhubadapter "github.com/souloss/quantds/adapters/finnhub"
@@ -23,32 +30,36 @@ import (
 	twelvedataadapter "github.com/souloss/quantds/adapters/twelvedata"
 	xueqiuadapter "github.com/souloss/quantds/adapters/xueqiu"
 	yahooadapter "github.com/souloss/quantds/adapters/yahoo"
-	alphavantageclient "github.com/souloss/quantds/clients/alphavantage"
 	binanceclient "github.com/souloss/quantds/clients/binance"
 	bseclient "github.com/souloss/quantds/clients/bse"
 	cninfoclient "github.com/souloss/quantds/clients/cninfo"
+	coingeckoclient "github.com/souloss/quantds/clients/coingecko"
 	eastmoneyclient "github.com/souloss/quantds/clients/eastmoney"
+	eastmoneyfundclient "github.com/souloss/quantds/clients/eastmoneyfund"
 	eastmoneyhkclient "github.com/souloss/quantds/clients/eastmoneyhk"
-	eodhdclient "github.com/souloss/quantds/clients/eodhd"
-	finnhubclient "github.com/souloss/quantds/clients/finnhub"
 	okxclient "github.com/souloss/quantds/clients/okx"
-	polygonclient "github.com/souloss/quantds/clients/polygon"
 	sinaclient "github.com/souloss/quantds/clients/sina"
 	sseclient "github.com/souloss/quantds/clients/sse"
 	szseclient "github.com/souloss/quantds/clients/szse"
 	tencentclient "github.com/souloss/quantds/clients/tencent"
-	tushareclient "github.com/souloss/quantds/clients/tushare"
-	twelvedataclient "github.com/souloss/quantds/clients/twelvedata"
 	xueqiuclient "github.com/souloss/quantds/clients/xueqiu"
 	yahooclient "github.com/souloss/quantds/clients/yahoo"
 	"github.com/souloss/quantds/domain"
 	"github.com/souloss/quantds/domain/announcement"
+	"github.com/souloss/quantds/domain/calendar"
+	"github.com/souloss/quantds/domain/corpaction"
 	"github.com/souloss/quantds/domain/financial"
+	"github.com/souloss/quantds/domain/fund"
+	"github.com/souloss/quantds/domain/index"
 	"github.com/souloss/quantds/domain/instrument"
 	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/domain/moneyflow"
+	"github.com/souloss/quantds/domain/orderbook"
 	"github.com/souloss/quantds/domain/profile"
+	"github.com/souloss/quantds/domain/sector"
 	"github.com/souloss/quantds/domain/spot"
 	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/manager/middleware"
 	"github.com/souloss/quantds/request"
 )
 
@@ -62,6 +73,15 @@ const (
 	CacheTTLKline = 5 * time.Minute
 	CacheTTLSpot  = 10 * time.Second
 	CacheTTLList  = 1 * time.Hour
+
+	CacheTTLMoneyFlow = 1 * time.Minute
+	CacheTTLSector    = 1 * time.Minute
+	CacheTTLOrderBook = 1 * time.Second
+
+	// 行情、K 线、资金流向按交易时段缓存：开盘期间使用较短时长，休市期间使用
+	// CacheTTLMarketClosed，且缓存不会跨越下一个开盘或收盘时刻。
+	CacheTTLKlineOpen    = 30 * time.Second
+	CacheTTLMarketClosed = 1 * time.Hour
 )
 
 // Service 多市场数据服务门面，统一编排各数据源提供商。
@@ -72,40 +92,178 @@ type Service struct {
 	profileManagers      map[domain.Market]*manager.Manager[profile.Request, profile.Response]
 	financialManagers    map[domain.Market]*manager.Manager[financial.Request, financial.Response]
 	announcementManagers map[domain.Market]*manager.Manager[announcement.Request, announcement.Response]
+	moneyflowManagers    map[domain.Market]*manager.Manager[moneyflow.Request, moneyflow.Response]
+	corpactionManagers   map[domain.Market]*manager.Manager[corpaction.Request, corpaction.Response]
+	adjFactorManagers    map[domain.Market]*manager.Manager[kline.FactorRequest, kline.FactorResponse]
+	calendarManagers     map[domain.Market]*manager.Manager[calendar.Request, calendar.Response]
+	fundListManagers     map[domain.Market]*manager.Manager[fund.ListRequest, fund.ListResponse]
+	fundNAVManagers      map[domain.Market]*manager.Manager[fund.NAVRequest, fund.NAVResponse]
+	fundEstimateManagers map[domain.Market]*manager.Manager[fund.EstimateRequest, fund.EstimateResponse]
+	sectorManagers       map[domain.Market]*manager.Manager[sector.ListRequest, sector.ListResponse]
+	constituentManagers  map[domain.Market]*manager.Manager[sector.ConstituentsRequest, sector.ConstituentsResponse]
+	membershipManagers   map[domain.Market]*manager.Manager[sector.MembershipRequest, sector.MembershipResponse]
+	indexListManagers    map[domain.Market]*manager.Manager[index.ListRequest, index.ListResponse]
+	indexWeightManagers  map[domain.Market]*manager.Manager[index.WeightsRequest, index.WeightsResponse]
+	orderBookManagers    map[domain.Market]*manager.Manager[orderbook.Request, orderbook.Response]
 
-	httpClient request.Client
-	metrics    manager.Collector
+	providerClients map[string]request.Client
+	config          *Config
+	served          map[route]bool
+	status          map[string]*ProviderStatus
+	credentials     CredentialSource
+	logger          *log.Logger
+	metrics         manager.Collector
+	cache           manager.Cache
+	klineStore      *middleware.KlineStore
+	localAdjust     bool
+	adjuster        *middleware.KlineAdjuster
+	calendar        *calendar.Calendar
 }
 
 // ServiceOption defines the option for Service.
 type ServiceOption func(*Service)
 
-// WithMetrics enables metrics collection.
+// WithMetrics sets the collector that receives the metrics of all managers;
+// by default an in-memory collector is used. Health-aware provider selection
+// keeps its own metrics per manager and does not depend on it.
 func WithMetrics(collector manager.Collector) ServiceOption {
 	return func(s *Service) {
 		s.metrics = collector
 	}
 }
 
+// WithCache sets the backend that stores fetch results of all managers, e.g.
+// a manager.DiskCache so results survive restarts. By default each manager
+// keeps its results in memory. The Service owns backend: Close closes it if
+// it implements io.Closer.
+func WithCache(backend manager.Cache) ServiceOption {
+	return func(s *Service) {
+		s.cache = backend
+	}
+}
+
+// WithConfig applies a declarative configuration: enabled providers, routes,
+// priorities, cache TTLs, credentials, timeouts and rate limits. cfg should
+// have been validated, e.g. by LoadConfig; use NewServiceFromConfig to also
+// reject routes to providers that do not serve them.
+func WithConfig(cfg *Config) ServiceOption {
+	return func(s *Service) {
+		s.config = cfg
+	}
+}
+
+// WithCredentialSource sets where API keys and tokens are read from when the
+// configuration does not provide them. By default they are read from the
+// environment, see EnvCredentials. Keyed providers whose credentials cannot
+// be found are not registered.
+func WithCredentialSource(src CredentialSource) ServiceOption {
+	return func(s *Service) {
+		s.credentials = src
+	}
+}
+
+// WithLogger logs the provider summary, see Service.Providers, once the
+// Service is created.
+func WithLogger(logger *log.Logger) ServiceOption {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithKlineStore serves GetKline from store, so repeated or overlapping
+// requests only fetch the bars the store does not hold yet.
+func WithKlineStore(store *middleware.KlineStore) ServiceOption {
+	return func(s *Service) {
+		s.klineStore = store
+	}
+}
+
+// WithLocalAdjust computes adjusted klines locally from unadjusted bars and
+// adjustment factors, or corporate actions where no factors are available,
+// so that every provider returns the same adjusted series. Responses are
+// tagged with the adjustment they received. Markets without factor or
+// corporate action providers keep the upstream adjustment.
+func WithLocalAdjust() ServiceOption {
+	return func(s *Service) {
+		s.localAdjust = true
+	}
+}
+
 // NewService 创建新的多市场数据服务。
 func NewService(opts ...ServiceOption) *Service {
 	s := &Service{
-		httpClient:           request.NewClient(request.DefaultConfig()),
+		providerClients:      make(map[string]request.Client),
+		served:               make(map[route]bool),
+		status:               make(map[string]*ProviderStatus),
+		credentials:          EnvCredentials(),
 		klineManagers:        make(map[domain.Market]*manager.Manager[kline.Request, kline.Response]),
 		spotManagers:         make(map[domain.Market]*manager.Manager[spot.Request, spot.Response]),
 		instrumentManagers:   make(map[domain.Market]*manager.Manager[instrument.Request, instrument.Response]),
 		profileManagers:      make(map[domain.Market]*manager.Manager[profile.Request, profile.Response]),
 		financialManagers:    make(map[domain.Market]*manager.Manager[financial.Request, financial.Response]),
 		announcementManagers: make(map[domain.Market]*manager.Manager[announcement.Request, announcement.Response]),
-		metrics:              manager.NewNoopCollector(),
+		moneyflowManagers:    make(map[domain.Market]*manager.Manager[moneyflow.Request, moneyflow.Response]),
+		corpactionManagers:   make(map[domain.Market]*manager.Manager[corpaction.Request, corpaction.Response]),
+		adjFactorManagers:    make(map[domain.Market]*manager.Manager[kline.FactorRequest, kline.FactorResponse]),
+		calendarManagers:     make(map[domain.Market]*manager.Manager[calendar.Request, calendar.Response]),
+		fundListManagers:     make(map[domain.Market]*manager.Manager[fund.ListRequest, fund.ListResponse]),
+		fundNAVManagers:      make(map[domain.Market]*manager.Manager[fund.NAVRequest, fund.NAVResponse]),
+		fundEstimateManagers: make(map[domain.Market]*manager.Manager[fund.EstimateRequest, fund.EstimateResponse]),
+		sectorManagers:       make(map[domain.Market]*manager.Manager[sector.ListRequest, sector.ListResponse]),
+		constituentManagers:  make(map[domain.Market]*manager.Manager[sector.ConstituentsRequest, sector.ConstituentsResponse]),
+		membershipManagers:   make(map[domain.Market]*manager.Manager[sector.MembershipRequest, sector.MembershipResponse]),
+		indexListManagers:    make(map[domain.Market]*manager.Manager[index.ListRequest, index.ListResponse]),
+		indexWeightManagers:  make(map[domain.Market]*manager.Manager[index.WeightsRequest, index.WeightsResponse]),
+		orderBookManagers:    make(map[domain.Market]*manager.Manager[orderbook.Request, orderbook.Response]),
+		metrics:              manager.NewMemoryCollector(),
 	}
 	for _, opt := range opts {
 		opt(s)
 	}
+	s.calendar = calendar.New(calendar.WithLoader(s.GetTradingCalendar))
 	s.initManagers()
+	if s.logger != nil {
+		for _, line := range strings.Split(strings.TrimSpace(s.Providers().String()), "\n") {
+			s.logger.Printf("[facade] %s", line)
+		}
+	}
+	if s.localAdjust {
+		s.adjuster = middleware.NewKlineAdjuster(
+			middleware.WithFactorSource(s.fetchAdjFactors),
+			middleware.WithCorpActionSource(s.GetCorporateActionsWithTrace),
+			middleware.WithSymbolFilter(s.canAdjust),
+		)
+	}
 	return s
 }
 
+// NewServiceFromConfig 按配置创建数据服务。配置无效，或路由指向不提供该数据的
+// 数据源时返回错误。
+func NewServiceFromConfig(cfg *Config, opts ...ServiceOption) (*Service, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	s := NewService(append(opts, WithConfig(cfg))...)
+	if err := s.checkRoutes(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Service) cacheOptions() []manager.TwoLevelCacheOption {
+	if s.cache == nil {
+		return nil
+	}
+	return []manager.TwoLevelCacheOption{manager.WithFetchCache(s.cache)}
+}
+
+// marketHoursTTL 返回按交易时段决定缓存时长的策略，开盘期间缓存 open 或
+// 配置中 data 的缓存时长。
+func (s *Service) marketHoursTTL(data DataType, open time.Duration) manager.TTLPolicy {
+	return manager.NewMarketHoursTTL(s.calendar, s.cacheTTL(data, open), s.closedTTL())
+}
+
 // GetStats returns the metrics statistics.
 func (s *Service) GetStats() manager.Stats {
 	return s.metrics.GetStats()
@@ -115,334 +273,508 @@ func (s *Service) initManagers() {
 	// ========== K 线数据 ==========
 	// A股 (CN) - 支持 eastmoney, sina, tencent, tushare, xueqiu
 	s.klineManagers[domain.MarketCN] = manager.NewManager[kline.Request, kline.Response](
-		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, CacheTTLKline),
+		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, s.cacheTTL(DataKline, CacheTTLKline), s.cacheOptions()...),
+		manager.WithCacheTTL[kline.Request, kline.Response](s.marketHoursTTL(DataKline, CacheTTLKlineOpen)),
 		manager.WithMetrics[kline.Request, kline.Response](s.metrics),
-		manager.WithProvider[kline.Request, kline.Response](
-			eastmoneyadapter.NewKlineAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[kline.Request, kline.Response](s.selector()),
+		withSource[kline.Request, kline.Response](s, domain.MarketCN, DataKline, PriorityHighest,
+			// 东方财富按日期分页，A 股每日交易 4 小时
+			middleware.KlinePaging(eastmoneyclient.MaxCandleLimit, middleware.WithDayPrecision(4*time.Hour))(
+				eastmoneyadapter.NewKlineAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+			),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			sinaadapter.NewKlineAdapter(sinaclient.NewClient(sinaclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHigh),
+		withSource[kline.Request, kline.Response](s, domain.MarketCN, DataKline, PriorityHigh,
+			sinaadapter.NewKlineAdapter(sinaclient.NewClient(sinaclient.WithHTTPClient(s.client(sinaadapter.Name)))),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			tencentadapter.NewKlineAdapter(tencentclient.NewClient(tencentclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityMedium),
+		withSource[kline.Request, kline.Response](s, domain.MarketCN, DataKline, PriorityMedium,
+			tencentadapter.NewKlineAdapter(tencentclient.NewClient(tencentclient.WithHTTPClient(s.client(tencentadapter.Name)))),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			tushareadapter.NewKlineAdapter(tushareclient.NewClient(tushareclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityLow),
+		withSource[kline.Request, kline.Response](s, domain.MarketCN, DataKline, PriorityLow,
+			tushareadapter.NewKlineAdapter(s.tushareClient()),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			xueqiuadapter.NewKlineAdapter(xueqiuclient.NewClient(xueqiuclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityLowest),
+		withSource[kline.Request, kline.Response](s, domain.MarketCN, DataKline, PriorityLowest,
+			xueqiuadapter.NewKlineAdapter(xueqiuclient.NewClient(xueqiuclient.WithHTTPClient(s.client(xueqiuadapter.Name)))),
 		),
 	)
 
 	// ========== 实时行情 ==========
 	// A股 (CN) - 支持 sina, tencent, eastmoney, xueqiu
 	s.spotManagers[domain.MarketCN] = manager.NewManager[spot.Request, spot.Response](
-		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, CacheTTLSpot),
+		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, s.cacheTTL(DataSpot, CacheTTLSpot), s.cacheOptions()...),
+		manager.WithCacheTTL[spot.Request, spot.Response](s.marketHoursTTL(DataSpot, CacheTTLSpot)),
 		manager.WithMetrics[spot.Request, spot.Response](s.metrics),
-		manager.WithProvider[spot.Request, spot.Response](
-			sinaadapter.NewSpotAdapter(sinaclient.NewClient(sinaclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[spot.Request, spot.Response](s.selector()),
+		withSource[spot.Request, spot.Response](s, domain.MarketCN, DataSpot, PriorityHighest,
+			sinaadapter.NewSpotAdapter(sinaclient.NewClient(sinaclient.WithHTTPClient(s.client(sinaadapter.Name)))),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			tencentadapter.NewSpotAdapter(tencentclient.NewClient(tencentclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHigh),
+		withSource[spot.Request, spot.Response](s, domain.MarketCN, DataSpot, PriorityHigh,
+			tencentadapter.NewSpotAdapter(tencentclient.NewClient(tencentclient.WithHTTPClient(s.client(tencentadapter.Name)))),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			eastmoneyadapter.NewSpotAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityMedium),
+		withSource[spot.Request, spot.Response](s, domain.MarketCN, DataSpot, PriorityMedium,
+			eastmoneyadapter.NewSpotAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			xueqiuadapter.NewSpotAdapter(xueqiuclient.NewClient(xueqiuclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityLow),
+		withSource[spot.Request, spot.Response](s, domain.MarketCN, DataSpot, PriorityLow,
+			xueqiuadapter.NewSpotAdapter(xueqiuclient.NewClient(xueqiuclient.WithHTTPClient(s.client(xueqiuadapter.Name)))),
 		),
 	)
 
 	// ========== 证券列表 ==========
 	// A股 (CN) - 支持 eastmoney, tushare, cninfo, sse, szse, bse
 	s.instrumentManagers[domain.MarketCN] = manager.NewManager[instrument.Request, instrument.Response](
-		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, s.cacheTTL(DataInstrument, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[instrument.Request, instrument.Response](s.metrics),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			eastmoneyadapter.NewInstrumentAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[instrument.Request, instrument.Response](s.selector()),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCN, DataInstrument, PriorityHighest,
+			eastmoneyadapter.NewInstrumentAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			tushareadapter.NewInstrumentAdapter(tushareclient.NewClient(tushareclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHigh),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCN, DataInstrument, PriorityHigh,
+			tushareadapter.NewInstrumentAdapter(s.tushareClient()),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			cninfoadapter.NewInstrumentAdapter(cninfoclient.NewClient(cninfoclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityMedium),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCN, DataInstrument, PriorityMedium,
+			cninfoadapter.NewInstrumentAdapter(cninfoclient.NewClient(cninfoclient.WithHTTPClient(s.client(cninfoadapter.Name)))),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			sseadapter.NewInstrumentAdapter(sseclient.NewClient(sseclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityLow),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCN, DataInstrument, PriorityLow,
+			sseadapter.NewInstrumentAdapter(sseclient.NewClient(sseclient.WithHTTPClient(s.client(sseadapter.Name)))),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			szseadapter.NewInstrumentAdapter(szseclient.NewClient(szseclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityLow),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCN, DataInstrument, PriorityLow,
+			szseadapter.NewInstrumentAdapter(szseclient.NewClient(szseclient.WithHTTPClient(s.client(szseadapter.Name)))),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			bseadapter.NewInstrumentAdapter(bseclient.NewClient(bseclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityLowest),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCN, DataInstrument, PriorityLowest,
+			bseadapter.NewInstrumentAdapter(bseclient.NewClient(bseclient.WithHTTPClient(s.client(bseadapter.Name)))),
 		),
 	)
 
 	// ========== 个股档案 ==========
 	// A股 (CN) - 支持 eastmoney, tushare
 	s.profileManagers[domain.MarketCN] = manager.NewManager[profile.Request, profile.Response](
-		manager.WithTwoLevelCache[profile.Request, profile.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[profile.Request, profile.Response](time.Minute, s.cacheTTL(DataProfile, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[profile.Request, profile.Response](s.metrics),
-		manager.WithProvider[profile.Request, profile.Response](
-			eastmoneyadapter.NewProfileAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[profile.Request, profile.Response](s.selector()),
+		withSource[profile.Request, profile.Response](s, domain.MarketCN, DataProfile, PriorityHighest,
+			eastmoneyadapter.NewProfileAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
 		),
-		manager.WithProvider[profile.Request, profile.Response](
-			tushareadapter.NewProfileAdapter(tushareclient.NewClient(tushareclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityMedium),
+		withSource[profile.Request, profile.Response](s, domain.MarketCN, DataProfile, PriorityMedium,
+			tushareadapter.NewProfileAdapter(s.tushareClient()),
 		),
 	)
 
 	// ========== 财务数据 ==========
 	// A股 (CN) - 支持 eastmoney, tushare
 	s.financialManagers[domain.MarketCN] = manager.NewManager[financial.Request, financial.Response](
-		manager.WithTwoLevelCache[financial.Request, financial.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[financial.Request, financial.Response](time.Minute, s.cacheTTL(DataFinancial, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[financial.Request, financial.Response](s.metrics),
-		manager.WithProvider[financial.Request, financial.Response](
-			eastmoneyadapter.NewFinancialAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[financial.Request, financial.Response](s.selector()),
+		withSource[financial.Request, financial.Response](s, domain.MarketCN, DataFinancial, PriorityHighest,
+			eastmoneyadapter.NewFinancialAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
 		),
-		manager.WithProvider[financial.Request, financial.Response](
-			tushareadapter.NewFinancialAdapter(tushareclient.NewClient(tushareclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityMedium),
+		withSource[financial.Request, financial.Response](s, domain.MarketCN, DataFinancial, PriorityMedium,
+			tushareadapter.NewFinancialAdapter(s.tushareClient()),
 		),
 	)
 
 	// ========== 公告新闻 ==========
 	// A股 (CN) - 支持 eastmoney, cninfo
 	s.announcementManagers[domain.MarketCN] = manager.NewManager[announcement.Request, announcement.Response](
-		manager.WithTwoLevelCache[announcement.Request, announcement.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[announcement.Request, announcement.Response](time.Minute, s.cacheTTL(DataAnnouncement, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[announcement.Request, announcement.Response](s.metrics),
-		manager.WithProvider[announcement.Request, announcement.Response](
-			eastmoneyadapter.NewAnnouncementAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[announcement.Request, announcement.Response](s.selector()),
+		withSource[announcement.Request, announcement.Response](s, domain.MarketCN, DataAnnouncement, PriorityHighest,
+			eastmoneyadapter.NewAnnouncementAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+		),
+		withSource[announcement.Request, announcement.Response](s, domain.MarketCN, DataAnnouncement, PriorityHigh,
+			cninfoadapter.NewAnnouncementAdapter(cninfoclient.NewClient(cninfoclient.WithHTTPClient(s.client(cninfoadapter.Name)))),
+		),
+	)
+
+	// ========== 资金流向 ==========
+	// A股 (CN) - 支持 eastmoney, sina, tencent
+	s.moneyflowManagers[domain.MarketCN] = manager.NewManager[moneyflow.Request, moneyflow.Response](
+		manager.WithTwoLevelCache[moneyflow.Request, moneyflow.Response](time.Minute, s.cacheTTL(DataMoneyFlow, CacheTTLMoneyFlow), s.cacheOptions()...),
+		manager.WithCacheTTL[moneyflow.Request, moneyflow.Response](s.marketHoursTTL(DataMoneyFlow, CacheTTLMoneyFlow)),
+		manager.WithMetrics[moneyflow.Request, moneyflow.Response](s.metrics),
+		manager.WithSelector[moneyflow.Request, moneyflow.Response](s.selector()),
+		withSource[moneyflow.Request, moneyflow.Response](s, domain.MarketCN, DataMoneyFlow, PriorityHighest,
+			eastmoneyadapter.NewMoneyFlowAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+		),
+		withSource[moneyflow.Request, moneyflow.Response](s, domain.MarketCN, DataMoneyFlow, PriorityHigh,
+			sinaadapter.NewMoneyFlowAdapter(sinaclient.NewClient(sinaclient.WithHTTPClient(s.client(sinaadapter.Name)))),
+		),
+		withSource[moneyflow.Request, moneyflow.Response](s, domain.MarketCN, DataMoneyFlow, PriorityMedium,
+			tencentadapter.NewMoneyFlowAdapter(tencentclient.NewClient(tencentclient.WithHTTPClient(s.client(tencentadapter.Name)))),
+		),
+	)
+
+	// ========== 复权因子 ==========
+	// A股 (CN) - 支持 tushare
+	s.adjFactorManagers[domain.MarketCN] = manager.NewManager[kline.FactorRequest, kline.FactorResponse](
+		manager.WithTwoLevelCache[kline.FactorRequest, kline.FactorResponse](time.Minute, s.cacheTTL(DataAdjFactor, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[kline.FactorRequest, kline.FactorResponse](s.metrics),
+		manager.WithSelector[kline.FactorRequest, kline.FactorResponse](s.selector()),
+		withSource[kline.FactorRequest, kline.FactorResponse](s, domain.MarketCN, DataAdjFactor, PriorityHighest,
+			tushareadapter.NewAdjFactorAdapter(s.tushareClient()),
+		),
+	)
+
+	// ========== 交易日历 ==========
+	// A股 (CN) - 支持 tushare，其余市场使用内置规则
+	s.calendarManagers[domain.MarketCN] = manager.NewManager[calendar.Request, calendar.Response](
+		manager.WithTwoLevelCache[calendar.Request, calendar.Response](time.Minute, s.cacheTTL(DataCalendar, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[calendar.Request, calendar.Response](s.metrics),
+		manager.WithSelector[calendar.Request, calendar.Response](s.selector()),
+		withSource[calendar.Request, calendar.Response](s, domain.MarketCN, DataCalendar, PriorityHighest,
+			tushareadapter.NewCalendarAdapter(s.tushareClient()),
+		),
+	)
+
+	// ========== 公司行为 ==========
+	// A股 (CN) - 支持 tushare, eastmoney
+	s.corpactionManagers[domain.MarketCN] = manager.NewManager[corpaction.Request, corpaction.Response](
+		manager.WithTwoLevelCache[corpaction.Request, corpaction.Response](time.Minute, s.cacheTTL(DataCorpAction, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[corpaction.Request, corpaction.Response](s.metrics),
+		manager.WithSelector[corpaction.Request, corpaction.Response](s.selector()),
+		withSource[corpaction.Request, corpaction.Response](s, domain.MarketCN, DataCorpAction, PriorityHighest,
+			tushareadapter.NewCorpActionAdapter(s.tushareClient()),
+		),
+		withSource[corpaction.Request, corpaction.Response](s, domain.MarketCN, DataCorpAction, PriorityHigh,
+			eastmoneyadapter.NewCorpActionAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+		),
+	)
+
+	// ========== 基金 ==========
+	// A股 (CN) - 列表与净值支持 eastmoneyfund, tushare；盘中估值支持 eastmoneyfund
+	fundClient := eastmoneyfundclient.NewClient(eastmoneyfundclient.WithHTTPClient(s.client(eastmoneyfundadapter.Name)))
+	s.fundListManagers[domain.MarketCN] = manager.NewManager[fund.ListRequest, fund.ListResponse](
+		manager.WithTwoLevelCache[fund.ListRequest, fund.ListResponse](time.Minute, s.cacheTTL(DataFundList, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[fund.ListRequest, fund.ListResponse](s.metrics),
+		manager.WithSelector[fund.ListRequest, fund.ListResponse](s.selector()),
+		withSource[fund.ListRequest, fund.ListResponse](s, domain.MarketCN, DataFundList, PriorityHighest,
+			eastmoneyfundadapter.NewFundListAdapter(fundClient),
+		),
+		withSource[fund.ListRequest, fund.ListResponse](s, domain.MarketCN, DataFundList, PriorityHigh,
+			tushareadapter.NewFundListAdapter(s.tushareClient()),
+		),
+	)
+	s.fundNAVManagers[domain.MarketCN] = manager.NewManager[fund.NAVRequest, fund.NAVResponse](
+		manager.WithTwoLevelCache[fund.NAVRequest, fund.NAVResponse](time.Minute, s.cacheTTL(DataFundNAV, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[fund.NAVRequest, fund.NAVResponse](s.metrics),
+		manager.WithSelector[fund.NAVRequest, fund.NAVResponse](s.selector()),
+		withSource[fund.NAVRequest, fund.NAVResponse](s, domain.MarketCN, DataFundNAV, PriorityHighest,
+			eastmoneyfundadapter.NewFundNAVAdapter(fundClient),
+		),
+		withSource[fund.NAVRequest, fund.NAVResponse](s, domain.MarketCN, DataFundNAV, PriorityHigh,
+			tushareadapter.NewFundNAVAdapter(s.tushareClient()),
+		),
+	)
+	s.fundEstimateManagers[domain.MarketCN] = manager.NewManager[fund.EstimateRequest, fund.EstimateResponse](
+		manager.WithTwoLevelCache[fund.EstimateRequest, fund.EstimateResponse](time.Minute, s.cacheTTL(DataFundEstimate, CacheTTLSpot), s.cacheOptions()...),
+		manager.WithCacheTTL[fund.EstimateRequest, fund.EstimateResponse](s.marketHoursTTL(DataFundEstimate, CacheTTLSpot)),
+		manager.WithMetrics[fund.EstimateRequest, fund.EstimateResponse](s.metrics),
+		manager.WithSelector[fund.EstimateRequest, fund.EstimateResponse](s.selector()),
+		withSource[fund.EstimateRequest, fund.EstimateResponse](s, domain.MarketCN, DataFundEstimate, PriorityHighest,
+			eastmoneyfundadapter.NewFundEstimateAdapter(fundClient),
+		),
+	)
+
+	// ========== 板块 ==========
+	// A股 (CN) - 列表仅 eastmoney（tushare 仅有分类，无涨跌幅与资金流向，代码也不通用）；
+	// 成分股与所属板块支持 eastmoney, tushare
+	s.sectorManagers[domain.MarketCN] = manager.NewManager[sector.ListRequest, sector.ListResponse](
+		manager.WithTwoLevelCache[sector.ListRequest, sector.ListResponse](time.Minute, s.cacheTTL(DataSector, CacheTTLSector), s.cacheOptions()...),
+		manager.WithCacheTTL[sector.ListRequest, sector.ListResponse](s.marketHoursTTL(DataSector, CacheTTLSector)),
+		manager.WithMetrics[sector.ListRequest, sector.ListResponse](s.metrics),
+		manager.WithSelector[sector.ListRequest, sector.ListResponse](s.selector()),
+		withSource[sector.ListRequest, sector.ListResponse](s, domain.MarketCN, DataSector, PriorityHighest,
+			eastmoneyadapter.NewSectorListAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+		),
+	)
+	s.constituentManagers[domain.MarketCN] = manager.NewManager[sector.ConstituentsRequest, sector.ConstituentsResponse](
+		manager.WithTwoLevelCache[sector.ConstituentsRequest, sector.ConstituentsResponse](time.Minute, s.cacheTTL(DataSectorConstituents, CacheTTLSector), s.cacheOptions()...),
+		manager.WithCacheTTL[sector.ConstituentsRequest, sector.ConstituentsResponse](s.marketHoursTTL(DataSectorConstituents, CacheTTLSector)),
+		manager.WithMetrics[sector.ConstituentsRequest, sector.ConstituentsResponse](s.metrics),
+		manager.WithSelector[sector.ConstituentsRequest, sector.ConstituentsResponse](s.selector()),
+		withSource[sector.ConstituentsRequest, sector.ConstituentsResponse](s, domain.MarketCN, DataSectorConstituents, PriorityHighest,
+			eastmoneyadapter.NewSectorConstituentsAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+		),
+		withSource[sector.ConstituentsRequest, sector.ConstituentsResponse](s, domain.MarketCN, DataSectorConstituents, PriorityHigh,
+			tushareadapter.NewSectorConstituentsAdapter(s.tushareClient()),
 		),
-		manager.WithProvider[announcement.Request, announcement.Response](
-			cninfoadapter.NewAnnouncementAdapter(cninfoclient.NewClient(cninfoclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHigh),
+	)
+	s.membershipManagers[domain.MarketCN] = manager.NewManager[sector.MembershipRequest, sector.MembershipResponse](
+		manager.WithTwoLevelCache[sector.MembershipRequest, sector.MembershipResponse](time.Minute, s.cacheTTL(DataSectorMembership, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[sector.MembershipRequest, sector.MembershipResponse](s.metrics),
+		manager.WithSelector[sector.MembershipRequest, sector.MembershipResponse](s.selector()),
+		withSource[sector.MembershipRequest, sector.MembershipResponse](s, domain.MarketCN, DataSectorMembership, PriorityHighest,
+			eastmoneyadapter.NewSectorMembershipAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+		),
+		withSource[sector.MembershipRequest, sector.MembershipResponse](s, domain.MarketCN, DataSectorMembership, PriorityHigh,
+			tushareadapter.NewSectorMembershipAdapter(s.tushareClient()),
+		),
+	)
+
+	// ========== 指数 ==========
+	// A股 (CN) - 列表支持 eastmoney, tushare；成分权重支持 tushare。指数 K 线通过 GetKline 获取
+	s.indexListManagers[domain.MarketCN] = manager.NewManager[index.ListRequest, index.ListResponse](
+		manager.WithTwoLevelCache[index.ListRequest, index.ListResponse](time.Minute, s.cacheTTL(DataIndexList, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[index.ListRequest, index.ListResponse](s.metrics),
+		manager.WithSelector[index.ListRequest, index.ListResponse](s.selector()),
+		withSource[index.ListRequest, index.ListResponse](s, domain.MarketCN, DataIndexList, PriorityHighest,
+			eastmoneyadapter.NewIndexListAdapter(eastmoneyclient.NewClient(eastmoneyclient.WithHTTPClient(s.client(eastmoneyadapter.Name)))),
+		),
+		withSource[index.ListRequest, index.ListResponse](s, domain.MarketCN, DataIndexList, PriorityHigh,
+			tushareadapter.NewIndexListAdapter(s.tushareClient()),
+		),
+	)
+	s.indexWeightManagers[domain.MarketCN] = manager.NewManager[index.WeightsRequest, index.WeightsResponse](
+		manager.WithTwoLevelCache[index.WeightsRequest, index.WeightsResponse](time.Minute, s.cacheTTL(DataIndexWeight, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[index.WeightsRequest, index.WeightsResponse](s.metrics),
+		manager.WithSelector[index.WeightsRequest, index.WeightsResponse](s.selector()),
+		withSource[index.WeightsRequest, index.WeightsResponse](s, domain.MarketCN, DataIndexWeight, PriorityHighest,
+			tushareadapter.NewIndexWeightsAdapter(s.tushareClient()),
+		),
+	)
+
+	// ========== 盘口 ==========
+	// A股 (CN) - 支持 xueqiu (五档)
+	s.orderBookManagers[domain.MarketCN] = manager.NewManager[orderbook.Request, orderbook.Response](
+		manager.WithTwoLevelCache[orderbook.Request, orderbook.Response](time.Minute, s.cacheTTL(DataOrderBook, CacheTTLOrderBook), s.cacheOptions()...),
+		manager.WithCacheTTL[orderbook.Request, orderbook.Response](s.marketHoursTTL(DataOrderBook, CacheTTLOrderBook)),
+		manager.WithMetrics[orderbook.Request, orderbook.Response](s.metrics),
+		manager.WithSelector[orderbook.Request, orderbook.Response](s.selector()),
+		withSource[orderbook.Request, orderbook.Response](s, domain.MarketCN, DataOrderBook, PriorityHighest,
+			xueqiuadapter.NewOrderBookAdapter(xueqiuclient.NewClient(xueqiuclient.WithHTTPClient(s.client(xueqiuadapter.Name)))),
 		),
 	)
 
 	// ========== 美股 (US) ==========
 	// K线 - 支持 yahoo, finnhub, polygon, alphavantage, twelvedata, eodhd
 	s.klineManagers[domain.MarketUS] = manager.NewManager[kline.Request, kline.Response](
-		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, CacheTTLKline),
+		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, s.cacheTTL(DataKline, CacheTTLKline), s.cacheOptions()...),
+		manager.WithCacheTTL[kline.Request, kline.Response](s.marketHoursTTL(DataKline, CacheTTLKlineOpen)),
 		manager.WithMetrics[kline.Request, kline.Response](s.metrics),
-		manager.WithProvider[kline.Request, kline.Response](
-			yahooadapter.NewKlineAdapter(yahooclient.NewClient(yahooclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[kline.Request, kline.Response](s.selector()),
+		withSource[kline.Request, kline.Response](s, domain.MarketUS, DataKline, PriorityHighest,
+			yahooadapter.NewKlineAdapter(yahooclient.NewClient(yahooclient.WithHTTPClient(s.client(yahooadapter.Name)))),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			finnhubadapter.NewKlineAdapter(finnhubclient.NewClient()),
-			manager.WithPriority(PriorityHigh),
+		withSource[kline.Request, kline.Response](s, domain.MarketUS, DataKline, PriorityHigh,
+			finnhubadapter.NewKlineAdapter(s.finnhubClient()),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			polygonadapter.NewKlineAdapter(polygonclient.NewClient()),
-			manager.WithPriority(PriorityMedium),
+		withSource[kline.Request, kline.Response](s, domain.MarketUS, DataKline, PriorityMedium,
+			polygonadapter.NewKlineAdapter(s.polygonClient()),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			alphavantageadapter.NewKlineAdapter(alphavantageclient.NewClient()),
-			manager.WithPriority(PriorityLow),
+		withSource[kline.Request, kline.Response](s, domain.MarketUS, DataKline, PriorityLow,
+			alphavantageadapter.NewKlineAdapter(s.alphavantageClient()),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			twelvedataadapter.NewKlineAdapter(twelvedataclient.NewClient()),
-			manager.WithPriority(PriorityLow),
+		withSource[kline.Request, kline.Response](s, domain.MarketUS, DataKline, PriorityLow,
+			twelvedataadapter.NewKlineAdapter(s.twelvedataClient()),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			eodhadadapter.NewKlineAdapter(eodhdclient.NewClient()),
-			manager.WithPriority(PriorityLowest),
+		withSource[kline.Request, kline.Response](s, domain.MarketUS, DataKline, PriorityLowest,
+			eodhadadapter.NewKlineAdapter(s.eodhdClient()),
 		),
 	)
 
 	// 实时行情 - 支持 yahoo, finnhub, polygon, alphavantage, twelvedata, eodhd
 	s.spotManagers[domain.MarketUS] = manager.NewManager[spot.Request, spot.Response](
-		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, CacheTTLSpot),
+		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, s.cacheTTL(DataSpot, CacheTTLSpot), s.cacheOptions()...),
+		manager.WithCacheTTL[spot.Request, spot.Response](s.marketHoursTTL(DataSpot, CacheTTLSpot)),
 		manager.WithMetrics[spot.Request, spot.Response](s.metrics),
-		manager.WithProvider[spot.Request, spot.Response](
-			yahooadapter.NewSpotAdapter(yahooclient.NewClient(yahooclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[spot.Request, spot.Response](s.selector()),
+		withSource[spot.Request, spot.Response](s, domain.MarketUS, DataSpot, PriorityHighest,
+			yahooadapter.NewSpotAdapter(yahooclient.NewClient(yahooclient.WithHTTPClient(s.client(yahooadapter.Name)))),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			finnhubadapter.NewSpotAdapter(finnhubclient.NewClient()),
-			manager.WithPriority(PriorityHigh),
+		withSource[spot.Request, spot.Response](s, domain.MarketUS, DataSpot, PriorityHigh,
+			finnhubadapter.NewSpotAdapter(s.finnhubClient()),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			polygonadapter.NewSpotAdapter(polygonclient.NewClient()),
-			manager.WithPriority(PriorityMedium),
+		withSource[spot.Request, spot.Response](s, domain.MarketUS, DataSpot, PriorityMedium,
+			polygonadapter.NewSpotAdapter(s.polygonClient()),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			alphavantageadapter.NewSpotAdapter(alphavantageclient.NewClient()),
-			manager.WithPriority(PriorityLow),
+		withSource[spot.Request, spot.Response](s, domain.MarketUS, DataSpot, PriorityLow,
+			alphavantageadapter.NewSpotAdapter(s.alphavantageClient()),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			twelvedataadapter.NewSpotAdapter(twelvedataclient.NewClient()),
-			manager.WithPriority(PriorityLow),
+		withSource[spot.Request, spot.Response](s, domain.MarketUS, DataSpot, PriorityLow,
+			twelvedataadapter.NewSpotAdapter(s.twelvedataClient()),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			eodhadadapter.NewSpotAdapter(eodhdclient.NewClient()),
-			manager.WithPriority(PriorityLowest),
+		withSource[spot.Request, spot.Response](s, domain.MarketUS, DataSpot, PriorityLowest,
+			eodhadadapter.NewSpotAdapter(s.eodhdClient()),
 		),
 	)
 
 	// 证券列表 - 支持 yahoo, finnhub, polygon, twelvedata, eodhd
 	s.instrumentManagers[domain.MarketUS] = manager.NewManager[instrument.Request, instrument.Response](
-		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, s.cacheTTL(DataInstrument, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[instrument.Request, instrument.Response](s.metrics),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			yahooadapter.NewInstrumentAdapter(yahooclient.NewClient(yahooclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[instrument.Request, instrument.Response](s.selector()),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketUS, DataInstrument, PriorityHighest,
+			yahooadapter.NewInstrumentAdapter(yahooclient.NewClient(yahooclient.WithHTTPClient(s.client(yahooadapter.Name)))),
+		),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketUS, DataInstrument, PriorityHigh,
+			finnhubadapter.NewInstrumentAdapter(s.finnhubClient()),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			finnhubadapter.NewInstrumentAdapter(finnhubclient.NewClient()),
-			manager.WithPriority(PriorityHigh),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketUS, DataInstrument, PriorityMedium,
+			polygonadapter.NewInstrumentAdapter(s.polygonClient()),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			polygonadapter.NewInstrumentAdapter(polygonclient.NewClient()),
-			manager.WithPriority(PriorityMedium),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketUS, DataInstrument, PriorityLow,
+			twelvedataadapter.NewInstrumentAdapter(s.twelvedataClient()),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			twelvedataadapter.NewInstrumentAdapter(twelvedataclient.NewClient()),
-			manager.WithPriority(PriorityLow),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketUS, DataInstrument, PriorityLowest,
+			eodhadadapter.NewInstrumentAdapter(s.eodhdClient()),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			eodhadadapter.NewInstrumentAdapter(eodhdclient.NewClient()),
-			manager.WithPriority(PriorityLowest),
+	)
+
+	// 公司行为 - 支持 yahoo
+	s.corpactionManagers[domain.MarketUS] = manager.NewManager[corpaction.Request, corpaction.Response](
+		manager.WithTwoLevelCache[corpaction.Request, corpaction.Response](time.Minute, s.cacheTTL(DataCorpAction, CacheTTLList), s.cacheOptions()...),
+		manager.WithMetrics[corpaction.Request, corpaction.Response](s.metrics),
+		manager.WithSelector[corpaction.Request, corpaction.Response](s.selector()),
+		withSource[corpaction.Request, corpaction.Response](s, domain.MarketUS, DataCorpAction, PriorityHighest,
+			yahooadapter.NewCorpActionAdapter(yahooclient.NewClient(yahooclient.WithHTTPClient(s.client(yahooadapter.Name)))),
 		),
 	)
 
 	// ========== 港股 (HK) ==========
 	// K线 - 支持 eastmoneyhk
 	s.klineManagers[domain.MarketHK] = manager.NewManager[kline.Request, kline.Response](
-		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, CacheTTLKline),
+		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, s.cacheTTL(DataKline, CacheTTLKline), s.cacheOptions()...),
+		manager.WithCacheTTL[kline.Request, kline.Response](s.marketHoursTTL(DataKline, CacheTTLKlineOpen)),
 		manager.WithMetrics[kline.Request, kline.Response](s.metrics),
-		manager.WithProvider[kline.Request, kline.Response](
-			eastmoneyhkadapter.NewKlineAdapter(eastmoneyhkclient.NewClient(eastmoneyhkclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[kline.Request, kline.Response](s.selector()),
+		withSource[kline.Request, kline.Response](s, domain.MarketHK, DataKline, PriorityHighest,
+			eastmoneyhkadapter.NewKlineAdapter(eastmoneyhkclient.NewClient(eastmoneyhkclient.WithHTTPClient(s.client(eastmoneyhkadapter.Name)))),
 		),
 	)
 
 	// 实时行情 - 支持 eastmoneyhk
 	s.spotManagers[domain.MarketHK] = manager.NewManager[spot.Request, spot.Response](
-		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, CacheTTLSpot),
+		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, s.cacheTTL(DataSpot, CacheTTLSpot), s.cacheOptions()...),
+		manager.WithCacheTTL[spot.Request, spot.Response](s.marketHoursTTL(DataSpot, CacheTTLSpot)),
 		manager.WithMetrics[spot.Request, spot.Response](s.metrics),
-		manager.WithProvider[spot.Request, spot.Response](
-			eastmoneyhkadapter.NewSpotAdapter(eastmoneyhkclient.NewClient(eastmoneyhkclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[spot.Request, spot.Response](s.selector()),
+		withSource[spot.Request, spot.Response](s, domain.MarketHK, DataSpot, PriorityHighest,
+			eastmoneyhkadapter.NewSpotAdapter(eastmoneyhkclient.NewClient(eastmoneyhkclient.WithHTTPClient(s.client(eastmoneyhkadapter.Name)))),
 		),
 	)
 
 	// 证券列表 - 支持 eastmoneyhk
 	s.instrumentManagers[domain.MarketHK] = manager.NewManager[instrument.Request, instrument.Response](
-		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, s.cacheTTL(DataInstrument, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[instrument.Request, instrument.Response](s.metrics),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			eastmoneyhkadapter.NewInstrumentAdapter(eastmoneyhkclient.NewClient(eastmoneyhkclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[instrument.Request, instrument.Response](s.selector()),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketHK, DataInstrument, PriorityHighest,
+			eastmoneyhkadapter.NewInstrumentAdapter(eastmoneyhkclient.NewClient(eastmoneyhkclient.WithHTTPClient(s.client(eastmoneyhkadapter.Name)))),
 		),
 	)
 
 	// ========== 加密货币 (Crypto) ==========
-	// K线 - 支持 binance, okx
+	// K线 - 支持 binance, okx, coingecko
 	s.klineManagers[domain.MarketCrypto] = manager.NewManager[kline.Request, kline.Response](
-		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, CacheTTLKline),
+		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, s.cacheTTL(DataKline, CacheTTLKline), s.cacheOptions()...),
+		manager.WithCacheTTL[kline.Request, kline.Response](s.marketHoursTTL(DataKline, CacheTTLKlineOpen)),
 		manager.WithMetrics[kline.Request, kline.Response](s.metrics),
-		manager.WithProvider[kline.Request, kline.Response](
-			binanceadapter.NewKlineAdapter(binanceclient.NewClient(binanceclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[kline.Request, kline.Response](s.selector()),
+		withSource[kline.Request, kline.Response](s, domain.MarketCrypto, DataKline, PriorityHighest,
+			middleware.KlinePaging(binanceclient.MaxKlineLimit)(
+				binanceadapter.NewKlineAdapter(binanceclient.NewClient(binanceclient.WithHTTPClient(s.client(binanceadapter.Name)))),
+			),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			okxadapter.NewKlineAdapter(okxclient.NewClient(okxclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHigh),
+		withSource[kline.Request, kline.Response](s, domain.MarketCrypto, DataKline, PriorityHigh,
+			okxadapter.NewKlineAdapter(okxclient.NewClient(okxclient.WithHTTPClient(s.client(okxadapter.Name)))),
+		),
+		// 交易所接口被地域封锁时的兜底数据源
+		withSource[kline.Request, kline.Response](s, domain.MarketCrypto, DataKline, PriorityLow,
+			coingeckoadapter.NewKlineAdapter(coingeckoclient.NewClient(coingeckoclient.WithHTTPClient(s.client(coingeckoadapter.Name)))),
 		),
 	)
 
-	// 实时行情 - 支持 binance, okx
+	// 实时行情 - 支持 binance, okx, coingecko
 	s.spotManagers[domain.MarketCrypto] = manager.NewManager[spot.Request, spot.Response](
-		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, CacheTTLSpot),
+		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, s.cacheTTL(DataSpot, CacheTTLSpot), s.cacheOptions()...),
+		manager.WithCacheTTL[spot.Request, spot.Response](s.marketHoursTTL(DataSpot, CacheTTLSpot)),
 		manager.WithMetrics[spot.Request, spot.Response](s.metrics),
-		manager.WithProvider[spot.Request, spot.Response](
-			binanceadapter.NewSpotAdapter(binanceclient.NewClient(binanceclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[spot.Request, spot.Response](s.selector()),
+		withSource[spot.Request, spot.Response](s, domain.MarketCrypto, DataSpot, PriorityHighest,
+			binanceadapter.NewSpotAdapter(binanceclient.NewClient(binanceclient.WithHTTPClient(s.client(binanceadapter.Name)))),
+		),
+		withSource[spot.Request, spot.Response](s, domain.MarketCrypto, DataSpot, PriorityHigh,
+			okxadapter.NewSpotAdapter(okxclient.NewClient(okxclient.WithHTTPClient(s.client(okxadapter.Name)))),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			okxadapter.NewSpotAdapter(okxclient.NewClient(okxclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHigh),
+		// 交易所接口被地域封锁时的兜底数据源
+		withSource[spot.Request, spot.Response](s, domain.MarketCrypto, DataSpot, PriorityLow,
+			coingeckoadapter.NewSpotAdapter(coingeckoclient.NewClient(coingeckoclient.WithHTTPClient(s.client(coingeckoadapter.Name)))),
 		),
 	)
 
-	// 证券列表 - 支持 binance, okx
+	// 盘口 - 支持 binance, okx
+	s.orderBookManagers[domain.MarketCrypto] = manager.NewManager[orderbook.Request, orderbook.Response](
+		manager.WithTwoLevelCache[orderbook.Request, orderbook.Response](time.Minute, s.cacheTTL(DataOrderBook, CacheTTLOrderBook), s.cacheOptions()...),
+		manager.WithCacheTTL[orderbook.Request, orderbook.Response](s.marketHoursTTL(DataOrderBook, CacheTTLOrderBook)),
+		manager.WithMetrics[orderbook.Request, orderbook.Response](s.metrics),
+		manager.WithSelector[orderbook.Request, orderbook.Response](s.selector()),
+		withSource[orderbook.Request, orderbook.Response](s, domain.MarketCrypto, DataOrderBook, PriorityHighest,
+			binanceadapter.NewOrderBookAdapter(binanceclient.NewClient(binanceclient.WithHTTPClient(s.client(binanceadapter.Name)))),
+		),
+		withSource[orderbook.Request, orderbook.Response](s, domain.MarketCrypto, DataOrderBook, PriorityHigh,
+			okxadapter.NewOrderBookAdapter(okxclient.NewClient(okxclient.WithHTTPClient(s.client(okxadapter.Name)))),
+		),
+	)
+
+	// 证券列表 - 支持 binance, okx, coingecko
 	s.instrumentManagers[domain.MarketCrypto] = manager.NewManager[instrument.Request, instrument.Response](
-		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, s.cacheTTL(DataInstrument, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[instrument.Request, instrument.Response](s.metrics),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			binanceadapter.NewInstrumentAdapter(binanceclient.NewClient(binanceclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[instrument.Request, instrument.Response](s.selector()),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCrypto, DataInstrument, PriorityHighest,
+			binanceadapter.NewInstrumentAdapter(binanceclient.NewClient(binanceclient.WithHTTPClient(s.client(binanceadapter.Name)))),
+		),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCrypto, DataInstrument, PriorityHigh,
+			okxadapter.NewInstrumentAdapter(okxclient.NewClient(okxclient.WithHTTPClient(s.client(okxadapter.Name)))),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			okxadapter.NewInstrumentAdapter(okxclient.NewClient(okxclient.WithHTTPClient(s.httpClient))),
-			manager.WithPriority(PriorityHigh),
+		// 交易所接口被地域封锁时的兜底数据源
+		withSource[instrument.Request, instrument.Response](s, domain.MarketCrypto, DataInstrument, PriorityLow,
+			coingeckoadapter.NewInstrumentAdapter(coingeckoclient.NewClient(coingeckoclient.WithHTTPClient(s.client(coingeckoadapter.Name)))),
 		),
 	)
 
 	// ========== 外汇 (Forex) ==========
 	// K线 - 支持 finnhub, alphavantage, twelvedata
 	s.klineManagers[domain.MarketForex] = manager.NewManager[kline.Request, kline.Response](
-		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, CacheTTLKline),
+		manager.WithTwoLevelCache[kline.Request, kline.Response](time.Minute, s.cacheTTL(DataKline, CacheTTLKline), s.cacheOptions()...),
+		manager.WithCacheTTL[kline.Request, kline.Response](s.marketHoursTTL(DataKline, CacheTTLKlineOpen)),
 		manager.WithMetrics[kline.Request, kline.Response](s.metrics),
-		manager.WithProvider[kline.Request, kline.Response](
-			finnhubadapter.NewKlineAdapter(finnhubclient.NewClient()),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[kline.Request, kline.Response](s.selector()),
+		withSource[kline.Request, kline.Response](s, domain.MarketForex, DataKline, PriorityHighest,
+			finnhubadapter.NewKlineAdapter(s.finnhubClient()),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			alphavantageadapter.NewKlineAdapter(alphavantageclient.NewClient()),
-			manager.WithPriority(PriorityMedium),
+		withSource[kline.Request, kline.Response](s, domain.MarketForex, DataKline, PriorityMedium,
+			alphavantageadapter.NewKlineAdapter(s.alphavantageClient()),
 		),
-		manager.WithProvider[kline.Request, kline.Response](
-			twelvedataadapter.NewKlineAdapter(twelvedataclient.NewClient()),
-			manager.WithPriority(PriorityLow),
+		withSource[kline.Request, kline.Response](s, domain.MarketForex, DataKline, PriorityLow,
+			twelvedataadapter.NewKlineAdapter(s.twelvedataClient()),
 		),
 	)
 
 	// 实时行情 - 支持 finnhub, twelvedata
 	s.spotManagers[domain.MarketForex] = manager.NewManager[spot.Request, spot.Response](
-		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, CacheTTLSpot),
+		manager.WithTwoLevelCache[spot.Request, spot.Response](time.Minute, s.cacheTTL(DataSpot, CacheTTLSpot), s.cacheOptions()...),
+		manager.WithCacheTTL[spot.Request, spot.Response](s.marketHoursTTL(DataSpot, CacheTTLSpot)),
 		manager.WithMetrics[spot.Request, spot.Response](s.metrics),
-		manager.WithProvider[spot.Request, spot.Response](
-			finnhubadapter.NewSpotAdapter(finnhubclient.NewClient()),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[spot.Request, spot.Response](s.selector()),
+		withSource[spot.Request, spot.Response](s, domain.MarketForex, DataSpot, PriorityHighest,
+			finnhubadapter.NewSpotAdapter(s.finnhubClient()),
 		),
-		manager.WithProvider[spot.Request, spot.Response](
-			twelvedataadapter.NewSpotAdapter(twelvedataclient.NewClient()),
-			manager.WithPriority(PriorityMedium),
+		withSource[spot.Request, spot.Response](s, domain.MarketForex, DataSpot, PriorityMedium,
+			twelvedataadapter.NewSpotAdapter(s.twelvedataClient()),
 		),
 	)
 
 	// 证券列表 - 支持 finnhub, twelvedata
 	s.instrumentManagers[domain.MarketForex] = manager.NewManager[instrument.Request, instrument.Response](
-		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, CacheTTLList),
+		manager.WithTwoLevelCache[instrument.Request, instrument.Response](time.Minute, s.cacheTTL(DataInstrument, CacheTTLList), s.cacheOptions()...),
 		manager.WithMetrics[instrument.Request, instrument.Response](s.metrics),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			finnhubadapter.NewInstrumentAdapter(finnhubclient.NewClient()),
-			manager.WithPriority(PriorityHighest),
+		manager.WithSelector[instrument.Request, instrument.Response](s.selector()),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketForex, DataInstrument, PriorityHighest,
+			finnhubadapter.NewInstrumentAdapter(s.finnhubClient()),
 		),
-		manager.WithProvider[instrument.Request, instrument.Response](
-			twelvedataadapter.NewInstrumentAdapter(twelvedataclient.NewClient()),
-			manager.WithPriority(PriorityMedium),
+		withSource[instrument.Request, instrument.Response](s, domain.MarketForex, DataInstrument, PriorityMedium,
+			twelvedataadapter.NewInstrumentAdapter(s.twelvedataClient()),
 		),
 	)
 }
@@ -472,13 +804,109 @@ func (s *Service) GetKlineWithTrace(ctx context.Context, req kline.Request) (kli
 	if !ok {
 		return kline.Response{}, nil, fmt.Errorf("unsupported market for kline: %s", market)
 	}
+	fetch := func(ctx context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+		result, err := m.Fetch(ctx, req)
+		if err != nil {
+			return kline.Response{}, nil, err
+		}
+		return result.Data, result.Trace, nil
+	}
+	if s.klineStore != nil {
+		fetchManager := fetch
+		fetch = func(ctx context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+			return s.klineStore.Fetch(ctx, req, fetchManager)
+		}
+	}
+	if s.adjuster != nil {
+		return s.adjuster.Fetch(ctx, req, fetch)
+	}
+	return fetch(ctx, req)
+}
+
+// fetchAdjFactors 获取复权因子，供本地复权使用。
+func (s *Service) fetchAdjFactors(ctx context.Context, req kline.FactorRequest) (kline.FactorResponse, *manager.RequestTrace, error) {
+	market, err := s.getMarketFromSymbol(req.Symbol)
+	if err != nil {
+		return kline.FactorResponse{}, nil, err
+	}
+	m, ok := s.adjFactorManagers[market]
+	if !ok {
+		return kline.FactorResponse{}, nil, fmt.Errorf("unsupported market for adjustment factors: %s", market)
+	}
 	result, err := m.Fetch(ctx, req)
 	if err != nil {
-		return kline.Response{}, nil, err
+		return kline.FactorResponse{}, nil, err
 	}
 	return result.Data, result.Trace, nil
 }
 
+// canAdjust 报告 symbol 所属市场是否有复权因子或公司行为数据源，
+// 没有时 K 线直接由上游复权。
+func (s *Service) canAdjust(symbol string) bool {
+	market, err := s.getMarketFromSymbol(symbol)
+	if err != nil {
+		return false
+	}
+	_, hasFactors := s.adjFactorManagers[market]
+	_, hasActions := s.corpactionManagers[market]
+	return hasFactors || hasActions
+}
+
+// GetKlineConsensus 同时从所有可用数据源获取 K 线，按时间戳对齐后依据 rule 合并收盘价，
+// 每根 K 线整根取自收盘价最接近合并值的数据源，并返回各数据源在每根 K 线、每个字段上超出 tolerance（相对偏差）的分歧报告。
+// 启用 WithLocalAdjust 时各数据源与 GetKline 一样在本地复权后再比较。
+// K 线存储每个序列只保存一个数据源的 K 线，因此这里不经过 WithKlineStore。
+func (s *Service) GetKlineConsensus(ctx context.Context, req kline.Request, rule kline.ConsensusRule, tolerance float64) (kline.Response, kline.ConsensusReport, error) {
+	market, err := s.getMarketFromSymbol(req.Symbol)
+	if err != nil {
+		return kline.Response{}, kline.ConsensusReport{}, err
+	}
+	m, ok := s.klineManagers[market]
+	if !ok {
+		return kline.Response{}, kline.ConsensusReport{}, fmt.Errorf("unsupported market for kline: %s", market)
+	}
+	providers, err := m.Route(req)
+	if err != nil {
+		return kline.Response{}, kline.ConsensusReport{}, err
+	}
+
+	responses := make([]kline.Response, len(providers))
+	errs := make([]error, len(providers))
+	var wg sync.WaitGroup
+	for i, name := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetch := func(ctx context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+				result, err := m.FetchFrom(ctx, name, req)
+				if err != nil {
+					return kline.Response{}, nil, err
+				}
+				return result.Data, result.Trace, nil
+			}
+			if s.adjuster != nil {
+				responses[i], _, errs[i] = s.adjuster.Fetch(ctx, req, fetch)
+			} else {
+				responses[i], _, errs[i] = fetch(ctx, req)
+			}
+			responses[i].Source = name
+		}()
+	}
+	wg.Wait()
+
+	succeeded := make([]kline.Response, 0, len(responses))
+	for i, resp := range responses {
+		if errs[i] == nil {
+			succeeded = append(succeeded, resp)
+		}
+	}
+	if len(succeeded) == 0 {
+		return kline.Response{}, kline.ConsensusReport{}, errors.Join(append([]error{manager.ErrAllProviderFailed}, errs...)...)
+	}
+	resp, report := kline.Consensus(succeeded, rule, tolerance)
+	return resp, report, nil
+}
+
 // GetSpot 获取实时行情。
 func (s *Service) GetSpot(ctx context.Context, req spot.Request) (spot.Response, error) {
 	resp, _, err := s.GetSpotWithTrace(ctx, req)
@@ -505,6 +933,29 @@ func (s *Service) GetSpotWithTrace(ctx context.Context, req spot.Request) (spot.
 	return result.Data, result.Trace, nil
 }
 
+// GetOrderBook 获取盘口快照（多档买卖盘），可计算价差、中间价、买卖失衡与累计深度。
+func (s *Service) GetOrderBook(ctx context.Context, req orderbook.Request) (orderbook.Response, error) {
+	resp, _, err := s.GetOrderBookWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetOrderBookWithTrace 获取盘口快照并返回请求追踪信息。
+func (s *Service) GetOrderBookWithTrace(ctx context.Context, req orderbook.Request) (orderbook.Response, *manager.RequestTrace, error) {
+	market, err := s.getMarketFromSymbol(req.Symbol)
+	if err != nil {
+		return orderbook.Response{}, nil, err
+	}
+	m, ok := s.orderBookManagers[market]
+	if !ok {
+		return orderbook.Response{}, nil, fmt.Errorf("unsupported market for order book: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return orderbook.Response{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
 // GetInstruments 获取证券列表。
 func (s *Service) GetInstruments(ctx context.Context, req instrument.Request) (instrument.Response, error) {
 	market := domain.MarketCN
@@ -598,6 +1049,243 @@ func (s *Service) GetAnnouncements(ctx context.Context, req announcement.Request
 	return result.Data, nil
 }
 
+// GetMoneyFlow 获取资金流向（当日实时或日线历史）。
+func (s *Service) GetMoneyFlow(ctx context.Context, req moneyflow.Request) (moneyflow.Response, error) {
+	resp, _, err := s.GetMoneyFlowWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetMoneyFlowWithTrace 获取资金流向并返回请求追踪信息。
+func (s *Service) GetMoneyFlowWithTrace(ctx context.Context, req moneyflow.Request) (moneyflow.Response, *manager.RequestTrace, error) {
+	market, err := s.getMarketFromSymbol(req.Symbol)
+	if err != nil {
+		return moneyflow.Response{}, nil, err
+	}
+	m, ok := s.moneyflowManagers[market]
+	if !ok {
+		return moneyflow.Response{}, nil, fmt.Errorf("unsupported market for money flow: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return moneyflow.Response{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetCorporateActions 获取公司行为（分红、送转、拆股、配股）。
+func (s *Service) GetCorporateActions(ctx context.Context, req corpaction.Request) (corpaction.Response, error) {
+	resp, _, err := s.GetCorporateActionsWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetCorporateActionsWithTrace 获取公司行为并返回请求追踪信息。
+func (s *Service) GetCorporateActionsWithTrace(ctx context.Context, req corpaction.Request) (corpaction.Response, *manager.RequestTrace, error) {
+	market, err := s.getMarketFromSymbol(req.Symbol)
+	if err != nil {
+		return corpaction.Response{}, nil, err
+	}
+	m, ok := s.corpactionManagers[market]
+	if !ok {
+		return corpaction.Response{}, nil, fmt.Errorf("unsupported market for corporate actions: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return corpaction.Response{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetTradingCalendar 从数据源获取交易所日历。
+func (s *Service) GetTradingCalendar(ctx context.Context, req calendar.Request) (calendar.Response, error) {
+	resp, _, err := s.GetTradingCalendarWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetTradingCalendarWithTrace 获取交易所日历并返回请求追踪信息。
+func (s *Service) GetTradingCalendarWithTrace(ctx context.Context, req calendar.Request) (calendar.Response, *manager.RequestTrace, error) {
+	market := req.RequestMarket()
+	m, ok := s.calendarManagers[market]
+	if !ok {
+		return calendar.Response{}, nil, fmt.Errorf("unsupported market for calendar: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return calendar.Response{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetFundList 获取公募基金列表，可按基金类型筛选并分页。
+func (s *Service) GetFundList(ctx context.Context, req fund.ListRequest) (fund.ListResponse, error) {
+	resp, _, err := s.GetFundListWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetFundListWithTrace 获取公募基金列表并返回请求追踪信息。
+func (s *Service) GetFundListWithTrace(ctx context.Context, req fund.ListRequest) (fund.ListResponse, *manager.RequestTrace, error) {
+	market := req.RequestMarket()
+	m, ok := s.fundListManagers[market]
+	if !ok {
+		return fund.ListResponse{}, nil, fmt.Errorf("unsupported market for fund list: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return fund.ListResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetFundNAV 获取基金历史单位净值与累计净值。
+func (s *Service) GetFundNAV(ctx context.Context, req fund.NAVRequest) (fund.NAVResponse, error) {
+	resp, _, err := s.GetFundNAVWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetFundNAVWithTrace 获取基金历史净值并返回请求追踪信息。
+func (s *Service) GetFundNAVWithTrace(ctx context.Context, req fund.NAVRequest) (fund.NAVResponse, *manager.RequestTrace, error) {
+	market := req.RequestMarket()
+	m, ok := s.fundNAVManagers[market]
+	if !ok {
+		return fund.NAVResponse{}, nil, fmt.Errorf("unsupported market for fund nav: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return fund.NAVResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetFundEstimates 获取基金盘中估值。
+func (s *Service) GetFundEstimates(ctx context.Context, req fund.EstimateRequest) (fund.EstimateResponse, error) {
+	resp, _, err := s.GetFundEstimatesWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetFundEstimatesWithTrace 获取基金盘中估值并返回请求追踪信息。
+func (s *Service) GetFundEstimatesWithTrace(ctx context.Context, req fund.EstimateRequest) (fund.EstimateResponse, *manager.RequestTrace, error) {
+	market := req.RequestMarket()
+	m, ok := s.fundEstimateManagers[market]
+	if !ok {
+		return fund.EstimateResponse{}, nil, fmt.Errorf("unsupported market for fund estimate: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return fund.EstimateResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetSectors 获取行业、概念或地域板块列表及当日表现。
+func (s *Service) GetSectors(ctx context.Context, req sector.ListRequest) (sector.ListResponse, error) {
+	resp, _, err := s.GetSectorsWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetSectorsWithTrace 获取板块列表并返回请求追踪信息。
+func (s *Service) GetSectorsWithTrace(ctx context.Context, req sector.ListRequest) (sector.ListResponse, *manager.RequestTrace, error) {
+	market := req.RequestMarket()
+	m, ok := s.sectorManagers[market]
+	if !ok {
+		return sector.ListResponse{}, nil, fmt.Errorf("unsupported market for sectors: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return sector.ListResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetSectorConstituents 获取板块成分股，板块代码由返回该板块的数据源决定。
+func (s *Service) GetSectorConstituents(ctx context.Context, req sector.ConstituentsRequest) (sector.ConstituentsResponse, error) {
+	resp, _, err := s.GetSectorConstituentsWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetSectorConstituentsWithTrace 获取板块成分股并返回请求追踪信息。
+func (s *Service) GetSectorConstituentsWithTrace(ctx context.Context, req sector.ConstituentsRequest) (sector.ConstituentsResponse, *manager.RequestTrace, error) {
+	market := req.RequestMarket()
+	m, ok := s.constituentManagers[market]
+	if !ok {
+		return sector.ConstituentsResponse{}, nil, fmt.Errorf("unsupported market for sector constituents: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return sector.ConstituentsResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetSymbolSectors 获取个股所属的板块。
+func (s *Service) GetSymbolSectors(ctx context.Context, req sector.MembershipRequest) (sector.MembershipResponse, error) {
+	resp, _, err := s.GetSymbolSectorsWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetSymbolSectorsWithTrace 获取个股所属板块并返回请求追踪信息。
+func (s *Service) GetSymbolSectorsWithTrace(ctx context.Context, req sector.MembershipRequest) (sector.MembershipResponse, *manager.RequestTrace, error) {
+	market, err := s.getMarketFromSymbol(req.Symbol)
+	if err != nil {
+		return sector.MembershipResponse{}, nil, err
+	}
+	m, ok := s.membershipManagers[market]
+	if !ok {
+		return sector.MembershipResponse{}, nil, fmt.Errorf("unsupported market for sector membership: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return sector.MembershipResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetIndexes 获取指数列表。指数 K 线使用 GetKline，代码需带交易所后缀（如 000300.SH）。
+func (s *Service) GetIndexes(ctx context.Context, req index.ListRequest) (index.ListResponse, error) {
+	resp, _, err := s.GetIndexesWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetIndexesWithTrace 获取指数列表并返回请求追踪信息。
+func (s *Service) GetIndexesWithTrace(ctx context.Context, req index.ListRequest) (index.ListResponse, *manager.RequestTrace, error) {
+	market := req.RequestMarket()
+	m, ok := s.indexListManagers[market]
+	if !ok {
+		return index.ListResponse{}, nil, fmt.Errorf("unsupported market for indexes: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return index.ListResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// GetIndexWeights 获取指数成分股及权重快照。
+func (s *Service) GetIndexWeights(ctx context.Context, req index.WeightsRequest) (index.WeightsResponse, error) {
+	resp, _, err := s.GetIndexWeightsWithTrace(ctx, req)
+	return resp, err
+}
+
+// GetIndexWeightsWithTrace 获取指数成分权重并返回请求追踪信息。
+func (s *Service) GetIndexWeightsWithTrace(ctx context.Context, req index.WeightsRequest) (index.WeightsResponse, *manager.RequestTrace, error) {
+	market, err := s.getMarketFromSymbol(req.Symbol)
+	if err != nil {
+		return index.WeightsResponse{}, nil, err
+	}
+	m, ok := s.indexWeightManagers[market]
+	if !ok {
+		return index.WeightsResponse{}, nil, fmt.Errorf("unsupported market for index weights: %s", market)
+	}
+	result, err := m.Fetch(ctx, req)
+	if err != nil {
+		return index.WeightsResponse{}, nil, err
+	}
+	return result.Data, result.Trace, nil
+}
+
+// Calendar 返回交易日历：优先使用数据源，不可用时回退到内置规则（周末与节假日表）。
+func (s *Service) Calendar() *calendar.Calendar {
+	return s.calendar
+}
+
 // Stats 返回统计信息。
 func (s *Service) Stats() manager.Stats {
 	if m, ok := s.klineManagers[domain.MarketCN]; ok {
@@ -606,9 +1294,12 @@ func (s *Service) Stats() manager.Stats {
 	return manager.Stats{}
 }
 
-// Close 释放资源。
+// Close 释放资源，包括 WithCache 设置的缓存。
 func (s *Service) Close() {
-	if s.httpClient != nil {
-		s.httpClient.Close()
+	for _, c := range s.providerClients {
+		c.Close()
+	}
+	if closer, ok := s.cache.(io.Closer); ok {
+		closer.Close()
 	}
 }
@@ -6,12 +6,22 @@ import (
 	"testing"
 	"time"
 
+	"github.com/souloss/quantds/domain"
 	"github.com/souloss/quantds/domain/announcement"
+	"github.com/souloss/quantds/domain/calendar"
+	"github.com/souloss/quantds/domain/corpaction"
 	"github.com/souloss/quantds/domain/financial"
+	"github.com/souloss/quantds/domain/fund"
+	"github.com/souloss/quantds/domain/index"
 	"github.com/souloss/quantds/domain/instrument"
 	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/domain/moneyflow"
+	"github.com/souloss/quantds/domain/orderbook"
 	"github.com/souloss/quantds/domain/profile"
+	"github.com/souloss/quantds/domain/sector"
 	"github.com/souloss/quantds/domain/spot"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
 )
 
 // checkFacadeError 检查 API 错误并优雅跳过不可控的外部故障
@@ -175,6 +185,296 @@ func TestService_GetAnnouncements_CN(t *testing.T) {
 	}
 }
 
+func TestService_GetMoneyFlow_CN(t *testing.T) {
+	svc := NewService()
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := svc.GetMoneyFlow(ctx, moneyflow.Request{
+		Symbol: "600519.SH",
+		Period: moneyflow.PeriodDaily,
+		Limit:  5,
+	})
+	checkFacadeError(t, err)
+
+	t.Logf("Money flow from %s: %d days", result.Source, len(result.Flows))
+	for _, f := range result.Flows {
+		t.Logf("  %s main=%.0f (%.2f%%)", f.Date.Format("2006-01-02"), f.MainNetInflow, f.MainNetRatio)
+	}
+}
+
+func TestService_GetKline_LocalAdjust(t *testing.T) {
+	svc := NewService(WithLocalAdjust())
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := svc.GetKline(ctx, kline.Request{
+		Symbol:    "600519.SH",
+		Timeframe: kline.Timeframe1d,
+		StartTime: time.Now().AddDate(0, -3, 0),
+		EndTime:   time.Now(),
+		Adjust:    kline.AdjustForward,
+	})
+	checkFacadeError(t, err)
+
+	t.Logf("Got %d bars from %s, adjusted %s/%s", len(result.Bars), result.Source, result.Adjust, result.AdjustMethod)
+	if len(result.Bars) > 0 && result.AdjustMethod != kline.AdjustMethodNone && result.Adjust != kline.AdjustForward {
+		t.Errorf("Adjust = %q, want %q", result.Adjust, kline.AdjustForward)
+	}
+}
+
+// consensusKlineProvider 返回固定的未复权 K 线；请求上游复权时返回 999 以便识别。
+type consensusKlineProvider struct {
+	name string
+}
+
+func (p consensusKlineProvider) Name() string { return p.name }
+func (p consensusKlineProvider) SupportedMarkets() []domain.Market {
+	return []domain.Market{domain.MarketCN}
+}
+func (p consensusKlineProvider) CanHandle(symbol string) bool { return true }
+func (p consensusKlineProvider) Fetch(_ context.Context, _ request.Client, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+	closes := []float64{10, 9.5}
+	if req.Adjust != kline.AdjustNone {
+		closes = []float64{999, 999}
+	}
+	resp := kline.Response{Symbol: req.Symbol, Source: p.name}
+	for i, c := range closes {
+		resp.Bars = append(resp.Bars, kline.Bar{
+			Timestamp: time.Date(2024, 6, 3+i, 0, 0, 0, 0, time.UTC),
+			Open:      c, High: c, Low: c, Close: c,
+		})
+	}
+	return resp, manager.NewRequestTrace(p.name), nil
+}
+
+// consensusActionProvider 返回一次每股 1 元的现金分红。
+type consensusActionProvider struct{}
+
+func (consensusActionProvider) Name() string { return "actions" }
+func (consensusActionProvider) SupportedMarkets() []domain.Market {
+	return []domain.Market{domain.MarketCN}
+}
+func (consensusActionProvider) CanHandle(symbol string) bool { return true }
+func (consensusActionProvider) Fetch(_ context.Context, _ request.Client, req corpaction.Request) (corpaction.Response, *manager.RequestTrace, error) {
+	return corpaction.Response{
+		Symbol: req.Symbol,
+		Actions: []corpaction.Action{{
+			Symbol: req.Symbol,
+			Type:   corpaction.ActionCashDividend,
+			ExDate: time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC),
+			Cash:   1,
+		}},
+		Source: "actions",
+	}, manager.NewRequestTrace("actions"), nil
+}
+
+func TestService_GetKlineConsensus_LocalAdjust(t *testing.T) {
+	svc := NewService(WithLocalAdjust())
+	defer svc.Close()
+	svc.klineManagers[domain.MarketCN] = manager.NewManager(
+		manager.WithProvider[kline.Request, kline.Response](consensusKlineProvider{name: "a"}),
+		manager.WithProvider[kline.Request, kline.Response](consensusKlineProvider{name: "b"}),
+	)
+	svc.corpactionManagers[domain.MarketCN] = manager.NewManager(
+		manager.WithProvider[corpaction.Request, corpaction.Response](consensusActionProvider{}),
+	)
+	delete(svc.adjFactorManagers, domain.MarketCN)
+
+	resp, report, err := svc.GetKlineConsensus(context.Background(), kline.Request{
+		Symbol:    "600519.SH",
+		Timeframe: kline.Timeframe1d,
+		Adjust:    kline.AdjustForward,
+	}, kline.ConsensusMedian, 0.01)
+	if err != nil {
+		t.Fatalf("GetKlineConsensus() error = %v", err)
+	}
+	if len(resp.Bars) != 2 {
+		t.Fatalf("got %d bars, want 2", len(resp.Bars))
+	}
+	// 前复权: 除息前收盘价 10 按 (10-1)/10 调整为 9，除息日不变
+	if got := resp.Bars[0].Close; got < 8.999 || got > 9.001 {
+		t.Errorf("Bars[0].Close = %v, want the locally adjusted 9", got)
+	}
+	if got := resp.Bars[1].Close; got != 9.5 {
+		t.Errorf("Bars[1].Close = %v, want 9.5", got)
+	}
+	if len(report.Providers) != 2 || len(report.Discrepancies) != 0 {
+		t.Errorf("report = %+v, want 2 agreeing providers", report)
+	}
+}
+
+func TestService_GetCorporateActions_CN(t *testing.T) {
+	svc := NewService()
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := svc.GetCorporateActions(ctx, corpaction.Request{
+		Symbol: "600519.SH",
+		Types:  []corpaction.ActionType{corpaction.ActionCashDividend},
+	})
+	checkFacadeError(t, err)
+
+	t.Logf("Corporate actions from %s: %d", result.Source, len(result.Actions))
+	for _, a := range result.Actions {
+		t.Logf("  %s %s cash=%.4f", a.ExDate.Format("2006-01-02"), a.Type, a.Cash)
+	}
+}
+
+func TestService_Fund(t *testing.T) {
+	svc := NewService()
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	t.Run("list", func(t *testing.T) {
+		result, err := svc.GetFundList(ctx, fund.ListRequest{Type: fund.TypeIndex, PageSize: 5})
+		checkFacadeError(t, err)
+		t.Logf("Index funds from %s: %d of %d", result.Source, len(result.Funds), result.Total)
+	})
+
+	t.Run("nav", func(t *testing.T) {
+		result, err := svc.GetFundNAV(ctx, fund.NAVRequest{
+			Code:      "000001",
+			StartTime: time.Now().AddDate(0, -1, 0),
+			EndTime:   time.Now(),
+		})
+		checkFacadeError(t, err)
+		t.Logf("NAVs from %s: %d", result.Source, len(result.NAVs))
+		for i := 1; i < len(result.NAVs); i++ {
+			if !result.NAVs[i].Date.After(result.NAVs[i-1].Date) {
+				t.Errorf("NAVs not in ascending date order at %d", i)
+			}
+		}
+	})
+
+	t.Run("estimate", func(t *testing.T) {
+		result, err := svc.GetFundEstimates(ctx, fund.EstimateRequest{Codes: []string{"000001", "110011"}})
+		checkFacadeError(t, err)
+		for _, e := range result.Estimates {
+			t.Logf("  %s %s est=%.4f (%.2f%%) at %s", e.Code, e.Name, e.EstNAV, e.EstChangeRate, e.EstTime.Format("15:04"))
+		}
+	})
+}
+
+func TestService_Sector(t *testing.T) {
+	svc := NewService()
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	list, err := svc.GetSectors(ctx, sector.ListRequest{Kind: sector.KindIndustry, PageSize: 5})
+	checkFacadeError(t, err)
+	t.Logf("Industries from %s: %d of %d", list.Source, len(list.Sectors), list.Total)
+	if len(list.Sectors) == 0 {
+		t.Skip("no sectors returned")
+	}
+
+	top := list.Sectors[0]
+	constituents, err := svc.GetSectorConstituents(ctx, sector.ConstituentsRequest{Code: top.Code})
+	checkFacadeError(t, err)
+	t.Logf("%s %s (%.2f%%): %d constituents from %s", top.Code, top.Name, top.ChangeRate, len(constituents.Constituents), constituents.Source)
+
+	membership, err := svc.GetSymbolSectors(ctx, sector.MembershipRequest{Symbol: "600519.SH"})
+	checkFacadeError(t, err)
+	for _, s := range membership.Sectors {
+		t.Logf("  600519.SH in %s %s (%s)", s.Code, s.Name, s.Kind)
+	}
+}
+
+func TestService_Index(t *testing.T) {
+	svc := NewService()
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	t.Run("list", func(t *testing.T) {
+		result, err := svc.GetIndexes(ctx, index.ListRequest{Exchange: domain.ExchangeSZ, PageSize: 5})
+		checkFacadeError(t, err)
+		t.Logf("SZ indexes from %s: %d of %d", result.Source, len(result.Indexes), result.Total)
+		for _, idx := range result.Indexes {
+			if idx.Exchange != domain.ExchangeSZ {
+				t.Errorf("index %s not on SZ", idx.Symbol)
+			}
+		}
+	})
+
+	t.Run("kline", func(t *testing.T) {
+		result, err := svc.GetKline(ctx, kline.Request{
+			Symbol:    "000300.SH",
+			Timeframe: kline.Timeframe1d,
+			StartTime: time.Now().AddDate(0, -1, 0),
+			EndTime:   time.Now(),
+		})
+		checkFacadeError(t, err)
+		t.Logf("CSI 300 bars from %s: %d", result.Source, len(result.Bars))
+		// 沪深300点位远高于 000300.SZ 之类的股票价格
+		if len(result.Bars) > 0 && result.Bars[0].Close < 1000 {
+			t.Errorf("000300.SH close = %.2f, looks like a stock rather than the index", result.Bars[0].Close)
+		}
+	})
+
+	t.Run("weights", func(t *testing.T) {
+		result, err := svc.GetIndexWeights(ctx, index.WeightsRequest{Symbol: "000300.SH"})
+		checkFacadeError(t, err)
+		t.Logf("CSI 300 weights on %s: %d constituents", result.Date.Format("2006-01-02"), len(result.Weights))
+	})
+}
+
+func TestService_OrderBook(t *testing.T) {
+	svc := NewService()
+	defer svc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, symbol := range []string{"600519.SH", "BTCUSDT"} {
+		t.Run(symbol, func(t *testing.T) {
+			result, err := svc.GetOrderBook(ctx, orderbook.Request{Symbol: symbol, Depth: 5})
+			checkFacadeError(t, err)
+			book := result.Book
+			t.Logf("%s from %s: %d bids, %d asks, spread %.4f, imbalance %.2f",
+				symbol, result.Source, len(book.Bids), len(book.Asks), book.Spread(), book.Imbalance(0))
+			if len(book.Bids) > 5 || len(book.Asks) > 5 {
+				t.Errorf("book deeper than requested: %d bids, %d asks", len(book.Bids), len(book.Asks))
+			}
+			if book.Spread() < 0 {
+				t.Errorf("crossed book: spread %f", book.Spread())
+			}
+		})
+	}
+}
+
+func TestService_Calendar(t *testing.T) {
+	svc := NewService()
+	defer svc.Close()
+
+	ctx := context.Background()
+	nyse := domain.ExchangeNYSE
+
+	// 美股没有日历数据源，使用内置规则
+	if _, err := svc.GetTradingCalendar(ctx, calendar.Request{Exchange: nyse}); err == nil {
+		t.Error("GetTradingCalendar(NYSE) should report an unsupported market")
+	}
+	thanksgiving := time.Date(2024, time.November, 28, 12, 0, 0, 0, time.UTC)
+	open, err := svc.Calendar().IsTradingDay(ctx, nyse, thanksgiving)
+	if err != nil || open {
+		t.Errorf("IsTradingDay(NYSE, Thanksgiving) = %v, %v", open, err)
+	}
+	if src := svc.Calendar().Source(ctx, nyse, thanksgiving); src != calendar.RuleSource {
+		t.Errorf("Source() = %q, want %q", src, calendar.RuleSource)
+	}
+}
+
 // ========== 美股市场测试 ==========
 
 func TestService_USMarket(t *testing.T) {
@@ -3,9 +3,11 @@ module github.com/souloss/quantds
 go 1.24.0
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/failsafe-go/failsafe-go v0.9.6
 	github.com/xuri/excelize/v2 v2.10.0
 	golang.org/x/text v0.34.0
+	gopkg.in/yaml.v3 v3.0.1
 	resty.dev/v3 v3.0.0-beta.6
 )
 
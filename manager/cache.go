@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
 	"sync"
 	"time"
 )
@@ -15,9 +17,13 @@ type Cache interface {
 	Clear()
 }
 
+// memoryCleanupInterval is how often Set sweeps expired entries out of a MemoryCache.
+const memoryCleanupInterval = time.Minute
+
 type MemoryCache struct {
-	mu   sync.RWMutex
-	data map[string]*cacheEntry
+	mu          sync.RWMutex
+	data        map[string]*cacheEntry
+	lastCleanup time.Time
 }
 
 type cacheEntry struct {
@@ -27,7 +33,8 @@ type cacheEntry struct {
 
 func NewMemoryCache() *MemoryCache {
 	return &MemoryCache{
-		data: make(map[string]*cacheEntry),
+		data:        make(map[string]*cacheEntry),
+		lastCleanup: time.Now(),
 	}
 }
 
@@ -47,9 +54,13 @@ func (c *MemoryCache) Get(key string) ([]byte, bool) {
 func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(c.lastCleanup) >= memoryCleanupInterval {
+		c.cleanup(now)
+	}
 	c.data[key] = &cacheEntry{
 		data:      value,
-		expiresAt: time.Now().Add(ttl),
+		expiresAt: now.Add(ttl),
 	}
 }
 
@@ -65,10 +76,9 @@ func (c *MemoryCache) Clear() {
 	c.data = make(map[string]*cacheEntry)
 }
 
-func (c *MemoryCache) cleanup() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	now := time.Now()
+// cleanup removes expired entries. The caller must hold the write lock.
+func (c *MemoryCache) cleanup(now time.Time) {
+	c.lastCleanup = now
 	for k, v := range c.data {
 		if now.After(v.expiresAt) {
 			delete(c.data, k)
@@ -83,17 +93,49 @@ type TwoLevelCache struct {
 	fetchCache   Cache
 	requestTTL   time.Duration
 	fetchTTL     time.Duration
+	owned        []Cache // default backends, closed by Close
 }
 
-func NewTwoLevelCache(requestTTL, fetchTTL time.Duration) *TwoLevelCache {
-	return &TwoLevelCache{
-		requestCache: NewMemoryCache(),
-		fetchCache:   NewMemoryCache(),
-		requestTTL:   requestTTL,
-		fetchTTL:     fetchTTL,
+// TwoLevelCacheOption configures a TwoLevelCache.
+type TwoLevelCacheOption func(*TwoLevelCache)
+
+// WithRequestCache sets the backend of the request level. Nil keeps the default MemoryCache.
+func WithRequestCache(cache Cache) TwoLevelCacheOption {
+	return func(c *TwoLevelCache) {
+		if cache != nil {
+			c.requestCache = cache
+		}
+	}
+}
+
+// WithFetchCache sets the backend of the fetch result level. Nil keeps the default MemoryCache.
+func WithFetchCache(cache Cache) TwoLevelCacheOption {
+	return func(c *TwoLevelCache) {
+		if cache != nil {
+			c.fetchCache = cache
+		}
 	}
 }
 
+func NewTwoLevelCache(requestTTL, fetchTTL time.Duration, opts ...TwoLevelCacheOption) *TwoLevelCache {
+	c := &TwoLevelCache{
+		requestTTL: requestTTL,
+		fetchTTL:   fetchTTL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.requestCache == nil {
+		c.requestCache = NewMemoryCache()
+		c.owned = append(c.owned, c.requestCache)
+	}
+	if c.fetchCache == nil {
+		c.fetchCache = NewMemoryCache()
+		c.owned = append(c.owned, c.fetchCache)
+	}
+	return c
+}
+
 func (c *TwoLevelCache) GetRequest(key string) ([]byte, bool) {
 	return c.requestCache.Get(key)
 }
@@ -110,17 +152,44 @@ func (c *TwoLevelCache) SetFetch(key string, value []byte) {
 	c.fetchCache.Set(key, value, c.fetchTTL)
 }
 
+// SetFetchTTL stores a fetch result for ttl, or for the fetch TTL when ttl is
+// not positive.
+func (c *TwoLevelCache) SetFetchTTL(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.fetchTTL
+	}
+	c.fetchCache.Set(key, value, ttl)
+}
+
 func (c *TwoLevelCache) Clear() {
 	c.requestCache.Clear()
 	c.fetchCache.Clear()
 }
 
+// Close releases the backends the cache created itself. Backends set by
+// WithRequestCache or WithFetchCache, such as a DiskCache shared by several
+// managers, belong to the caller and are left open.
+func (c *TwoLevelCache) Close() error {
+	var err error
+	for _, backend := range c.owned {
+		if closer, ok := backend.(io.Closer); ok {
+			if cerr := closer.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	return err
+}
+
+// BuildCacheKey hashes the type and JSON encoding of data, so requests of
+// different types never share a key in a common backend.
 func BuildCacheKey(data interface{}) string {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return ""
 	}
 	h := sha256.New()
+	fmt.Fprintf(h, "%T:", data)
 	h.Write(jsonData)
 	return hex.EncodeToString(h.Sum(nil))
 }
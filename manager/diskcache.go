@@ -0,0 +1,373 @@
+package manager
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	diskCacheExt    = ".cache"
+	diskTempPrefix  = ".tmp-"
+	diskHeaderBytes = 8 // big-endian expiry in unix nanoseconds
+)
+
+// DiskCache is a Cache that stores each entry as a file in a directory, so
+// cached data survives restarts. It bounds the total size and entry count
+// with LRU eviction and removes expired files from a background janitor.
+//
+// Recency is tracked in memory; after a restart the file modification time
+// seeds the LRU order. Files are read, written and removed outside the lock,
+// so a slow disk does not hold up callers of other keys.
+type DiskCache struct {
+	dir        string
+	maxBytes   int64
+	maxEntries int
+	interval   time.Duration
+
+	mu    sync.Mutex
+	lru   *list.List // front = most recently used
+	index map[string]*list.Element
+	size  int64
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+type diskEntry struct {
+	name      string
+	size      int64
+	expiresAt time.Time
+}
+
+// DiskCacheOption configures a DiskCache.
+type DiskCacheOption func(*DiskCache)
+
+// WithMaxBytes limits the total size of cached files. Zero means unlimited.
+func WithMaxBytes(n int64) DiskCacheOption {
+	return func(c *DiskCache) {
+		c.maxBytes = n
+	}
+}
+
+// WithMaxEntries limits the number of cached entries. Zero means unlimited.
+func WithMaxEntries(n int) DiskCacheOption {
+	return func(c *DiskCache) {
+		c.maxEntries = n
+	}
+}
+
+// WithJanitorInterval sets how often expired entries are removed. Zero disables the janitor.
+func WithJanitorInterval(d time.Duration) DiskCacheOption {
+	return func(c *DiskCache) {
+		c.interval = d
+	}
+}
+
+// NewDiskCache opens or creates a DiskCache in dir and loads the entries left
+// by a previous run. Defaults are 256MB, no entry limit and a 5 minute janitor.
+func NewDiskCache(dir string, opts ...DiskCacheOption) (*DiskCache, error) {
+	c := &DiskCache{
+		dir:      dir,
+		maxBytes: 256 << 20,
+		interval: 5 * time.Minute,
+		lru:      list.New(),
+		index:    make(map[string]*list.Element),
+		stop:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	if err := c.load(); err != nil {
+		return nil, fmt.Errorf("load cache dir: %w", err)
+	}
+
+	if c.interval > 0 {
+		go c.janitor()
+	}
+	return c, nil
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	name := diskFileName(key)
+
+	c.mu.Lock()
+	elem, ok := c.index[name]
+	if !ok {
+		c.mu.Unlock()
+		return nil, false
+	}
+	if time.Now().After(elem.Value.(*diskEntry).expiresAt) {
+		stale := c.remove(elem)
+		c.mu.Unlock()
+		removeFiles(stale)
+		return nil, false
+	}
+	c.mu.Unlock()
+
+	raw, err := os.ReadFile(c.path(name))
+
+	c.mu.Lock()
+	if c.index[name] != elem {
+		// Replaced or removed while reading
+		c.mu.Unlock()
+		if err != nil || len(raw) < diskHeaderBytes {
+			return nil, false
+		}
+		return raw[diskHeaderBytes:], true
+	}
+	if err != nil || len(raw) < diskHeaderBytes {
+		stale := c.remove(elem)
+		c.mu.Unlock()
+		removeFiles(stale)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	c.mu.Unlock()
+	return raw[diskHeaderBytes:], true
+}
+
+// Set stores value for ttl. A value larger than the size limit is not
+// stored, and removes any previous value of key.
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	name := diskFileName(key)
+	expiresAt := time.Now().Add(ttl)
+
+	raw := make([]byte, diskHeaderBytes+len(value))
+	binary.BigEndian.PutUint64(raw, uint64(expiresAt.UnixNano()))
+	copy(raw[diskHeaderBytes:], value)
+	size := int64(len(raw))
+	if c.maxBytes > 0 && size > c.maxBytes {
+		c.Delete(key)
+		return
+	}
+
+	if err := writeFileAtomic(c.path(name), raw); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.index[name]; ok {
+		entry := elem.Value.(*diskEntry)
+		c.size += size - entry.size
+		entry.size = size
+		entry.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+	} else {
+		c.index[name] = c.lru.PushFront(&diskEntry{name: name, size: size, expiresAt: expiresAt})
+		c.size += size
+	}
+	evicted := c.evict()
+	c.mu.Unlock()
+	removeFiles(evicted...)
+}
+
+func (c *DiskCache) Delete(key string) {
+	var stale string
+	c.mu.Lock()
+	if elem, ok := c.index[diskFileName(key)]; ok {
+		stale = c.remove(elem)
+	}
+	c.mu.Unlock()
+	removeFiles(stale)
+}
+
+func (c *DiskCache) Clear() {
+	c.mu.Lock()
+	var stale []string
+	for c.lru.Len() > 0 {
+		stale = append(stale, c.remove(c.lru.Back()))
+	}
+	c.mu.Unlock()
+	removeFiles(stale...)
+}
+
+// Len returns the number of cached entries.
+func (c *DiskCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Size returns the total size of cached files in bytes.
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// Close stops the janitor. The cache stays usable and Close may be called
+// more than once. Managers and caches it backs do not close it: its owner
+// closes it once they are done.
+func (c *DiskCache) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+// load indexes the files left in the directory, oldest modification first.
+func (c *DiskCache) load() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	type found struct {
+		entry   *diskEntry
+		modTime time.Time
+	}
+	var files []found
+	now := time.Now()
+	for _, de := range entries {
+		if strings.HasPrefix(de.Name(), diskTempPrefix) {
+			// Left over by an interrupted write
+			os.Remove(filepath.Join(c.dir, de.Name()))
+			continue
+		}
+		if de.IsDir() || !strings.HasSuffix(de.Name(), diskCacheExt) {
+			continue
+		}
+		name := strings.TrimSuffix(de.Name(), diskCacheExt)
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		expiresAt, err := readExpiry(c.path(name))
+		if err != nil || now.After(expiresAt) {
+			os.Remove(c.path(name))
+			continue
+		}
+		files = append(files, found{
+			entry:   &diskEntry{name: name, size: info.Size(), expiresAt: expiresAt},
+			modTime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	c.mu.Lock()
+	for _, f := range files {
+		c.index[f.entry.name] = c.lru.PushFront(f.entry)
+		c.size += f.entry.size
+	}
+	evicted := c.evict()
+	c.mu.Unlock()
+	removeFiles(evicted...)
+	return nil
+}
+
+func (c *DiskCache) janitor() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.cleanup(time.Now())
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// cleanup removes expired entries.
+func (c *DiskCache) cleanup(now time.Time) {
+	var stale []string
+	c.mu.Lock()
+	for elem := c.lru.Back(); elem != nil; {
+		prev := elem.Prev()
+		if now.After(elem.Value.(*diskEntry).expiresAt) {
+			stale = append(stale, c.remove(elem))
+		}
+		elem = prev
+	}
+	c.mu.Unlock()
+	removeFiles(stale...)
+}
+
+// evict drops least recently used entries until the limits hold and returns
+// the files to remove. The caller must hold the lock.
+func (c *DiskCache) evict() []string {
+	var stale []string
+	for c.lru.Len() > 0 &&
+		((c.maxBytes > 0 && c.size > c.maxBytes) || (c.maxEntries > 0 && c.lru.Len() > c.maxEntries)) {
+		stale = append(stale, c.remove(c.lru.Back()))
+	}
+	return stale
+}
+
+// remove drops an entry from the index and returns its file, which the
+// caller removes after releasing the lock. The caller must hold the lock.
+func (c *DiskCache) remove(elem *list.Element) string {
+	entry := elem.Value.(*diskEntry)
+	c.lru.Remove(elem)
+	delete(c.index, entry.name)
+	c.size -= entry.size
+	return c.path(entry.name)
+}
+
+// removeFiles removes the given files, skipping empty paths.
+func removeFiles(paths ...string) {
+	for _, path := range paths {
+		if path != "" {
+			os.Remove(path)
+		}
+	}
+}
+
+func (c *DiskCache) path(name string) string {
+	return filepath.Join(c.dir, name+diskCacheExt)
+}
+
+func diskFileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func readExpiry(path string) (time.Time, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+	var header [diskHeaderBytes]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(header[:]))), nil
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place,
+// so readers never see a partially written entry.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), diskTempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+var _ Cache = (*DiskCache)(nil)
@@ -1,8 +1,51 @@
 package manager
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"github.com/souloss/quantds/request"
+)
 
 var (
 	ErrNoProvider        = errors.New("no provider available")
 	ErrAllProviderFailed = errors.New("all providers failed")
 )
+
+// SkippedProvider records a provider that was filtered out during routing.
+type SkippedProvider struct {
+	Name   string
+	Reason string
+}
+
+// NoProviderError is returned when providers are registered but none of them
+// can serve the request. It matches ErrNoProvider via errors.Is.
+type NoProviderError struct {
+	Skipped []SkippedProvider
+}
+
+func (e *NoProviderError) Error() string {
+	if len(e.Skipped) == 0 {
+		return ErrNoProvider.Error()
+	}
+	parts := make([]string, len(e.Skipped))
+	for i, s := range e.Skipped {
+		parts[i] = s.Name + ": " + s.Reason
+	}
+	return ErrNoProvider.Error() + " (skipped " + strings.Join(parts, "; ") + ")"
+}
+
+func (e *NoProviderError) Is(target error) bool {
+	return target == ErrNoProvider
+}
+
+// errorType names the kind of a failed fetch in metrics: the request error
+// type when the provider's error carries one, such as no_data or quota, and
+// fetch_error otherwise.
+func errorType(err error) string {
+	var reqErr *request.RequestError
+	if errors.As(err, &reqErr) && reqErr.Type != request.ErrorTypeNone {
+		return string(reqErr.Type)
+	}
+	return "fetch_error"
+}
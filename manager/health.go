@@ -0,0 +1,207 @@
+package manager
+
+import (
+	"sync"
+	"time"
+)
+
+// FetchObserver is implemented by selectors that learn from the fetches of
+// the manager they are installed in.
+type FetchObserver interface {
+	ObserveFetch(metric Metric)
+}
+
+// HealthSelector orders providers by health derived from the per-provider
+// fetch metrics of a Collector, on top of a base Selector.
+//
+// A provider that fails FailureThreshold times in a row is put on cooldown
+// and tried last (or skipped, see WithSkipUnhealthy). Once the cooldown
+// expires it is probed again at its normal position; another failure restarts
+// the cooldown and a success restores it. Failures specific to the request,
+// such as an unknown symbol or a range without data, are not counted.
+// Providers whose average latency exceeds the slow threshold are tried after
+// the healthy ones.
+type HealthSelector struct {
+	collector        Collector
+	private          bool
+	base             Selector
+	failureThreshold int
+	cooldown         time.Duration
+	slowThreshold    time.Duration
+	skipUnhealthy    bool
+	now              func() time.Time
+
+	mu     sync.Mutex
+	health map[string]*providerHealth
+}
+
+type providerHealth struct {
+	success  int64
+	failed   int64
+	rejected int64
+	duration int64
+
+	consecutiveFailures int
+	latency             time.Duration
+	downUntil           time.Time
+}
+
+// HealthOption configures a HealthSelector.
+type HealthOption func(*HealthSelector)
+
+// WithBaseSelector sets the selector used to order providers of equal health.
+func WithBaseSelector(base Selector) HealthOption {
+	return func(s *HealthSelector) {
+		s.base = base
+	}
+}
+
+// WithFailureThreshold sets the consecutive failures that mark a provider unhealthy.
+func WithFailureThreshold(n int) HealthOption {
+	return func(s *HealthSelector) {
+		s.failureThreshold = n
+	}
+}
+
+// WithCooldown sets how long an unhealthy provider is demoted before it is probed again.
+func WithCooldown(d time.Duration) HealthOption {
+	return func(s *HealthSelector) {
+		s.cooldown = d
+	}
+}
+
+// WithSlowThreshold demotes providers whose average latency exceeds d. Zero disables it.
+func WithSlowThreshold(d time.Duration) HealthOption {
+	return func(s *HealthSelector) {
+		s.slowThreshold = d
+	}
+}
+
+// WithSkipUnhealthy drops providers on cooldown instead of trying them last,
+// unless no other provider is left.
+func WithSkipUnhealthy(skip bool) HealthOption {
+	return func(s *HealthSelector) {
+		s.skipUnhealthy = skip
+	}
+}
+
+// NewHealthSelector creates a HealthSelector reading metrics from collector.
+//
+// A nil collector makes the selector keep its own metrics, fed only by the
+// fetches of the manager it is installed in, so that a provider failing one
+// kind of data is not demoted for the others and health does not depend on
+// the collector passed to WithMetrics. Such a selector must not be shared
+// between managers.
+func NewHealthSelector(collector Collector, opts ...HealthOption) *HealthSelector {
+	private := collector == nil
+	if private {
+		collector = NewMemoryCollector()
+	}
+	s := &HealthSelector{
+		collector:        collector,
+		private:          private,
+		base:             NewPrioritySelector(),
+		failureThreshold: 3,
+		cooldown:         30 * time.Second,
+		now:              time.Now,
+		health:           make(map[string]*providerHealth),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *HealthSelector) Select(providers []ProviderInfo) []string {
+	names := s.base.Select(providers)
+	if len(names) == 0 {
+		return names
+	}
+
+	stats := s.collector.GetStats()
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	healthy := make([]string, 0, len(names))
+	var slow, unhealthy []string
+	for _, name := range names {
+		h := s.update(name, stats.ByProvider[name], now)
+		switch {
+		case now.Before(h.downUntil):
+			unhealthy = append(unhealthy, name)
+		case s.slowThreshold > 0 && h.latency > s.slowThreshold:
+			slow = append(slow, name)
+		default:
+			healthy = append(healthy, name)
+		}
+	}
+
+	ordered := append(healthy, slow...)
+	if s.skipUnhealthy && len(ordered) > 0 {
+		return ordered
+	}
+	return append(ordered, unhealthy...)
+}
+
+// ObserveFetch records a fetch of the selector's manager when the selector
+// keeps its own metrics.
+func (s *HealthSelector) ObserveFetch(metric Metric) {
+	if s.private {
+		s.collector.RecordFetch(metric)
+	}
+}
+
+// update folds the metrics recorded since the last call into the provider's health.
+func (s *HealthSelector) update(name string, m ProviderMetric, now time.Time) *providerHealth {
+	h, ok := s.health[name]
+	if !ok {
+		h = &providerHealth{}
+		s.health[name] = h
+	}
+
+	// Failures specific to the request, such as an unknown symbol, say
+	// nothing about the provider and only count towards its latency.
+	providerFailed := m.Failed - m.Rejected
+	success := m.Success - h.success
+	failed := providerFailed - h.failed
+	rejected := m.Rejected - h.rejected
+	duration := m.Duration - h.duration
+	if success < 0 || failed < 0 || rejected < 0 || duration < 0 {
+		// Collector was reset
+		*h = providerHealth{}
+		success, failed, rejected, duration = m.Success, providerFailed, m.Rejected, m.Duration
+	}
+	h.success, h.failed, h.rejected, h.duration = m.Success, providerFailed, m.Rejected, m.Duration
+
+	switch {
+	case failed > 0 && success == 0:
+		h.consecutiveFailures += int(failed)
+	case failed > 0:
+		h.consecutiveFailures = int(failed)
+	case success > 0:
+		h.consecutiveFailures = 0
+		h.downUntil = time.Time{}
+	}
+
+	if failed > 0 && h.consecutiveFailures >= s.failureThreshold {
+		h.downUntil = now.Add(s.cooldown)
+	}
+
+	if n := success + failed + rejected; n > 0 {
+		avg := time.Duration(duration / n)
+		if h.latency == 0 {
+			h.latency = avg
+		} else {
+			h.latency = (h.latency*7 + avg*3) / 10
+		}
+	}
+
+	return h
+}
+
+var (
+	_ Selector      = (*HealthSelector)(nil)
+	_ FetchObserver = (*HealthSelector)(nil)
+)
@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Hedge outcome tags set on request records of attempts that did not win.
+const (
+	TagHedge         = "hedge"
+	TagHedgeProvider = "hedge_provider"
+	HedgeFailed      = "failed"    // attempt failed before a winner was found
+	HedgeCancelled   = "cancelled" // attempt was cancelled after another provider won
+	HedgeDiscarded   = "discarded" // attempt succeeded after another provider won
+)
+
+type hedgeConfig struct {
+	maxParallel int
+	delay       time.Duration
+}
+
+type hedgeAttempt[Resp any] struct {
+	name     string
+	resp     Resp
+	trace    *RequestTrace
+	err      error
+	duration time.Duration
+}
+
+// fetchHedged runs providers concurrently according to the hedge config and
+// returns the first successful result. Remaining attempts are cancelled and
+// awaited so that their requests can be added to the winner's trace.
+func (m *Manager[Req, Resp]) fetchHedged(ctx context.Context, req Req, names []string) (*FetchResult[Resp], error) {
+	hctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeAttempt[Resp], len(names))
+	next, inflight := 0, 0
+	launch := func() {
+		for next < len(names) {
+			name := names[next]
+			next++
+
+			m.mu.RLock()
+			provider, ok := m.providers[name]
+			m.mu.RUnlock()
+			if !ok {
+				continue
+			}
+
+			inflight++
+			go func() {
+				start := time.Now()
+				resp, trace, err := provider.Fetch(hctx, m.client, req)
+				m.recordRequests(name, trace)
+				results <- hedgeAttempt[Resp]{name: name, resp: resp, trace: trace, err: err, duration: time.Since(start)}
+			}()
+			return
+		}
+	}
+
+	launch()
+	var tick <-chan time.Time
+	if m.hedge.delay > 0 {
+		ticker := time.NewTicker(m.hedge.delay)
+		defer ticker.Stop()
+		tick = ticker.C
+	} else {
+		for inflight < m.hedge.maxParallel && next < len(names) {
+			launch()
+		}
+	}
+
+	var winner *hedgeAttempt[Resp]
+	var losers []hedgeAttempt[Resp]
+	var lastErr error
+	for inflight > 0 {
+		select {
+		case a := <-results:
+			inflight--
+			switch {
+			case winner == nil && a.err == nil:
+				winner = &a
+				cancel()
+				tick = nil
+				m.recordFetch(Metric{Provider: a.name, Duration: a.duration, Success: true})
+			case winner == nil && ctx.Err() != nil:
+				lastErr = a.err
+				tagAttempt(a.trace, a.name, HedgeCancelled)
+				losers = append(losers, a)
+			case winner == nil:
+				lastErr = a.err
+				tagAttempt(a.trace, a.name, HedgeFailed)
+				losers = append(losers, a)
+				m.recordFetch(failedFetch(a.name, a.duration, a.err))
+				if inflight < m.hedge.maxParallel {
+					launch()
+				}
+			case a.err == nil:
+				tagAttempt(a.trace, a.name, HedgeDiscarded)
+				losers = append(losers, a)
+				m.recordFetch(Metric{Provider: a.name, Duration: a.duration, Success: true})
+			default:
+				tagAttempt(a.trace, a.name, HedgeCancelled)
+				losers = append(losers, a)
+			}
+		case <-tick:
+			if inflight < m.hedge.maxParallel {
+				launch()
+			}
+		}
+	}
+
+	if winner == nil {
+		if lastErr == nil {
+			return nil, ErrNoProvider
+		}
+		return nil, errors.Join(ErrAllProviderFailed, lastErr)
+	}
+
+	trace := winner.trace
+	if trace == nil {
+		trace = NewRequestTrace(winner.name)
+	}
+	for _, l := range losers {
+		if l.trace == nil {
+			continue
+		}
+		for _, r := range l.trace.Requests {
+			trace.AddRequest(r)
+		}
+	}
+
+	result := &FetchResult[Resp]{
+		Data:     winner.resp,
+		Trace:    trace,
+		Provider: winner.name,
+		Cached:   false,
+	}
+	m.storeResult(ctx, req, result)
+	return result, nil
+}
+
+func tagAttempt(trace *RequestTrace, provider, outcome string) {
+	if trace == nil {
+		return
+	}
+	for _, r := range trace.Requests {
+		if r.Tags == nil {
+			r.Tags = make(map[string]string)
+		}
+		r.Tags[TagHedge] = outcome
+		r.Tags[TagHedgeProvider] = provider
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -34,8 +35,11 @@ type Manager[Req, Resp any] struct {
 	providerInfo map[string]ProviderInfo
 	client       request.Client
 	cache        *TwoLevelCache
+	ttlPolicy    TTLPolicy
 	metrics      Collector
 	selector     Selector
+	hedge        *hedgeConfig
+	flight       request.SingleFlight[*FetchResult[Resp]]
 }
 
 type ManagerOption[Req, Resp any] func(*Manager[Req, Resp])
@@ -46,9 +50,11 @@ func WithClient[Req, Resp any](client request.Client) ManagerOption[Req, Resp] {
 	}
 }
 
-func WithTwoLevelCache[Req, Resp any](requestTTL, fetchTTL time.Duration) ManagerOption[Req, Resp] {
+// WithTwoLevelCache enables caching. Backends default to MemoryCache and can
+// be replaced with WithRequestCache and WithFetchCache.
+func WithTwoLevelCache[Req, Resp any](requestTTL, fetchTTL time.Duration, opts ...TwoLevelCacheOption) ManagerOption[Req, Resp] {
 	return func(m *Manager[Req, Resp]) {
-		m.cache = NewTwoLevelCache(requestTTL, fetchTTL)
+		m.cache = NewTwoLevelCache(requestTTL, fetchTTL, opts...)
 	}
 }
 
@@ -64,6 +70,19 @@ func WithSelector[Req, Resp any](selector Selector) ManagerOption[Req, Resp] {
 	}
 }
 
+// WithHedging enables hedged fetching. The first provider starts immediately
+// and another one is started every delay while no result has arrived, with at
+// most maxParallel in flight. A zero delay races the top maxParallel
+// providers at once. A failed attempt immediately starts the next provider.
+func WithHedging[Req, Resp any](maxParallel int, delay time.Duration) ManagerOption[Req, Resp] {
+	return func(m *Manager[Req, Resp]) {
+		if maxParallel < 1 {
+			maxParallel = 1
+		}
+		m.hedge = &hedgeConfig{maxParallel: maxParallel, delay: delay}
+	}
+}
+
 func WithProvider[Req, Resp any](p Provider[Req, Resp], opts ...ProviderOption) ManagerOption[Req, Resp] {
 	return func(m *Manager[Req, Resp]) {
 		m.Register(p, opts...)
@@ -108,6 +127,9 @@ func (m *Manager[Req, Resp]) Register(p Provider[Req, Resp], opts ...ProviderOpt
 	m.providerInfo[p.Name()] = info
 }
 
+// Fetch returns the data for req from the cache or from the first provider
+// that serves it. Concurrent identical fetches are coalesced into one, and
+// their results share Data: callers must treat it as read-only.
 func (m *Manager[Req, Resp]) Fetch(ctx context.Context, req Req) (*FetchResult[Resp], error) {
 	startTime := time.Now()
 
@@ -117,7 +139,7 @@ func (m *Manager[Req, Resp]) Fetch(ctx context.Context, req Req) (*FetchResult[R
 			var result FetchResult[Resp]
 			if err := json.Unmarshal(data, &result); err == nil {
 				result.Cached = true
-				m.metrics.RecordFetch(Metric{
+				m.recordFetch(Metric{
 					Provider: "cache",
 					Duration: time.Since(startTime),
 					Success:  true,
@@ -128,11 +150,33 @@ func (m *Manager[Req, Resp]) Fetch(ctx context.Context, req Req) (*FetchResult[R
 		}
 	}
 
-	providerNames := m.getOrderedProviders()
-	if len(providerNames) == 0 {
-		return nil, ErrNoProvider
+	providerNames, err := m.routeProviders(req)
+	if err != nil {
+		return nil, err
 	}
 
+	// Concurrent identical fetches share one call; the callers that joined
+	// it get their own copy of the result marked as coalesced. The call is
+	// detached from the callers, so one caller cancelling does not fail the
+	// others.
+	result, err, coalesced := m.flight.Do(ctx, BuildCacheKey(req), func(ctx context.Context) (*FetchResult[Resp], error) {
+		if m.hedge != nil {
+			return m.fetchHedged(ctx, req, providerNames)
+		}
+		return m.fetchSequential(ctx, req, providerNames)
+	})
+	if coalesced && result != nil {
+		cp := *result
+		if cp.Trace != nil {
+			cp.Trace = cp.Trace.coalescedCopy()
+		}
+		return &cp, err
+	}
+	return result, err
+}
+
+// fetchSequential tries the providers one after another until one succeeds.
+func (m *Manager[Req, Resp]) fetchSequential(ctx context.Context, req Req, providerNames []string) (*FetchResult[Resp], error) {
 	var lastErr error
 	for _, name := range providerNames {
 		m.mu.RLock()
@@ -143,15 +187,12 @@ func (m *Manager[Req, Resp]) Fetch(ctx context.Context, req Req) (*FetchResult[R
 			continue
 		}
 
+		attemptStart := time.Now()
 		resp, trace, err := provider.Fetch(ctx, m.client, req)
+		m.recordRequests(name, trace)
 		if err != nil {
 			lastErr = err
-			m.metrics.RecordFetch(Metric{
-				Provider:  name,
-				Duration:  time.Since(startTime),
-				Success:   false,
-				ErrorType: "fetch_error",
-			})
+			m.recordFetch(failedFetch(name, time.Since(attemptStart), err))
 			continue
 		}
 
@@ -162,16 +203,11 @@ func (m *Manager[Req, Resp]) Fetch(ctx context.Context, req Req) (*FetchResult[R
 			Cached:   false,
 		}
 
-		if m.cache != nil {
-			cacheKey := BuildCacheKey(req)
-			if data, merr := json.Marshal(result); merr == nil {
-				m.cache.SetFetch(cacheKey, data)
-			}
-		}
+		m.storeResult(ctx, req, result)
 
-		m.metrics.RecordFetch(Metric{
+		m.recordFetch(Metric{
 			Provider: name,
-			Duration: time.Since(startTime),
+			Duration: time.Since(attemptStart),
 			Success:  true,
 		})
 
@@ -181,6 +217,63 @@ func (m *Manager[Req, Resp]) Fetch(ctx context.Context, req Req) (*FetchResult[R
 	return nil, errors.Join(ErrAllProviderFailed, lastErr)
 }
 
+// recordFetch reports a fetch to the metrics collector and to a selector
+// that learns from the manager's fetches.
+func (m *Manager[Req, Resp]) recordFetch(metric Metric) {
+	m.metrics.RecordFetch(metric)
+	if o, ok := m.selector.(FetchObserver); ok {
+		o.ObserveFetch(metric)
+	}
+}
+
+// failedFetch returns the metric of a fetch from provider that failed with err.
+func failedFetch(provider string, d time.Duration, err error) Metric {
+	return Metric{
+		Provider:        provider,
+		Duration:        d,
+		Success:         false,
+		ErrorType:       errorType(err),
+		RequestSpecific: request.IsRequestSpecific(err),
+	}
+}
+
+// recordRequests reports the HTTP requests a provider made during a fetch,
+// including the rate limit budget their hosts reported.
+func (m *Manager[Req, Resp]) recordRequests(provider string, trace *RequestTrace) {
+	if trace == nil {
+		return
+	}
+	for _, r := range trace.Requests {
+		metric := Metric{
+			Provider:  provider,
+			Duration:  r.Duration,
+			Success:   r.IsSuccess(),
+			RateLimit: r.RateLimit,
+		}
+		if r.Error != nil {
+			metric.ErrorType = string(r.Error.Type)
+		}
+		if r.RateLimit != nil {
+			metric.Host = r.RateLimit.Host
+		}
+		m.metrics.RecordRequest(metric)
+	}
+}
+
+func (m *Manager[Req, Resp]) storeResult(ctx context.Context, req Req, result *FetchResult[Resp]) {
+	if m.cache == nil {
+		return
+	}
+	var ttl time.Duration
+	if m.ttlPolicy != nil {
+		ttl = m.ttlPolicy.TTL(ctx, req, time.Now())
+	}
+	cacheKey := BuildCacheKey(req)
+	if data, err := json.Marshal(result); err == nil {
+		m.cache.SetFetchTTL(cacheKey, data, ttl)
+	}
+}
+
 func (m *Manager[Req, Resp]) FetchFrom(ctx context.Context, providerName string, req Req) (*FetchResult[Resp], error) {
 	m.mu.RLock()
 	provider, ok := m.providers[providerName]
@@ -192,17 +285,13 @@ func (m *Manager[Req, Resp]) FetchFrom(ctx context.Context, providerName string,
 
 	startTime := time.Now()
 	resp, trace, err := provider.Fetch(ctx, m.client, req)
+	m.recordRequests(providerName, trace)
 	if err != nil {
-		m.metrics.RecordFetch(Metric{
-			Provider:  providerName,
-			Duration:  time.Since(startTime),
-			Success:   false,
-			ErrorType: "fetch_error",
-		})
+		m.recordFetch(failedFetch(providerName, time.Since(startTime), err))
 		return nil, err
 	}
 
-	m.metrics.RecordFetch(Metric{
+	m.recordFetch(Metric{
 		Provider: providerName,
 		Duration: time.Since(startTime),
 		Success:  true,
@@ -216,16 +305,81 @@ func (m *Manager[Req, Resp]) FetchFrom(ctx context.Context, providerName string,
 	}, nil
 }
 
-func (m *Manager[Req, Resp]) getOrderedProviders() []string {
+// FetchAll queries every provider able to serve req concurrently and returns
+// their successful results in selection order. It bypasses the fetch cache and
+// only fails when no provider succeeds.
+func (m *Manager[Req, Resp]) FetchAll(ctx context.Context, req Req) ([]*FetchResult[Resp], error) {
+	providerNames, err := m.routeProviders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*FetchResult[Resp], len(providerNames))
+	errs := make([]error, len(providerNames))
+	var wg sync.WaitGroup
+	for i, name := range providerNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = m.FetchFrom(ctx, name, req)
+		}()
+	}
+	wg.Wait()
+
+	succeeded := make([]*FetchResult[Resp], 0, len(results))
+	for _, r := range results {
+		if r != nil {
+			succeeded = append(succeeded, r)
+		}
+	}
+	if len(succeeded) == 0 {
+		return nil, errors.Join(append([]error{ErrAllProviderFailed}, errs...)...)
+	}
+	return succeeded, nil
+}
+
+// Route returns the providers able to serve req in selection order, e.g. to
+// fetch from each of them with FetchFrom. It fails like Fetch when no
+// provider is able to.
+func (m *Manager[Req, Resp]) Route(req Req) ([]string, error) {
+	return m.routeProviders(req)
+}
+
+// routeProviders returns the providers able to serve req in selection order.
+// Providers rejected by SupportedMarkets or CanHandle are reported in a
+// NoProviderError when no candidate remains.
+func (m *Manager[Req, Resp]) routeProviders(req Req) ([]string, error) {
+	var symbols []string
+	if r, ok := any(req).(SymbolRequest); ok {
+		symbols = r.RequestSymbols()
+	}
+	market := requestMarket(req)
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	if len(m.providerInfo) == 0 {
+		return nil, ErrNoProvider
+	}
+
 	providers := make([]ProviderInfo, 0, len(m.providerInfo))
-	for _, info := range m.providerInfo {
+	var skipped []SkippedProvider
+	for name, info := range m.providerInfo {
+		if reason := checkProvider(m.providers[name], market, symbols); reason != "" {
+			skipped = append(skipped, SkippedProvider{Name: name, Reason: reason})
+			continue
+		}
 		providers = append(providers, info)
 	}
 
-	return m.selector.Select(providers)
+	if len(providers) == 0 {
+		sort.Slice(skipped, func(i, j int) bool {
+			return skipped[i].Name < skipped[j].Name
+		})
+		return nil, &NoProviderError{Skipped: skipped}
+	}
+
+	return m.selector.Select(providers), nil
 }
 
 func (m *Manager[Req, Resp]) Providers() []string {
@@ -247,4 +401,7 @@ func (m *Manager[Req, Resp]) Close() {
 	if m.client != nil {
 		m.client.Close()
 	}
+	if m.cache != nil {
+		m.cache.Close()
+	}
 }
@@ -2,15 +2,29 @@ package manager
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/kline"
 	"github.com/souloss/quantds/request"
 )
 
 type testReq struct {
 	Symbol string
+	Market domain.Market
+}
+
+func (r testReq) RequestSymbols() []string {
+	return []string{r.Symbol}
+}
+
+func (r testReq) RequestMarket() domain.Market {
+	return r.Market
 }
 
 type testResp struct {
@@ -18,9 +32,12 @@ type testResp struct {
 }
 
 type testProvider struct {
-	name string
-	data string
-	err  error
+	name      string
+	data      string
+	err       error
+	markets   []domain.Market
+	canHandle func(symbol string) bool
+	calls     int
 }
 
 func (p *testProvider) Name() string {
@@ -28,14 +45,21 @@ func (p *testProvider) Name() string {
 }
 
 func (p *testProvider) SupportedMarkets() []domain.Market {
+	if p.markets != nil {
+		return p.markets
+	}
 	return []domain.Market{domain.MarketCN}
 }
 
 func (p *testProvider) CanHandle(symbol string) bool {
+	if p.canHandle != nil {
+		return p.canHandle(symbol)
+	}
 	return true
 }
 
 func (p *testProvider) Fetch(ctx context.Context, client request.Client, req testReq) (testResp, *RequestTrace, error) {
+	p.calls++
 	return testResp{Data: p.data}, NewRequestTrace(p.name), p.err
 }
 
@@ -110,6 +134,70 @@ func TestManager_Fetch_NoProvider(t *testing.T) {
 	}
 }
 
+func TestManager_Fetch_Routing(t *testing.T) {
+	cnOnly := &testProvider{name: "cn", data: "cn"}
+	crypto := &testProvider{name: "crypto", data: "crypto", markets: []domain.Market{domain.MarketCrypto}}
+	picky := &testProvider{
+		name:      "picky",
+		data:      "picky",
+		markets:   []domain.Market{domain.MarketCN},
+		canHandle: func(symbol string) bool { return symbol == "600519.SH" },
+	}
+
+	m := NewManager[testReq, testResp](
+		WithProvider[testReq, testResp](picky, WithPriority(10)),
+		WithProvider[testReq, testResp](crypto, WithPriority(5)),
+		WithProvider[testReq, testResp](cnOnly, WithPriority(1)),
+	)
+	defer m.Close()
+
+	result, err := m.Fetch(context.Background(), testReq{Symbol: "000001.SZ", Market: domain.MarketCN})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Provider != "cn" {
+		t.Errorf("Provider = %v, want cn", result.Provider)
+	}
+	if picky.calls != 0 || crypto.calls != 0 {
+		t.Errorf("skipped providers were called: picky=%d crypto=%d", picky.calls, crypto.calls)
+	}
+
+	result, err = m.Fetch(context.Background(), testReq{Symbol: "600519.SH"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Provider != "picky" {
+		t.Errorf("Provider = %v, want picky", result.Provider)
+	}
+}
+
+func TestManager_Fetch_NoProviderSkipped(t *testing.T) {
+	m := NewManager[testReq, testResp](
+		WithProvider[testReq, testResp](&testProvider{name: "p1", canHandle: func(string) bool { return false }}),
+		WithProvider[testReq, testResp](&testProvider{name: "p2", markets: []domain.Market{domain.MarketUS}}),
+	)
+	defer m.Close()
+
+	_, err := m.Fetch(context.Background(), testReq{Symbol: "000001.SZ", Market: domain.MarketCN})
+	if !errors.Is(err, ErrNoProvider) {
+		t.Fatalf("Fetch() error = %v, want %v", err, ErrNoProvider)
+	}
+
+	var npErr *NoProviderError
+	if !errors.As(err, &npErr) {
+		t.Fatalf("Fetch() error = %T, want *NoProviderError", err)
+	}
+	if len(npErr.Skipped) != 2 {
+		t.Fatalf("Skipped = %v, want 2 entries", npErr.Skipped)
+	}
+	if npErr.Skipped[0].Name != "p1" || npErr.Skipped[0].Reason != "cannot handle symbol 000001.SZ" {
+		t.Errorf("Skipped[0] = %+v", npErr.Skipped[0])
+	}
+	if npErr.Skipped[1].Name != "p2" || npErr.Skipped[1].Reason != "market CN not supported" {
+		t.Errorf("Skipped[1] = %+v", npErr.Skipped[1])
+	}
+}
+
 func TestManager_FetchFrom(t *testing.T) {
 	m := NewManager[testReq, testResp](
 		WithProvider[testReq, testResp](&testProvider{name: "p1", data: "data1"}, WithPriority(10)),
@@ -126,6 +214,143 @@ func TestManager_FetchFrom(t *testing.T) {
 	}
 }
 
+func TestManager_FetchAll(t *testing.T) {
+	m := NewManager[testReq, testResp](
+		WithProvider[testReq, testResp](&testProvider{name: "p1", data: "data1"}, WithPriority(10)),
+		WithProvider[testReq, testResp](&testProvider{name: "p2", err: errors.New("boom")}, WithPriority(8)),
+		WithProvider[testReq, testResp](&testProvider{name: "p3", data: "data3"}, WithPriority(5)),
+	)
+	defer m.Close()
+
+	results, err := m.FetchAll(context.Background(), testReq{Symbol: "test"})
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Provider != "p1" || results[1].Provider != "p3" {
+		t.Fatalf("FetchAll() providers = %v, want [p1 p3]", results)
+	}
+
+	failing := NewManager[testReq, testResp](
+		WithProvider[testReq, testResp](&testProvider{name: "p1", err: errors.New("boom")}),
+	)
+	defer failing.Close()
+	if _, err := failing.FetchAll(context.Background(), testReq{Symbol: "test"}); !errors.Is(err, ErrAllProviderFailed) {
+		t.Errorf("FetchAll() error = %v, want ErrAllProviderFailed", err)
+	}
+}
+
+func TestManager_Route(t *testing.T) {
+	m := NewManager[testReq, testResp](
+		WithProvider[testReq, testResp](&testProvider{name: "p1"}, WithPriority(5)),
+		WithProvider[testReq, testResp](&testProvider{name: "p2"}, WithPriority(10)),
+	)
+	defer m.Close()
+
+	names, err := m.Route(testReq{Symbol: "test"})
+	if err != nil {
+		t.Fatalf("Route() error = %v", err)
+	}
+	if len(names) != 2 || names[0] != "p2" || names[1] != "p1" {
+		t.Errorf("Route() = %v, want [p2 p1]", names)
+	}
+
+	if _, err := NewManager[testReq, testResp]().Route(testReq{Symbol: "test"}); !errors.Is(err, ErrNoProvider) {
+		t.Errorf("Route() error = %v, want ErrNoProvider", err)
+	}
+}
+
+func TestManager_Fetch_Coalesced(t *testing.T) {
+	p := &delayProvider{name: "slow", delay: 50 * time.Millisecond}
+	m := NewManager(
+		WithProvider[testReq, testResp](p),
+	)
+	defer m.Close()
+
+	const callers = 10
+	results := make([]*FetchResult[testResp], callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := m.Fetch(context.Background(), testReq{Symbol: "test"})
+			if err != nil {
+				t.Errorf("Fetch() error = %v", err)
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	leaders, ids := 0, make(map[string]bool)
+	for _, r := range results {
+		if r == nil || r.Data.Data != "slow" {
+			t.Fatalf("Fetch() result = %+v", r)
+		}
+		ids[r.Trace.FetchID] = true
+		if !r.Trace.Coalesced {
+			leaders++
+			continue
+		}
+		for _, rec := range r.Trace.Requests {
+			if rec.Tags[request.TagCoalesced] != "true" {
+				t.Error("coalesced trace record not tagged")
+			}
+		}
+	}
+	if leaders != 1 {
+		t.Errorf("uncoalesced results = %d, want 1", leaders)
+	}
+	if len(ids) != callers {
+		t.Errorf("distinct trace IDs = %d, want %d", len(ids), callers)
+	}
+}
+
+func TestManager_Fetch_CoalescedCancel(t *testing.T) {
+	p := &delayProvider{name: "slow", delay: 100 * time.Millisecond}
+	m := NewManager(
+		WithProvider[testReq, testResp](p),
+	)
+	defer m.Close()
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := m.Fetch(first, testReq{Symbol: "test"})
+		firstErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	joined := make(chan error, 1)
+	go func() {
+		result, err := m.Fetch(context.Background(), testReq{Symbol: "test"})
+		if err == nil && (!result.Trace.Coalesced || result.Data.Data != "slow") {
+			err = fmt.Errorf("result = %+v, want the coalesced result", result)
+		}
+		joined <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Fetch() error = %v, want %v", err, context.Canceled)
+	}
+	if err := <-joined; err != nil {
+		t.Errorf("joined Fetch() error = %v, want the first caller's cancellation ignored", err)
+	}
+
+	// a waiter whose own context ends stops waiting for the shared call
+	expired, cancelExpired := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelExpired()
+	start := time.Now()
+	if _, err := m.Fetch(expired, testReq{Symbol: "other"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fetch() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 80*time.Millisecond {
+		t.Errorf("Fetch() returned after %v, want it to stop at its own deadline", elapsed)
+	}
+}
+
 func TestManager_Cache(t *testing.T) {
 	m := NewManager[testReq, testResp](
 		WithTwoLevelCache[testReq, testResp](time.Minute, time.Minute),
@@ -150,6 +375,205 @@ func TestManager_Cache(t *testing.T) {
 	}
 }
 
+type ttlFunc func(ctx context.Context, req any, now time.Time) time.Duration
+
+func (f ttlFunc) TTL(ctx context.Context, req any, now time.Time) time.Duration {
+	return f(ctx, req, now)
+}
+
+func TestManager_CacheTTLPolicy(t *testing.T) {
+	p := &testProvider{name: "p1", data: "data1"}
+	m := NewManager[testReq, testResp](
+		WithTwoLevelCache[testReq, testResp](time.Minute, time.Minute),
+		WithCacheTTL[testReq, testResp](ttlFunc(func(_ context.Context, req any, _ time.Time) time.Duration {
+			if req.(testReq).Symbol == "short" {
+				return time.Millisecond
+			}
+			return 0
+		})),
+		WithProvider[testReq, testResp](p),
+	)
+	defer m.Close()
+
+	ctx := context.Background()
+	for _, symbol := range []string{"short", "default"} {
+		if _, err := m.Fetch(ctx, testReq{Symbol: symbol}); err != nil {
+			t.Fatalf("Fetch(%s) error = %v", symbol, err)
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	short, _ := m.Fetch(ctx, testReq{Symbol: "short"})
+	def, _ := m.Fetch(ctx, testReq{Symbol: "default"})
+	if short.Cached || !def.Cached {
+		t.Errorf("cached = %v, %v, want the short TTL expired and the default kept", short.Cached, def.Cached)
+	}
+}
+
+func TestMarketHoursTTL(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	policy := NewMarketHoursTTL(nil, 10*time.Second, time.Hour)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  any
+		now  time.Time
+		want time.Duration
+	}{
+		{"open", testReq{Symbol: "600519.SH"}, time.Date(2025, time.March, 3, 10, 0, 0, 0, shanghai), 10 * time.Second},
+		{"closed", testReq{Symbol: "600519.SH"}, time.Date(2025, time.March, 3, 20, 0, 0, 0, shanghai), time.Hour},
+		{"before the open", testReq{Symbol: "600519.SH"}, time.Date(2025, time.March, 3, 9, 20, 0, 0, shanghai), 10 * time.Minute},
+		{"before the close", testReq{Symbol: "600519.SH"}, time.Date(2025, time.March, 3, 14, 59, 55, 0, shanghai), 5 * time.Second},
+		{"market request", testReq{Market: domain.MarketCN}, time.Date(2025, time.March, 1, 10, 0, 0, 0, shanghai), time.Hour},
+		{"crypto", testReq{Symbol: "BTCUSDT"}, time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC), 10 * time.Second},
+		{"unknown", testResp{}, time.Date(2025, time.March, 3, 10, 0, 0, 0, shanghai), 0},
+		{"settled daily", kline.Request{Symbol: "600519.SH", Timeframe: kline.Timeframe1d, EndTime: time.Date(2025, time.February, 28, 0, 0, 0, 0, shanghai)},
+			time.Date(2025, time.March, 3, 10, 0, 0, 0, shanghai), time.Hour},
+		{"settled before the open", kline.Request{Symbol: "600519.SH", Timeframe: kline.Timeframe1w, EndTime: time.Date(2025, time.February, 28, 0, 0, 0, 0, shanghai)},
+			time.Date(2025, time.March, 3, 9, 20, 0, 0, shanghai), time.Hour},
+		{"daily up to now", kline.Request{Symbol: "600519.SH", Timeframe: kline.Timeframe1d},
+			time.Date(2025, time.March, 3, 10, 0, 0, 0, shanghai), 10 * time.Second},
+		{"daily ending today", kline.Request{Symbol: "600519.SH", Timeframe: kline.Timeframe1d, EndTime: time.Date(2025, time.March, 3, 0, 0, 0, 0, shanghai)},
+			time.Date(2025, time.March, 3, 10, 0, 0, 0, shanghai), 10 * time.Second},
+		{"past intraday", kline.Request{Symbol: "600519.SH", Timeframe: kline.Timeframe5m, EndTime: time.Date(2025, time.February, 28, 0, 0, 0, 0, shanghai)},
+			time.Date(2025, time.March, 3, 10, 0, 0, 0, shanghai), 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := policy.TTL(ctx, tt.req, tt.now); got != tt.want {
+			t.Errorf("%s: TTL() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// delayProvider answers after delay unless its context is cancelled first.
+type delayProvider struct {
+	name    string
+	delay   time.Duration
+	err     error
+	mu      sync.Mutex
+	started time.Time
+}
+
+func (p *delayProvider) Name() string                      { return p.name }
+func (p *delayProvider) SupportedMarkets() []domain.Market { return []domain.Market{domain.MarketCN} }
+func (p *delayProvider) CanHandle(symbol string) bool      { return true }
+
+func (p *delayProvider) Fetch(ctx context.Context, client request.Client, req testReq) (testResp, *RequestTrace, error) {
+	p.mu.Lock()
+	p.started = time.Now()
+	p.mu.Unlock()
+
+	trace := NewRequestTrace(p.name)
+	record := &request.Record{ID: p.name}
+	trace.AddRequest(record)
+
+	select {
+	case <-time.After(p.delay):
+		return testResp{Data: p.name}, trace, p.err
+	case <-ctx.Done():
+		record.Error = request.ClassifyError(ctx.Err(), 0)
+		return testResp{}, trace, ctx.Err()
+	}
+}
+
+func (p *delayProvider) startedAt() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.started
+}
+
+func TestManager_Fetch_HedgedRace(t *testing.T) {
+	slow := &delayProvider{name: "slow", delay: time.Second}
+	fast := &delayProvider{name: "fast", delay: 10 * time.Millisecond}
+	m := NewManager(
+		WithHedging[testReq, testResp](2, 0),
+		WithProvider[testReq, testResp](slow, WithPriority(10)),
+		WithProvider[testReq, testResp](fast, WithPriority(5)),
+	)
+	defer m.Close()
+
+	start := time.Now()
+	result, err := m.Fetch(context.Background(), testReq{Symbol: "test"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Fetch() took %v, slow provider was not cancelled", elapsed)
+	}
+	if result.Provider != "fast" || result.Data.Data != "fast" {
+		t.Errorf("Fetch() winner = %s, want fast", result.Provider)
+	}
+
+	if got := result.Trace.TotalRequests(); got != 2 {
+		t.Fatalf("Trace.TotalRequests() = %d, want 2", got)
+	}
+	var cancelled bool
+	for _, r := range result.Trace.Requests {
+		if r.ID == "slow" {
+			cancelled = r.Tags[TagHedge] == HedgeCancelled && r.Tags[TagHedgeProvider] == "slow"
+		}
+	}
+	if !cancelled {
+		t.Error("slow attempt not tagged as cancelled in trace")
+	}
+}
+
+func TestManager_Fetch_HedgedDelay(t *testing.T) {
+	primary := &delayProvider{name: "primary", delay: 300 * time.Millisecond}
+	backup := &delayProvider{name: "backup", delay: 10 * time.Millisecond}
+	m := NewManager(
+		WithHedging[testReq, testResp](2, 50*time.Millisecond),
+		WithProvider[testReq, testResp](primary, WithPriority(10)),
+		WithProvider[testReq, testResp](backup, WithPriority(5)),
+	)
+	defer m.Close()
+
+	result, err := m.Fetch(context.Background(), testReq{Symbol: "test"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Provider != "backup" {
+		t.Errorf("Fetch() winner = %s, want backup", result.Provider)
+	}
+	if gap := backup.startedAt().Sub(primary.startedAt()); gap < 40*time.Millisecond {
+		t.Errorf("backup started %v after primary, want >= hedge delay", gap)
+	}
+}
+
+func TestManager_Fetch_HedgedFailure(t *testing.T) {
+	broken := &delayProvider{name: "broken", delay: time.Millisecond, err: errors.New("boom")}
+	backup := &delayProvider{name: "backup", delay: time.Millisecond}
+	m := NewManager(
+		WithHedging[testReq, testResp](1, time.Second),
+		WithProvider[testReq, testResp](broken, WithPriority(10)),
+		WithProvider[testReq, testResp](backup, WithPriority(5)),
+	)
+	defer m.Close()
+
+	start := time.Now()
+	result, err := m.Fetch(context.Background(), testReq{Symbol: "test"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("failed attempt did not start the next provider immediately")
+	}
+	if result.Provider != "backup" {
+		t.Errorf("Fetch() winner = %s, want backup", result.Provider)
+	}
+	for _, r := range result.Trace.Requests {
+		if r.ID == "broken" && r.Tags[TagHedge] != HedgeFailed {
+			t.Errorf("broken attempt tag = %q, want %q", r.Tags[TagHedge], HedgeFailed)
+		}
+	}
+
+	backup.err = errors.New("boom")
+	if _, err := m.Fetch(context.Background(), testReq{Symbol: "test"}); !errors.Is(err, ErrAllProviderFailed) {
+		t.Errorf("Fetch() error = %v, want ErrAllProviderFailed", err)
+	}
+}
+
 func TestPrioritySelector(t *testing.T) {
 	selector := NewPrioritySelector()
 
@@ -176,6 +600,166 @@ func TestPrioritySelector(t *testing.T) {
 	}
 }
 
+func TestWeightedSelector(t *testing.T) {
+	selector := NewWeightedSelector()
+
+	providers := []ProviderInfo{
+		{Name: "heavy", Weight: 3},
+		{Name: "light", Weight: 1},
+		{Name: "off", Weight: 0},
+	}
+
+	first := make(map[string]int)
+	for i := 0; i < 4000; i++ {
+		names := selector.Select(providers)
+		if len(names) != 3 {
+			t.Fatalf("Select() returned %d names, want 3", len(names))
+		}
+		if names[2] != "off" {
+			t.Fatalf("names[2] = %v, want off", names[2])
+		}
+		first[names[0]]++
+	}
+
+	// heavy should lead about 75% of the time
+	if first["heavy"] < 2700 || first["heavy"] > 3300 {
+		t.Errorf("heavy first %d/4000 times, want about 3000", first["heavy"])
+	}
+	if first["light"] == 0 {
+		t.Error("light was never selected first")
+	}
+}
+
+func TestHealthSelector(t *testing.T) {
+	collector := NewMemoryCollector()
+	now := time.Now()
+	selector := NewHealthSelector(collector, WithFailureThreshold(2), WithCooldown(time.Minute))
+	selector.now = func() time.Time { return now }
+
+	providers := []ProviderInfo{
+		{Name: "primary", Priority: 10},
+		{Name: "backup", Priority: 5},
+	}
+
+	if names := selector.Select(providers); names[0] != "primary" {
+		t.Fatalf("names = %v, want primary first", names)
+	}
+
+	// One failure is tolerated
+	collector.RecordFetch(Metric{Provider: "primary", Success: false})
+	if names := selector.Select(providers); names[0] != "primary" {
+		t.Errorf("after 1 failure names = %v, want primary first", names)
+	}
+
+	// Threshold reached: primary is demoted
+	collector.RecordFetch(Metric{Provider: "primary", Success: false})
+	if names := selector.Select(providers); names[0] != "backup" || names[1] != "primary" {
+		t.Errorf("after 2 failures names = %v, want [backup primary]", names)
+	}
+
+	// Cooldown expired: primary is probed again
+	now = now.Add(2 * time.Minute)
+	if names := selector.Select(providers); names[0] != "primary" {
+		t.Errorf("after cooldown names = %v, want primary first", names)
+	}
+
+	// Failed probe restarts the cooldown
+	collector.RecordFetch(Metric{Provider: "primary", Success: false})
+	if names := selector.Select(providers); names[0] != "backup" {
+		t.Errorf("after failed probe names = %v, want backup first", names)
+	}
+
+	// Successful probe restores it
+	now = now.Add(2 * time.Minute)
+	collector.RecordFetch(Metric{Provider: "primary", Success: true})
+	if names := selector.Select(providers); names[0] != "primary" {
+		t.Errorf("after successful probe names = %v, want primary first", names)
+	}
+}
+
+func TestHealthSelector_PerManager(t *testing.T) {
+	shared := NewNoopCollector()
+	failing := &testProvider{name: "primary", err: errors.New("down")}
+	newManager := func(primary Provider[testReq, testResp]) *Manager[testReq, testResp] {
+		return NewManager(
+			WithMetrics[testReq, testResp](shared),
+			WithSelector[testReq, testResp](NewHealthSelector(nil, WithFailureThreshold(1), WithCooldown(time.Minute))),
+			WithProvider[testReq, testResp](primary, WithPriority(10)),
+			WithProvider[testReq, testResp](&testProvider{name: "backup", data: "backup"}, WithPriority(5)),
+		)
+	}
+	a := newManager(failing)
+	defer a.Close()
+	b := newManager(&testProvider{name: "primary", data: "primary"})
+	defer b.Close()
+
+	if _, err := a.Fetch(context.Background(), testReq{Symbol: "a"}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	failing.calls = 0
+	if _, err := a.Fetch(context.Background(), testReq{Symbol: "b"}); err != nil || failing.calls != 0 {
+		t.Errorf("Fetch() error = %v, primary calls = %d, want primary demoted despite a noop collector", err, failing.calls)
+	}
+	if result, err := b.Fetch(context.Background(), testReq{Symbol: "a"}); err != nil || result.Provider != "primary" {
+		t.Errorf("other manager Fetch() = %+v, %v, want primary unaffected", result, err)
+	}
+}
+
+func TestHealthSelector_RequestSpecific(t *testing.T) {
+	now := time.Now()
+	selector := NewHealthSelector(nil, WithFailureThreshold(3), WithCooldown(time.Minute))
+	selector.now = func() time.Time { return now }
+	primary := &testProvider{name: "primary", err: fmt.Errorf("wrapped: %w", request.ErrInvalidSymbol)}
+	m := NewManager(
+		WithSelector[testReq, testResp](selector),
+		WithProvider[testReq, testResp](primary, WithPriority(10)),
+		WithProvider[testReq, testResp](&testProvider{name: "backup", data: "backup"}, WithPriority(5)),
+	)
+	defer m.Close()
+
+	for i := range 5 {
+		if _, err := m.Fetch(context.Background(), testReq{Symbol: fmt.Sprint(i)}); err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+	}
+	if primary.calls != 5 {
+		t.Errorf("primary calls = %d, want 5: invalid symbols must not demote it", primary.calls)
+	}
+	if h := selector.health["primary"]; h == nil || h.consecutiveFailures != 0 || !h.downUntil.IsZero() {
+		t.Errorf("primary health = %+v, want no failures and no cooldown", h)
+	}
+}
+
+func TestHealthSelector_SkipAndSlow(t *testing.T) {
+	collector := NewMemoryCollector()
+	selector := NewHealthSelector(collector,
+		WithFailureThreshold(1),
+		WithSkipUnhealthy(true),
+		WithSlowThreshold(time.Second),
+	)
+
+	providers := []ProviderInfo{
+		{Name: "down", Priority: 10},
+		{Name: "slow", Priority: 5},
+		{Name: "fast", Priority: 1},
+	}
+
+	collector.RecordFetch(Metric{Provider: "down", Success: false})
+	collector.RecordFetch(Metric{Provider: "slow", Success: true, Duration: 5 * time.Second})
+	collector.RecordFetch(Metric{Provider: "fast", Success: true, Duration: 10 * time.Millisecond})
+
+	names := selector.Select(providers)
+	if len(names) != 2 || names[0] != "fast" || names[1] != "slow" {
+		t.Errorf("names = %v, want [fast slow]", names)
+	}
+
+	// Unhealthy providers are still returned when nothing else is left
+	names = selector.Select(providers[:1])
+	if len(names) != 1 || names[0] != "down" {
+		t.Errorf("names = %v, want [down]", names)
+	}
+}
+
 func TestMemoryCache(t *testing.T) {
 	cache := NewMemoryCache()
 
@@ -222,6 +806,164 @@ func TestMemoryCache_Expiration(t *testing.T) {
 	}
 }
 
+func TestMemoryCache_Cleanup(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("old", []byte("value"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	cache.lastCleanup = time.Now().Add(-memoryCleanupInterval)
+	cache.Set("new", []byte("value"), time.Minute)
+
+	if _, ok := cache.data["old"]; ok {
+		t.Error("expired entry was not swept by Set()")
+	}
+	if _, ok := cache.Get("new"); !ok {
+		t.Error("Get() returned not ok for live entry")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, WithMaxEntries(2), WithJanitorInterval(0))
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	defer cache.Close()
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+	if data, ok := cache.Get("a"); !ok || string(data) != "1" {
+		t.Fatalf("Get(a) = %q, %v", data, ok)
+	}
+
+	// b is least recently used and is evicted by c
+	cache.Set("c", []byte("3"), time.Minute)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) should miss after LRU eviction")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+
+	cache.Set("short", []byte("x"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	cache.cleanup(time.Now())
+	if cache.Len() != 1 {
+		t.Errorf("Len() after cleanup = %d, want 1", cache.Len())
+	}
+
+	reopened, err := NewDiskCache(dir, WithJanitorInterval(0))
+	if err != nil {
+		t.Fatalf("NewDiskCache() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	if data, ok := reopened.Get("c"); !ok || string(data) != "3" {
+		t.Errorf("Get(c) after reopen = %q, %v", data, ok)
+	}
+
+	reopened.Clear()
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Clear() left %d files", len(files))
+	}
+}
+
+func TestDiskCache_MaxBytes(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), WithMaxBytes(100), WithJanitorInterval(0))
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	defer cache.Close()
+
+	value := make([]byte, 40)
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(key, value, time.Minute)
+	}
+	if cache.Size() > 100 {
+		t.Errorf("Size() = %d, want <= 100", cache.Size())
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Get(a) should miss after eviction")
+	}
+}
+
+func TestDiskCache_Oversized(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), WithMaxBytes(100), WithJanitorInterval(0))
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	defer cache.Close()
+
+	cache.Set("a", []byte("old"), time.Minute)
+	cache.Set("a", make([]byte, 200), time.Minute)
+	if data, ok := cache.Get("a"); ok {
+		t.Errorf("Get(a) = %q, want the stale value dropped by the oversized Set", data)
+	}
+}
+
+func TestDiskCache_Concurrent(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), WithMaxEntries(8), WithJanitorInterval(0))
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	defer cache.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				key := fmt.Sprintf("k%d", (i+j)%12)
+				cache.Set(key, []byte(key), time.Minute)
+				if data, ok := cache.Get(key); ok && string(data) != key {
+					t.Errorf("Get(%s) = %q", key, data)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if cache.Len() > 8 {
+		t.Errorf("Len() = %d, want <= 8", cache.Len())
+	}
+}
+
+func TestManager_CacheBackend(t *testing.T) {
+	backend, err := NewDiskCache(t.TempDir(), WithJanitorInterval(0))
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	defer backend.Close()
+
+	p := &testProvider{name: "p1", data: "data1"}
+	newManager := func() *Manager[testReq, testResp] {
+		return NewManager(
+			WithTwoLevelCache[testReq, testResp](time.Minute, time.Minute, WithFetchCache(backend)),
+			WithProvider[testReq, testResp](p),
+		)
+	}
+
+	m := newManager()
+	if _, err := m.Fetch(context.Background(), testReq{Symbol: "test"}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	m.Close()
+	select {
+	case <-backend.stop:
+		t.Error("Manager.Close() closed the shared backend")
+	default:
+	}
+
+	m = newManager()
+	defer m.Close()
+	result, err := m.Fetch(context.Background(), testReq{Symbol: "test"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !result.Cached || result.Data.Data != "data1" || p.calls != 1 {
+		t.Errorf("Fetch() cached = %v, calls = %d, want cached result from backend", result.Cached, p.calls)
+	}
+}
+
 func TestMemoryCollector(t *testing.T) {
 	collector := NewMemoryCollector()
 
@@ -249,3 +991,66 @@ func TestMemoryCollector(t *testing.T) {
 		t.Errorf("After Reset(), TotalFetches = %v, want 0", stats.TotalFetches)
 	}
 }
+
+type tracedProvider struct {
+	testProvider
+	record *request.Record
+}
+
+func (p *tracedProvider) Fetch(ctx context.Context, client request.Client, req testReq) (testResp, *RequestTrace, error) {
+	trace := NewRequestTrace(p.name)
+	trace.AddRequest(p.record)
+	return testResp{Data: p.data}, trace, nil
+}
+
+func TestManager_Fetch_RecordsRateLimit(t *testing.T) {
+	collector := NewMemoryCollector()
+	rl := &request.RateLimit{Host: "api.example.com", Limit: 100, Remaining: 7, Used: 93}
+	p := &tracedProvider{
+		testProvider: testProvider{name: "p1", data: "data"},
+		record:       &request.Record{Response: request.Response{StatusCode: 200}, RateLimit: rl},
+	}
+	m := NewManager(WithMetrics[testReq, testResp](collector), WithProvider[testReq, testResp](p))
+	defer m.Close()
+
+	if _, err := m.Fetch(context.Background(), testReq{Symbol: "000001"}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if got := collector.GetStats().RateLimits["api.example.com"]; got != *rl {
+		t.Errorf("RateLimits[api.example.com] = %+v, want %+v", got, *rl)
+	}
+
+	collector.Reset()
+	if got := len(collector.GetStats().RateLimits); got != 0 {
+		t.Errorf("After Reset(), len(RateLimits) = %d, want 0", got)
+	}
+}
+
+type fetchRecorder struct {
+	NoopCollector
+	fetches []Metric
+}
+
+func (c *fetchRecorder) RecordFetch(metric Metric) { c.fetches = append(c.fetches, metric) }
+
+func TestManager_Fetch_VendorErrorFallback(t *testing.T) {
+	collector := &fetchRecorder{}
+	noData := request.NewVendorError("p1", request.ErrorTypeNoData, "", "data is null")
+	m := NewManager[testReq, testResp](
+		WithMetrics[testReq, testResp](collector),
+		WithProvider[testReq, testResp](&testProvider{name: "p1", err: noData}, WithPriority(10)),
+		WithProvider[testReq, testResp](&testProvider{name: "p2", data: "data2"}, WithPriority(5)),
+	)
+	defer m.Close()
+
+	result, err := m.Fetch(context.Background(), testReq{Symbol: "test"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.Provider != "p2" {
+		t.Errorf("Provider = %s, want fallback to p2", result.Provider)
+	}
+	if len(collector.fetches) != 2 || collector.fetches[0].ErrorType != string(request.ErrorTypeNoData) {
+		t.Errorf("fetch metrics = %+v, want p1 recorded as %s", collector.fetches, request.ErrorTypeNoData)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/souloss/quantds/request"
 )
 
 type Metric struct {
@@ -12,6 +14,14 @@ type Metric struct {
 	Success   bool
 	CacheHit  bool
 	ErrorType string
+	// RequestSpecific marks a failure caused by the request rather than the
+	// provider, such as an unknown symbol (see request.IsRequestSpecific).
+	RequestSpecific bool
+
+	// Host and RateLimit describe the budget the host of a request reported;
+	// only set for RecordRequest.
+	Host      string
+	RateLimit *request.RateLimit
 }
 
 type Collector interface {
@@ -29,12 +39,15 @@ type Stats struct {
 	AvgLatency     time.Duration
 
 	ByProvider map[string]ProviderMetric
+	// RateLimits holds the latest rate limit budget reported by each host.
+	RateLimits map[string]request.RateLimit
 }
 
 type ProviderMetric struct {
 	Fetches  int64
 	Success  int64
 	Failed   int64
+	Rejected int64 // failures specific to the request, included in Failed
 	Duration int64
 }
 
@@ -47,11 +60,13 @@ type MemoryCollector struct {
 
 	mu         sync.RWMutex
 	byProvider map[string]*ProviderMetric
+	rateLimits map[string]request.RateLimit
 }
 
 func NewMemoryCollector() *MemoryCollector {
 	return &MemoryCollector{
 		byProvider: make(map[string]*ProviderMetric),
+		rateLimits: make(map[string]request.RateLimit),
 	}
 }
 
@@ -85,10 +100,20 @@ func (c *MemoryCollector) RecordFetch(metric Metric) {
 		} else {
 			atomic.AddInt64(&stats.Failed, 1)
 		}
+		if metric.RequestSpecific {
+			atomic.AddInt64(&stats.Rejected, 1)
+		}
 	}
 }
 
+// RecordRequest keeps the latest rate limit budget of the request's host.
 func (c *MemoryCollector) RecordRequest(metric Metric) {
+	if metric.RateLimit == nil || metric.Host == "" {
+		return
+	}
+	c.mu.Lock()
+	c.rateLimits[metric.Host] = *metric.RateLimit
+	c.mu.Unlock()
 }
 
 func (c *MemoryCollector) GetStats() Stats {
@@ -98,6 +123,7 @@ func (c *MemoryCollector) GetStats() Stats {
 		FailedFetches:  atomic.LoadInt64(&c.failedFetches),
 		CacheHits:      atomic.LoadInt64(&c.cacheHits),
 		ByProvider:     make(map[string]ProviderMetric),
+		RateLimits:     make(map[string]request.RateLimit),
 	}
 
 	totalLatency := atomic.LoadInt64(&c.totalLatency)
@@ -111,9 +137,13 @@ func (c *MemoryCollector) GetStats() Stats {
 			Fetches:  atomic.LoadInt64(&ps.Fetches),
 			Success:  atomic.LoadInt64(&ps.Success),
 			Failed:   atomic.LoadInt64(&ps.Failed),
+			Rejected: atomic.LoadInt64(&ps.Rejected),
 			Duration: atomic.LoadInt64(&ps.Duration),
 		}
 	}
+	for host, rl := range c.rateLimits {
+		stats.RateLimits[host] = rl
+	}
 	c.mu.RUnlock()
 
 	return stats
@@ -128,6 +158,7 @@ func (c *MemoryCollector) Reset() {
 
 	c.mu.Lock()
 	c.byProvider = make(map[string]*ProviderMetric)
+	c.rateLimits = make(map[string]request.RateLimit)
 	c.mu.Unlock()
 }
 
@@ -140,7 +171,7 @@ func NewNoopCollector() *NoopCollector {
 func (c *NoopCollector) RecordFetch(metric Metric)   {}
 func (c *NoopCollector) RecordRequest(metric Metric) {}
 func (c *NoopCollector) GetStats() Stats {
-	return Stats{ByProvider: make(map[string]ProviderMetric)}
+	return Stats{ByProvider: make(map[string]ProviderMetric), RateLimits: make(map[string]request.RateLimit)}
 }
 func (c *NoopCollector) Reset() {}
 
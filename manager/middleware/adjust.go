@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/corpaction"
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// FactorFetchFunc fetches the adjustment factors of a symbol.
+type FactorFetchFunc func(ctx context.Context, req kline.FactorRequest) (kline.FactorResponse, *manager.RequestTrace, error)
+
+// CorpActionFetchFunc fetches the corporate actions of a symbol.
+type CorpActionFetchFunc func(ctx context.Context, req corpaction.Request) (corpaction.Response, *manager.RequestTrace, error)
+
+// KlineAdjuster adjusts prices locally instead of relying on each upstream's
+// own adjustment, so that every provider yields the same adjusted series.
+//
+// For adjusted requests it fetches unadjusted bars and applies adjustment
+// factors, or, when no factors are available, factors derived from corporate
+// actions. The response is tagged with the adjustment it received. When
+// neither source is available the request is passed through unchanged and
+// the upstream adjusts, leaving AdjustMethod empty.
+type KlineAdjuster struct {
+	factors  FactorFetchFunc
+	actions  CorpActionFetchFunc
+	supports func(symbol string) bool
+}
+
+// AdjusterOption configures a KlineAdjuster.
+type AdjusterOption func(*KlineAdjuster)
+
+// WithFactorSource sets where adjustment factors come from.
+func WithFactorSource(fetch FactorFetchFunc) AdjusterOption {
+	return func(a *KlineAdjuster) {
+		a.factors = fetch
+	}
+}
+
+// WithCorpActionSource sets where corporate actions come from. They are used
+// when no adjustment factors are available.
+func WithCorpActionSource(fetch CorpActionFetchFunc) AdjusterOption {
+	return func(a *KlineAdjuster) {
+		a.actions = fetch
+	}
+}
+
+// WithSymbolFilter limits local adjustment to the symbols supports accepts,
+// such as those of markets with a factor or corporate action provider. Other
+// symbols are passed through, so they cost no unadjusted fetch.
+func WithSymbolFilter(supports func(symbol string) bool) AdjusterOption {
+	return func(a *KlineAdjuster) {
+		a.supports = supports
+	}
+}
+
+// NewKlineAdjuster creates a KlineAdjuster.
+func NewKlineAdjuster(opts ...AdjusterOption) *KlineAdjuster {
+	a := &KlineAdjuster{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Fetch serves req through fetch, adjusting the bars locally when req asks
+// for an adjustment. The returned trace also holds the records of the factor
+// or corporate action requests. Indexes have no adjustment and are passed
+// through, as are symbols rejected by the symbol filter.
+func (a *KlineAdjuster) Fetch(ctx context.Context, req kline.Request, fetch KlineFetchFunc) (kline.Response, *manager.RequestTrace, error) {
+	if req.Adjust == kline.AdjustNone || (a.factors == nil && a.actions == nil) || isIndex(req.Symbol) {
+		return fetch(ctx, req)
+	}
+	if a.supports != nil && !a.supports(req.Symbol) {
+		return fetch(ctx, req)
+	}
+
+	raw := req
+	raw.Adjust = kline.AdjustNone
+	resp, rawTrace, err := fetch(ctx, raw)
+	if err != nil {
+		return resp, rawTrace, err
+	}
+	// The fetched trace may be shared with other callers, so records are
+	// collected in a new one.
+	trace := manager.NewRequestTrace(resp.Source)
+	if rawTrace != nil {
+		trace.Provider = rawTrace.Provider
+	}
+	mergeTrace(trace, rawTrace)
+	defer trace.Finish()
+	if len(resp.Bars) == 0 {
+		return resp, trace, nil
+	}
+
+	start := req.StartTime
+	if start.IsZero() {
+		start = resp.Bars[0].Timestamp
+	}
+
+	if a.factors != nil {
+		// Forward adjustment needs the factors up to today
+		factors, factorTrace, err := a.factors(ctx, kline.FactorRequest{Symbol: req.Symbol, StartTime: start})
+		mergeTrace(trace, factorTrace)
+		if err == nil && len(factors.Factors) > 0 {
+			return kline.AdjustByFactors(resp, factors.Factors, req.Adjust), trace, nil
+		}
+	}
+
+	if a.actions != nil {
+		actions, actionTrace, err := a.actions(ctx, corpaction.Request{Symbol: req.Symbol, StartTime: start})
+		mergeTrace(trace, actionTrace)
+		if err == nil {
+			return kline.AdjustByActions(resp, actions.Actions, req.Adjust), trace, nil
+		}
+	}
+
+	// No adjustment data: let the upstream adjust
+	adjusted, upstreamTrace, err := fetch(ctx, req)
+	mergeTrace(trace, upstreamTrace)
+	if upstreamTrace != nil {
+		trace.Provider = upstreamTrace.Provider
+	}
+	return adjusted, trace, err
+}
+
+// mergeTrace appends the records of from to trace.
+func mergeTrace(trace, from *manager.RequestTrace) {
+	if from == nil {
+		return
+	}
+	for _, r := range from.Requests {
+		trace.AddRequest(r)
+	}
+}
+
+// LocalAdjust adjusts the bars of the wrapped provider locally with adjuster.
+func LocalAdjust(adjuster *KlineAdjuster) Middleware[kline.Request, kline.Response] {
+	return func(next manager.Provider[kline.Request, kline.Response]) manager.Provider[kline.Request, kline.Response] {
+		return wrap(next, func(ctx context.Context, client request.Client, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+			return adjuster.Fetch(ctx, req, func(ctx context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+				return next.Fetch(ctx, client, req)
+			})
+		})
+	}
+}
+
+// isIndex reports whether symbol is a market index such as 000300.SH.
+func isIndex(symbol string) bool {
+	var sym domain.Symbol
+	return sym.Parse(symbol) == nil && sym.AssetType == domain.AssetTypeIndex
+}
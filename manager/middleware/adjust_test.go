@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/souloss/quantds/domain/corpaction"
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// splitFetcher returns two unadjusted daily bars around a 2-for-1 split, or
+// bars already adjusted by the upstream when asked for an adjustment.
+type splitFetcher struct {
+	calls []kline.Request
+}
+
+func (f *splitFetcher) fetch(_ context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+	f.calls = append(f.calls, req)
+	trace := manager.NewRequestTrace("raw")
+	trace.AddRequest(request.NewRecord())
+	first := 20.0
+	if req.Adjust != kline.AdjustNone {
+		first = 10
+	}
+	return kline.Response{
+		Symbol: req.Symbol,
+		Source: "raw",
+		Bars: []kline.Bar{
+			{Timestamp: adjustDay(1), Close: first},
+			{Timestamp: adjustDay(2), Close: 10},
+		},
+	}, trace, nil
+}
+
+func adjustDay(d int) time.Time {
+	return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func factorSource(factors []kline.Factor, err error) FactorFetchFunc {
+	return func(context.Context, kline.FactorRequest) (kline.FactorResponse, *manager.RequestTrace, error) {
+		trace := manager.NewRequestTrace("factors")
+		trace.AddRequest(request.NewRecord())
+		return kline.FactorResponse{Factors: factors}, trace, err
+	}
+}
+
+func actionSource(actions []corpaction.Action, err error) CorpActionFetchFunc {
+	return func(context.Context, corpaction.Request) (corpaction.Response, *manager.RequestTrace, error) {
+		trace := manager.NewRequestTrace("actions")
+		trace.AddRequest(request.NewRecord())
+		return corpaction.Response{Actions: actions}, trace, err
+	}
+}
+
+func TestKlineAdjuster(t *testing.T) {
+	req := kline.Request{Symbol: "AAPL.US", Timeframe: kline.Timeframe1d, Adjust: kline.AdjustForward}
+	split := []corpaction.Action{{Type: corpaction.ActionSplit, ExDate: adjustDay(2), Ratio: 2}}
+	unavailable := errors.New("unavailable")
+
+	tests := []struct {
+		name       string
+		opts       []AdjusterOption
+		method     kline.AdjustMethod
+		fetches    int
+		requests   int
+		firstClose float64
+	}{
+		{
+			name:       "factors",
+			opts:       []AdjusterOption{WithFactorSource(factorSource([]kline.Factor{{Date: adjustDay(1), Value: 1}, {Date: adjustDay(2), Value: 2}}, nil))},
+			method:     kline.AdjustMethodFactor,
+			fetches:    1,
+			requests:   2,
+			firstClose: 10,
+		},
+		{
+			name: "corporate actions when factors fail",
+			opts: []AdjusterOption{
+				WithFactorSource(factorSource(nil, unavailable)),
+				WithCorpActionSource(actionSource(split, nil)),
+			},
+			method:     kline.AdjustMethodCorpAction,
+			fetches:    1,
+			requests:   3,
+			firstClose: 10,
+		},
+		{
+			name:       "upstream when nothing is available",
+			opts:       []AdjusterOption{WithCorpActionSource(actionSource(nil, unavailable))},
+			method:     kline.AdjustMethodNone,
+			fetches:    2,
+			requests:   3,
+			firstClose: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &splitFetcher{}
+			resp, trace, err := NewKlineAdjuster(tt.opts...).Fetch(context.Background(), req, f.fetch)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if f.calls[0].Adjust != kline.AdjustNone {
+				t.Errorf("first fetch Adjust = %q, want unadjusted", f.calls[0].Adjust)
+			}
+			if len(f.calls) != tt.fetches || trace.TotalRequests() != tt.requests {
+				t.Errorf("fetches = %d, requests = %d, want %d and %d", len(f.calls), trace.TotalRequests(), tt.fetches, tt.requests)
+			}
+			if resp.AdjustMethod != tt.method {
+				t.Errorf("AdjustMethod = %q, want %q", resp.AdjustMethod, tt.method)
+			}
+			if resp.Bars[0].Close != tt.firstClose || resp.Bars[1].Close != 10 {
+				t.Errorf("closes = %v, %v", resp.Bars[0].Close, resp.Bars[1].Close)
+			}
+		})
+	}
+}
+
+func TestKlineAdjuster_Unadjusted(t *testing.T) {
+	called := false
+	adjuster := NewKlineAdjuster(WithFactorSource(func(context.Context, kline.FactorRequest) (kline.FactorResponse, *manager.RequestTrace, error) {
+		called = true
+		return kline.FactorResponse{}, nil, nil
+	}))
+
+	f := &splitFetcher{}
+	resp, _, err := adjuster.Fetch(context.Background(), kline.Request{Symbol: "AAPL.US"}, f.fetch)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if called || resp.Bars[0].Close != 20 || resp.Adjust != kline.AdjustNone {
+		t.Errorf("unadjusted request was adjusted: %+v", resp)
+	}
+
+	// Indexes are never adjusted locally
+	f = &splitFetcher{}
+	if _, _, err := adjuster.Fetch(context.Background(), kline.Request{Symbol: "000300.SH", Adjust: kline.AdjustForward}, f.fetch); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if called || len(f.calls) != 1 || f.calls[0].Adjust != kline.AdjustForward {
+		t.Errorf("index request was adjusted locally: calls = %+v", f.calls)
+	}
+}
+
+func TestKlineAdjuster_SymbolFilter(t *testing.T) {
+	adjuster := NewKlineAdjuster(
+		WithFactorSource(factorSource(nil, errors.New("unsupported market"))),
+		WithSymbolFilter(func(symbol string) bool { return symbol == "000001.SZ" }),
+	)
+
+	// Unsupported symbols go straight to the upstream adjustment
+	f := &splitFetcher{}
+	resp, _, err := adjuster.Fetch(context.Background(), kline.Request{Symbol: "BTCUSDT", Adjust: kline.AdjustForward}, f.fetch)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(f.calls) != 1 || f.calls[0].Adjust != kline.AdjustForward || resp.AdjustMethod != "" {
+		t.Errorf("unsupported symbol: calls = %+v, AdjustMethod = %q", f.calls, resp.AdjustMethod)
+	}
+
+	f = &splitFetcher{}
+	if _, _, err := adjuster.Fetch(context.Background(), kline.Request{Symbol: "000001.SZ", Adjust: kline.AdjustForward}, f.fetch); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(f.calls) == 0 || f.calls[0].Adjust != kline.AdjustNone {
+		t.Errorf("supported symbol was not fetched unadjusted first: calls = %+v", f.calls)
+	}
+}
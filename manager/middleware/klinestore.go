@@ -0,0 +1,317 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// KlineFetchFunc fetches the bars of a kline request.
+type KlineFetchFunc func(ctx context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error)
+
+// KlineStore keeps fetched bars per (symbol, timeframe, adjust) together with
+// the time ranges it holds completely, so that a later request only fetches
+// the sub-ranges it does not cover yet. A successful fetch covers the whole
+// range it was asked for, including the parts without bars such as weekends
+// and holidays, so that they are not fetched again.
+//
+// Closed bars are treated as immutable. The range from the start of the
+// in-progress bar onwards is never marked as covered and is therefore
+// refreshed on every request that reaches it. Forward-adjusted series change
+// after corporate actions; call Invalidate for the affected symbol then.
+//
+// A series holds the bars of one provider. When a fetch is served by another
+// provider than the stored bars, the series is replaced by a fetch of the
+// whole requested range rather than mixing providers. The store holds at
+// most a bounded number of bars, evicting the least recently used series.
+type KlineStore struct {
+	mu      sync.Mutex
+	series  map[klineSeriesKey]*list.Element
+	lru     *list.List // front = most recently used
+	bars    int
+	maxBars int
+	now     func() time.Time
+}
+
+// DefaultMaxStoredBars is the default number of bars a KlineStore holds.
+const DefaultMaxStoredBars = 500_000
+
+type klineSeriesKey struct {
+	symbol    string
+	timeframe kline.Timeframe
+	adjust    kline.AdjustType
+}
+
+type klineSeries struct {
+	key     klineSeriesKey
+	source  string
+	bars    map[int64]kline.Bar
+	covered []timeRange // sorted, non-overlapping
+}
+
+type timeRange struct {
+	start, end time.Time
+}
+
+// KlineStoreOption configures a KlineStore.
+type KlineStoreOption func(*KlineStore)
+
+// WithMaxStoredBars bounds the number of bars the store holds. Zero or less
+// means unbounded.
+func WithMaxStoredBars(n int) KlineStoreOption {
+	return func(s *KlineStore) {
+		s.maxBars = n
+	}
+}
+
+// NewKlineStore creates an empty in-memory KlineStore holding at most
+// DefaultMaxStoredBars bars.
+func NewKlineStore(opts ...KlineStoreOption) *KlineStore {
+	s := &KlineStore{
+		series:  make(map[klineSeriesKey]*list.Element),
+		lru:     list.New(),
+		maxBars: DefaultMaxStoredBars,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Fetch serves req from the store, calling fetch only for the missing
+// sub-ranges. Requests without both StartTime and EndTime bypass the store.
+// The returned trace holds the records of all sub-range fetches.
+func (s *KlineStore) Fetch(ctx context.Context, req kline.Request, fetch KlineFetchFunc) (kline.Response, *manager.RequestTrace, error) {
+	if req.StartTime.IsZero() || req.EndTime.IsZero() || req.EndTime.Before(req.StartTime) {
+		return fetch(ctx, req)
+	}
+
+	key := klineSeriesKey{symbol: req.Symbol, timeframe: req.Timeframe, adjust: req.Adjust}
+	// Bars starting after this instant have not closed yet.
+	closedUntil := s.now().Add(-req.Timeframe.Duration())
+
+	s.mu.Lock()
+	ks := s.seriesFor(key)
+	gaps := ks.missing(timeRange{req.StartTime, req.EndTime})
+	source := ks.source
+	s.mu.Unlock()
+
+	trace := manager.NewRequestTrace(source)
+	defer trace.Finish()
+	for _, gap := range gaps {
+		sub := req
+		sub.StartTime, sub.EndTime = gap.start, gap.end
+		resp, err := fetchInto(ctx, trace, sub, fetch)
+		if err != nil {
+			return kline.Response{}, trace, err
+		}
+
+		s.mu.Lock()
+		mixed := ks.source != "" && resp.Source != "" && resp.Source != ks.source
+		if !mixed {
+			s.add(ks, resp, gap, closedUntil)
+		}
+		s.mu.Unlock()
+		if mixed {
+			return s.replace(ctx, trace, req, fetch, closedUntil)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ks.slice(req), trace, nil
+}
+
+// replace fetches the whole range of req and stores it as the new series of
+// req, dropping the bars of the previous provider.
+func (s *KlineStore) replace(ctx context.Context, trace *manager.RequestTrace, req kline.Request, fetch KlineFetchFunc, closedUntil time.Time) (kline.Response, *manager.RequestTrace, error) {
+	resp, err := fetchInto(ctx, trace, req, fetch)
+	if err != nil {
+		return kline.Response{}, trace, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := klineSeriesKey{symbol: req.Symbol, timeframe: req.Timeframe, adjust: req.Adjust}
+	if elem, ok := s.series[key]; ok {
+		s.remove(elem)
+	}
+	ks := s.seriesFor(key)
+	s.add(ks, resp, timeRange{req.StartTime, req.EndTime}, closedUntil)
+	return ks.slice(req), trace, nil
+}
+
+// fetchInto fetches req and adds its request records to trace.
+func fetchInto(ctx context.Context, trace *manager.RequestTrace, req kline.Request, fetch KlineFetchFunc) (kline.Response, error) {
+	resp, subTrace, err := fetch(ctx, req)
+	if subTrace != nil {
+		trace.Provider = subTrace.Provider
+		for _, r := range subTrace.Requests {
+			trace.AddRequest(r)
+		}
+	}
+	return resp, err
+}
+
+// Invalidate drops all stored series of symbol.
+func (s *KlineStore) Invalidate(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, elem := range s.series {
+		if strings.EqualFold(key.symbol, symbol) {
+			s.remove(elem)
+		}
+	}
+}
+
+// Clear drops all stored series.
+func (s *KlineStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.series = make(map[klineSeriesKey]*list.Element)
+	s.lru.Init()
+	s.bars = 0
+}
+
+// Len returns the number of stored bars.
+func (s *KlineStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bars
+}
+
+// seriesFor returns the series of key, creating it if needed, and marks it as
+// most recently used. The caller must hold the lock.
+func (s *KlineStore) seriesFor(key klineSeriesKey) *klineSeries {
+	if elem, ok := s.series[key]; ok {
+		s.lru.MoveToFront(elem)
+		return elem.Value.(*klineSeries)
+	}
+	ks := &klineSeries{key: key, bars: make(map[int64]kline.Bar)}
+	s.series[key] = s.lru.PushFront(ks)
+	return ks
+}
+
+// add merges resp, fetched for r, into ks and evicts the least recently used
+// other series while the store holds too many bars. A series evicted by a
+// concurrent fetch still serves the fetch holding it but no longer counts.
+// The caller must hold the lock.
+func (s *KlineStore) add(ks *klineSeries, resp kline.Response, r timeRange, closedUntil time.Time) {
+	added := ks.add(resp, r, closedUntil)
+	elem, ok := s.series[ks.key]
+	if !ok || elem.Value != ks {
+		return
+	}
+	s.bars += added
+	for s.maxBars > 0 && s.bars > s.maxBars {
+		back := s.lru.Back()
+		if back == elem {
+			return
+		}
+		s.remove(back)
+	}
+}
+
+// remove drops the series of elem. The caller must hold the lock.
+func (s *KlineStore) remove(elem *list.Element) {
+	ks := s.lru.Remove(elem).(*klineSeries)
+	delete(s.series, ks.key)
+	s.bars -= len(ks.bars)
+}
+
+// missing returns the parts of r not covered by the series.
+func (ks *klineSeries) missing(r timeRange) []timeRange {
+	var gaps []timeRange
+	cursor := r.start
+	for _, c := range ks.covered {
+		if c.end.Before(cursor) {
+			continue
+		}
+		if c.start.After(r.end) {
+			break
+		}
+		if c.start.After(cursor) {
+			gaps = append(gaps, timeRange{cursor, c.start.Add(-time.Nanosecond)})
+		}
+		cursor = c.end.Add(time.Nanosecond)
+		if cursor.After(r.end) {
+			return gaps
+		}
+	}
+	return append(gaps, timeRange{cursor, r.end})
+}
+
+// add merges the bars of resp, fetched for r, and marks r up to closedUntil
+// as complete. Bars inside the fetched range replace stored ones, so the
+// in-progress bar is refreshed. It returns the number of bars added.
+func (ks *klineSeries) add(resp kline.Response, r timeRange, closedUntil time.Time) int {
+	if resp.Source != "" {
+		ks.source = resp.Source
+	}
+	n := len(ks.bars)
+	for _, bar := range resp.Bars {
+		ks.bars[bar.Timestamp.UnixNano()] = bar
+	}
+	added := len(ks.bars) - n
+
+	covered := r
+	if covered.end.After(closedUntil) {
+		covered.end = closedUntil
+	}
+	if covered.end.Before(covered.start) {
+		return added
+	}
+
+	ranges := append(ks.covered, covered)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Before(ranges[j].start)
+	})
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r.start.After(last.end.Add(time.Nanosecond)) {
+			merged = append(merged, r)
+			continue
+		}
+		if r.end.After(last.end) {
+			last.end = r.end
+		}
+	}
+	ks.covered = merged
+	return added
+}
+
+// slice returns the stored bars of req's range in ascending order.
+func (ks *klineSeries) slice(req kline.Request) kline.Response {
+	resp := kline.Response{Symbol: req.Symbol, Source: ks.source}
+	for _, bar := range ks.bars {
+		if bar.Timestamp.Before(req.StartTime) || bar.Timestamp.After(req.EndTime) {
+			continue
+		}
+		resp.Bars = append(resp.Bars, bar)
+	}
+	sort.Slice(resp.Bars, func(i, j int) bool {
+		return resp.Bars[i].Timestamp.Before(resp.Bars[j].Timestamp)
+	})
+	return resp
+}
+
+// IncrementalKline serves kline requests of the wrapped provider from store,
+// fetching only the ranges the store does not hold yet.
+func IncrementalKline(store *KlineStore) Middleware[kline.Request, kline.Response] {
+	return func(next manager.Provider[kline.Request, kline.Response]) manager.Provider[kline.Request, kline.Response] {
+		return wrap(next, func(ctx context.Context, client request.Client, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+			return store.Fetch(ctx, req, func(ctx context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+				return next.Fetch(ctx, client, req)
+			})
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// dailyFetcher returns one bar per UTC midnight in the requested range and
+// records the requested ranges.
+type dailyFetcher struct {
+	calls []kline.Request
+	close float64
+}
+
+func (f *dailyFetcher) fetch(_ context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+	f.calls = append(f.calls, req)
+	trace := manager.NewRequestTrace("daily")
+	trace.AddRequest(request.NewRecord())
+
+	var bars []kline.Bar
+	day := req.StartTime.Truncate(24 * time.Hour)
+	if day.Before(req.StartTime) {
+		day = day.Add(24 * time.Hour)
+	}
+	for ; !day.After(req.EndTime); day = day.Add(24 * time.Hour) {
+		bars = append(bars, kline.Bar{Timestamp: day, Close: f.close})
+	}
+	return kline.Response{Symbol: req.Symbol, Bars: bars, Source: "daily"}, trace, nil
+}
+
+func TestKlineStore(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 30).Add(12 * time.Hour)
+	store := NewKlineStore()
+	store.now = func() time.Time { return now }
+	f := &dailyFetcher{close: 1}
+
+	req := kline.Request{Symbol: "000001.SZ", Timeframe: kline.Timeframe1d, StartTime: start, EndTime: start.AddDate(0, 0, 9)}
+	resp, trace, err := store.Fetch(context.Background(), req, f.fetch)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(resp.Bars) != 10 || trace.TotalRequests() != 1 || resp.Source != "daily" {
+		t.Fatalf("Fetch() bars = %d, requests = %d, source = %q", len(resp.Bars), trace.TotalRequests(), resp.Source)
+	}
+
+	// Fully covered: served locally
+	sub := req
+	sub.StartTime = start.AddDate(0, 0, 2)
+	sub.EndTime = start.AddDate(0, 0, 5)
+	resp, trace, _ = store.Fetch(context.Background(), sub, f.fetch)
+	if len(f.calls) != 1 || trace.TotalRequests() != 0 || len(resp.Bars) != 4 {
+		t.Errorf("covered Fetch() calls = %d, bars = %d", len(f.calls), len(resp.Bars))
+	}
+
+	// Extended range: only the tail is fetched
+	f.close = 2
+	ext := req
+	ext.EndTime = start.AddDate(0, 0, 19)
+	resp, _, _ = store.Fetch(context.Background(), ext, f.fetch)
+	if len(f.calls) != 2 || !f.calls[1].StartTime.After(req.EndTime) || !f.calls[1].EndTime.Equal(ext.EndTime) {
+		t.Fatalf("extended Fetch() requested %+v", f.calls[len(f.calls)-1])
+	}
+	if len(resp.Bars) != 20 || resp.Bars[0].Close != 1 || resp.Bars[19].Close != 2 {
+		t.Errorf("extended Fetch() bars = %d", len(resp.Bars))
+	}
+
+	// The in-progress bar is refetched every time
+	live := req
+	live.StartTime = start.AddDate(0, 0, 25)
+	live.EndTime = now
+	store.Fetch(context.Background(), live, f.fetch)
+	f.close = 3
+	resp, _, _ = store.Fetch(context.Background(), live, f.fetch)
+	if len(f.calls) != 4 {
+		t.Fatalf("live Fetch() calls = %d, want 4", len(f.calls))
+	}
+	if last := f.calls[3]; !last.StartTime.After(start.AddDate(0, 0, 29)) {
+		t.Errorf("live refetch started at %v, want only the open bar", last.StartTime)
+	}
+	if got := resp.Bars[len(resp.Bars)-1]; got.Close != 3 || resp.Bars[0].Close != 2 {
+		t.Errorf("live Fetch() first/last close = %v/%v, want 2/3", resp.Bars[0].Close, got.Close)
+	}
+
+	store.Invalidate("000001.SZ")
+	store.Fetch(context.Background(), sub, f.fetch)
+	if len(f.calls) != 5 {
+		t.Errorf("Fetch() after Invalidate calls = %d, want 5", len(f.calls))
+	}
+}
+
+func TestIncrementalKline(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := kline.Request{Symbol: "BTCUSDT", Timeframe: kline.Timeframe1d, StartTime: start, EndTime: start.AddDate(0, 0, 4)}
+
+	p := &pagingProvider{}
+	wrapped := IncrementalKline(NewKlineStore())(p)
+	if wrapped.Name() != p.Name() {
+		t.Errorf("Name() = %v, want %v", wrapped.Name(), p.Name())
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, _, err := wrapped.Fetch(context.Background(), nil, req)
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		if len(resp.Bars) != 5 {
+			t.Errorf("Fetch() bars = %d, want 5", len(resp.Bars))
+		}
+	}
+	if len(p.calls) != 1 {
+		t.Errorf("provider calls = %d, want 1", len(p.calls))
+	}
+}
+
+func TestKlineStore_NonTradingDays(t *testing.T) {
+	// Saturday 2024-01-06 to Sunday 2024-01-14
+	start := time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	store := NewKlineStore()
+	store.now = func() time.Time { return start.AddDate(1, 0, 0) }
+
+	var calls int
+	fetch := func(_ context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+		calls++
+		var bars []kline.Bar
+		for day := req.StartTime; !day.After(req.EndTime); day = day.AddDate(0, 0, 1) {
+			if wd := day.Weekday(); wd != time.Saturday && wd != time.Sunday {
+				bars = append(bars, kline.Bar{Timestamp: day})
+			}
+		}
+		return kline.Response{Symbol: req.Symbol, Bars: bars, Source: "daily"}, nil, nil
+	}
+
+	req := kline.Request{Symbol: "000001.SZ", Timeframe: kline.Timeframe1d, StartTime: start, EndTime: start.AddDate(0, 0, 8)}
+	resp, _, err := store.Fetch(context.Background(), req, fetch)
+	if err != nil || len(resp.Bars) != 5 {
+		t.Fatalf("Fetch() bars = %d, error = %v, want 5", len(resp.Bars), err)
+	}
+	resp, trace, _ := store.Fetch(context.Background(), req, fetch)
+	if calls != 1 || trace.TotalRequests() != 0 || len(resp.Bars) != 5 {
+		t.Errorf("repeated weekend-bounded Fetch() calls = %d, bars = %d, want 1 and 5", calls, len(resp.Bars))
+	}
+
+	// A range without any bar is covered as well
+	weekend := req
+	weekend.StartTime, weekend.EndTime = start.AddDate(0, 0, 14), start.AddDate(0, 0, 15)
+	store.Fetch(context.Background(), weekend, fetch)
+	store.Fetch(context.Background(), weekend, fetch)
+	if calls != 2 {
+		t.Errorf("empty weekend Fetch() calls = %d, want 2", calls)
+	}
+}
+
+func TestKlineStore_ProviderChange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewKlineStore()
+	store.now = func() time.Time { return start.AddDate(1, 0, 0) }
+
+	f := &dailyFetcher{close: 1}
+	req := kline.Request{Symbol: "000001.SZ", Timeframe: kline.Timeframe1d, StartTime: start, EndTime: start.AddDate(0, 0, 4)}
+	store.Fetch(context.Background(), req, f.fetch)
+
+	// The next range is served by another provider
+	other := func(ctx context.Context, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+		resp, trace, err := (&dailyFetcher{close: 2}).fetch(ctx, req)
+		resp.Source = "other"
+		return resp, trace, err
+	}
+	ext := req
+	ext.EndTime = start.AddDate(0, 0, 9)
+	resp, trace, err := store.Fetch(context.Background(), ext, other)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if resp.Source != "other" || len(resp.Bars) != 10 || trace.TotalRequests() != 2 {
+		t.Fatalf("Fetch() source = %q, bars = %d, requests = %d", resp.Source, len(resp.Bars), trace.TotalRequests())
+	}
+	for _, bar := range resp.Bars {
+		if bar.Close != 2 {
+			t.Fatalf("bars of two providers mixed: %+v", resp.Bars)
+		}
+	}
+}
+
+func TestKlineStore_MaxBars(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewKlineStore(WithMaxStoredBars(15))
+	store.now = func() time.Time { return start.AddDate(1, 0, 0) }
+	f := &dailyFetcher{}
+
+	fetch := func(symbol string) {
+		req := kline.Request{Symbol: symbol, Timeframe: kline.Timeframe1d, StartTime: start, EndTime: start.AddDate(0, 0, 9)}
+		if _, _, err := store.Fetch(context.Background(), req, f.fetch); err != nil {
+			t.Fatalf("Fetch(%s) error = %v", symbol, err)
+		}
+	}
+	fetch("000001.SZ")
+	fetch("600519.SH")
+	if store.Len() != 10 {
+		t.Errorf("Len() = %d, want 10 after evicting the oldest series", store.Len())
+	}
+	fetch("600519.SH")
+	fetch("000001.SZ")
+	if len(f.calls) != 3 {
+		t.Errorf("calls = %d, want only the evicted series refetched", len(f.calls))
+	}
+}
@@ -51,3 +51,14 @@ func (p *providerFunc[Req, Resp]) Fetch(ctx context.Context, client request.Clie
 func ProviderFunc[Req, Resp any](name string, fetch func(ctx context.Context, client request.Client, req Req) (Resp, *manager.RequestTrace, error)) manager.Provider[Req, Resp] {
 	return &providerFunc[Req, Resp]{name: name, fetch: fetch}
 }
+
+// wrap replaces the Fetch of next while keeping its name, supported markets
+// and symbol matching.
+func wrap[Req, Resp any](next manager.Provider[Req, Resp], fetch func(ctx context.Context, client request.Client, req Req) (Resp, *manager.RequestTrace, error)) manager.Provider[Req, Resp] {
+	return &providerFunc[Req, Resp]{
+		name:             next.Name(),
+		fetch:            fetch,
+		supportedMarkets: next.SupportedMarkets(),
+		canHandle:        next.CanHandle,
+	}
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+// KlinePaging splits kline requests whose time range spans more than maxBars
+// bars into consecutive windows, fetches every window from the wrapped
+// provider and stitches the pages back into a single response.
+//
+// Bars are de-duplicated by timestamp (later pages win) and returned in
+// ascending order. All page records are collected into one RequestTrace.
+// Requests without both StartTime and EndTime are passed through unchanged.
+func KlinePaging(maxBars int, opts ...PagingOption) Middleware[kline.Request, kline.Response] {
+	return func(next manager.Provider[kline.Request, kline.Response]) manager.Provider[kline.Request, kline.Response] {
+		return wrap(next, func(ctx context.Context, client request.Client, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+			windows := SplitKlineRange(req, maxBars, opts...)
+			if len(windows) <= 1 {
+				return next.Fetch(ctx, client, req)
+			}
+
+			trace := manager.NewRequestTrace(next.Name())
+			pages := make([]kline.Response, 0, len(windows))
+			for _, w := range windows {
+				if err := ctx.Err(); err != nil {
+					trace.Finish()
+					return kline.Response{}, trace, err
+				}
+
+				resp, pageTrace, err := next.Fetch(ctx, client, w)
+				if pageTrace != nil {
+					for _, r := range pageTrace.Requests {
+						trace.AddRequest(r)
+					}
+				}
+				if err != nil {
+					trace.Finish()
+					return kline.Response{}, trace, err
+				}
+				pages = append(pages, resp)
+			}
+
+			trace.Finish()
+			return MergeKlinePages(req.Symbol, pages), trace, nil
+		})
+	}
+}
+
+// PagingOption configures KlinePaging and SplitKlineRange.
+type PagingOption func(*paging)
+
+type paging struct {
+	// session is the trading time per day of a provider that takes dates
+	// only, zero for providers that take timestamps.
+	session time.Duration
+}
+
+// WithDayPrecision splits ranges into whole days, for providers that take a
+// date range rather than timestamps, such as eastmoney. session is the
+// trading time per day, which bounds how many intraday bars a day holds.
+func WithDayPrecision(session time.Duration) PagingOption {
+	return func(p *paging) {
+		p.session = session
+	}
+}
+
+// SplitKlineRange splits req into windows of at most maxBars bars each.
+// It returns req unchanged when the range is open-ended or already fits.
+func SplitKlineRange(req kline.Request, maxBars int, opts ...PagingOption) []kline.Request {
+	if maxBars <= 0 || req.StartTime.IsZero() || req.EndTime.IsZero() || !req.EndTime.After(req.StartTime) {
+		return []kline.Request{req}
+	}
+	var p paging
+	for _, opt := range opts {
+		opt(&p)
+	}
+	if p.session > 0 {
+		return splitKlineDays(req, maxBars, p.session)
+	}
+
+	window := time.Duration(maxBars-1) * req.Timeframe.Duration()
+	if window <= 0 || req.EndTime.Sub(req.StartTime) <= window {
+		return []kline.Request{req}
+	}
+
+	var windows []kline.Request
+	for start := req.StartTime; !start.After(req.EndTime); {
+		end := start.Add(window)
+		if end.After(req.EndTime) {
+			end = req.EndTime
+		}
+		w := req
+		w.StartTime = start
+		w.EndTime = end
+		windows = append(windows, w)
+
+		if !end.Before(req.EndTime) {
+			break
+		}
+		start = end
+	}
+	return windows
+}
+
+// splitKlineDays splits req into windows of whole days holding at most
+// maxBars bars each, given session hours of trading per day. Windows do not
+// share a day, since a date range returns every bar of its days.
+func splitKlineDays(req kline.Request, maxBars int, session time.Duration) []kline.Request {
+	const day = 24 * time.Hour
+	bar := req.Timeframe.Duration()
+	days := maxBars * int(bar/day)
+	if bar < day {
+		days = maxBars / max(1, int(session/bar))
+	}
+	days = max(1, days)
+
+	loc := req.StartTime.Location()
+	first := startOfDay(req.StartTime, loc)
+	last := startOfDay(req.EndTime, loc)
+	if first.AddDate(0, 0, days-1).Compare(last) >= 0 {
+		return []kline.Request{req}
+	}
+
+	var windows []kline.Request
+	for start := first; !start.After(last); start = start.AddDate(0, 0, days) {
+		w := req
+		w.StartTime = start
+		if start.Equal(first) {
+			w.StartTime = req.StartTime
+		}
+		w.EndTime = start.AddDate(0, 0, days-1)
+		if !w.EndTime.Before(last) {
+			w.EndTime = req.EndTime
+		}
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+// startOfDay returns midnight of t's day in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// MergeKlinePages stitches paged kline responses into one response sorted by
+// timestamp. Overlapping bars are de-duplicated and later pages take
+// precedence.
+func MergeKlinePages(symbol string, pages []kline.Response) kline.Response {
+	merged := kline.Response{Symbol: symbol}
+	index := make(map[int64]int)
+	for _, page := range pages {
+		if merged.Source == "" {
+			merged.Source = page.Source
+		}
+		for _, bar := range page.Bars {
+			key := bar.Timestamp.UnixNano()
+			if i, ok := index[key]; ok {
+				merged.Bars[i] = bar
+				continue
+			}
+			index[key] = len(merged.Bars)
+			merged.Bars = append(merged.Bars, bar)
+		}
+	}
+
+	sort.SliceStable(merged.Bars, func(i, j int) bool {
+		return merged.Bars[i].Timestamp.Before(merged.Bars[j].Timestamp)
+	})
+	return merged
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/kline"
+	"github.com/souloss/quantds/manager"
+	"github.com/souloss/quantds/request"
+)
+
+type pagingProvider struct {
+	calls []kline.Request
+	err   error
+}
+
+func (p *pagingProvider) Name() string { return "paging" }
+
+func (p *pagingProvider) SupportedMarkets() []domain.Market {
+	return []domain.Market{domain.MarketCrypto}
+}
+
+func (p *pagingProvider) CanHandle(symbol string) bool { return symbol == "BTCUSDT" }
+
+// Fetch returns one daily bar per day in the requested range, inclusive.
+func (p *pagingProvider) Fetch(_ context.Context, _ request.Client, req kline.Request) (kline.Response, *manager.RequestTrace, error) {
+	p.calls = append(p.calls, req)
+	trace := manager.NewRequestTrace(p.Name())
+	trace.AddRequest(request.NewRecord())
+	if p.err != nil && len(p.calls) > 1 {
+		return kline.Response{}, trace, p.err
+	}
+
+	var bars []kline.Bar
+	for t := req.StartTime; !t.After(req.EndTime); t = t.Add(24 * time.Hour) {
+		bars = append(bars, kline.Bar{Timestamp: t, Close: float64(len(p.calls))})
+	}
+	return kline.Response{Symbol: req.Symbol, Bars: bars, Source: p.Name()}, trace, nil
+}
+
+func TestKlinePaging(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := kline.Request{
+		Symbol:    "BTCUSDT",
+		Timeframe: kline.Timeframe1d,
+		StartTime: start,
+		EndTime:   start.AddDate(0, 0, 24),
+	}
+
+	p := &pagingProvider{}
+	paged := KlinePaging(10)(p)
+
+	if paged.Name() != p.Name() {
+		t.Errorf("Name() = %v, want %v", paged.Name(), p.Name())
+	}
+	if !paged.CanHandle("BTCUSDT") || paged.CanHandle("000001.SZ") {
+		t.Error("CanHandle() should delegate to the wrapped provider")
+	}
+	if markets := paged.SupportedMarkets(); len(markets) != 1 || markets[0] != domain.MarketCrypto {
+		t.Errorf("SupportedMarkets() = %v, want [%s]", markets, domain.MarketCrypto)
+	}
+
+	resp, trace, err := paged.Fetch(context.Background(), nil, req)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if len(p.calls) != 3 {
+		t.Fatalf("provider called %d times, want 3", len(p.calls))
+	}
+	for i, c := range p.calls {
+		if bars := int(c.EndTime.Sub(c.StartTime)/(24*time.Hour)) + 1; bars > 10 {
+			t.Errorf("page %d spans %d bars, want <= 10", i, bars)
+		}
+	}
+	if trace.TotalRequests() != 3 {
+		t.Errorf("TotalRequests() = %d, want 3", trace.TotalRequests())
+	}
+
+	if len(resp.Bars) != 25 {
+		t.Fatalf("len(Bars) = %d, want 25", len(resp.Bars))
+	}
+	for i := 1; i < len(resp.Bars); i++ {
+		if !resp.Bars[i].Timestamp.After(resp.Bars[i-1].Timestamp) {
+			t.Fatalf("bars not strictly ascending at %d", i)
+		}
+	}
+	if resp.Source != p.Name() {
+		t.Errorf("Source = %v, want %v", resp.Source, p.Name())
+	}
+}
+
+func TestKlinePaging_PassThrough(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &pagingProvider{}
+
+	_, _, err := KlinePaging(10)(p).Fetch(context.Background(), nil, kline.Request{
+		Symbol:    "BTCUSDT",
+		Timeframe: kline.Timeframe1d,
+		StartTime: start,
+		EndTime:   start.AddDate(0, 0, 5),
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(p.calls) != 1 {
+		t.Errorf("provider called %d times, want 1", len(p.calls))
+	}
+}
+
+func TestKlinePaging_PageError(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	wantErr := errors.New("page failed")
+	p := &pagingProvider{err: wantErr}
+
+	_, trace, err := KlinePaging(10)(p).Fetch(context.Background(), nil, kline.Request{
+		Symbol:    "BTCUSDT",
+		Timeframe: kline.Timeframe1d,
+		StartTime: start,
+		EndTime:   start.AddDate(0, 0, 30),
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Fetch() error = %v, want %v", err, wantErr)
+	}
+	if trace.TotalRequests() != 2 {
+		t.Errorf("TotalRequests() = %d, want 2", trace.TotalRequests())
+	}
+}
+
+func TestMergeKlinePages(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(24 * time.Hour)
+	t3 := t2.Add(24 * time.Hour)
+
+	merged := MergeKlinePages("BTCUSDT", []kline.Response{
+		{Source: "a", Bars: []kline.Bar{{Timestamp: t2, Close: 1}, {Timestamp: t1, Close: 1}}},
+		{Source: "b", Bars: []kline.Bar{{Timestamp: t2, Close: 2}, {Timestamp: t3, Close: 2}}},
+	})
+
+	if merged.Source != "a" {
+		t.Errorf("Source = %v, want a", merged.Source)
+	}
+	if len(merged.Bars) != 3 {
+		t.Fatalf("len(Bars) = %d, want 3", len(merged.Bars))
+	}
+	if !merged.Bars[0].Timestamp.Equal(t1) || !merged.Bars[2].Timestamp.Equal(t3) {
+		t.Errorf("bars not sorted: %v", merged.Bars)
+	}
+	if merged.Bars[1].Close != 2 {
+		t.Errorf("overlapping bar Close = %v, want 2 (later page wins)", merged.Bars[1].Close)
+	}
+}
+
+func TestSplitKlineRange_DayPrecision(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	req := kline.Request{
+		Symbol:    "000001.SZ",
+		Timeframe: kline.Timeframe1m,
+		StartTime: start,
+		EndTime:   time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC),
+	}
+
+	// 1000 one-minute bars hold 4 days of 4-hour sessions
+	windows := SplitKlineRange(req, 1000, WithDayPrecision(4*time.Hour))
+	want := [][2]string{{"20240101", "20240104"}, {"20240105", "20240108"}, {"20240109", "20240110"}}
+	if len(windows) != len(want) {
+		t.Fatalf("got %d windows, want %d: %+v", len(windows), len(want), windows)
+	}
+	for i, w := range windows {
+		if got := [2]string{w.StartTime.Format("20060102"), w.EndTime.Format("20060102")}; got != want[i] {
+			t.Errorf("window %d = %v, want %v", i, got, want[i])
+		}
+	}
+	if !windows[0].StartTime.Equal(req.StartTime) || !windows[2].EndTime.Equal(req.EndTime) {
+		t.Errorf("windows do not keep the requested bounds: %+v", windows)
+	}
+
+	// Daily bars are one per day
+	req.Timeframe = kline.Timeframe1d
+	if windows := SplitKlineRange(req, 1000, WithDayPrecision(4*time.Hour)); len(windows) != 1 {
+		t.Errorf("daily range split into %d windows, want 1", len(windows))
+	}
+}
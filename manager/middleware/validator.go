@@ -10,7 +10,7 @@ import (
 
 func Validator[Req, Resp any](validate func(Resp) error) Middleware[Req, Resp] {
 	return func(next manager.Provider[Req, Resp]) manager.Provider[Req, Resp] {
-		return ProviderFunc[Req, Resp](next.Name(), func(ctx context.Context, client request.Client, req Req) (Resp, *manager.RequestTrace, error) {
+		return wrap(next, func(ctx context.Context, client request.Client, req Req) (Resp, *manager.RequestTrace, error) {
 			resp, trace, err := next.Fetch(ctx, client, req)
 			if err != nil {
 				return resp, trace, err
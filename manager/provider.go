@@ -44,3 +44,21 @@ func WithTags(tags map[string]string) ProviderOption {
 		info.Tags = tags
 	}
 }
+
+// 常用凭证名称。
+const (
+	CredentialToken  = "token"
+	CredentialAPIKey = "api_key"
+)
+
+// Credential 描述 Provider 访问数据源所需的凭证。
+type Credential struct {
+	Key string // 凭证名称, 如 api_key
+	Env string // 约定的环境变量, 如 POLYGON_API_KEY
+}
+
+// KeyedProvider 由需要凭证的 Provider 实现，声明其必需的凭证。
+// 凭证缺失时请求必然失败，调用方应跳过注册该 Provider。
+type KeyedProvider interface {
+	Credentials() []Credential
+}
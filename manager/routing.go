@@ -0,0 +1,53 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/souloss/quantds/domain"
+)
+
+// SymbolRequest is implemented by requests that target specific symbols.
+// The manager only routes them to providers whose CanHandle accepts every
+// symbol.
+type SymbolRequest interface {
+	RequestSymbols() []string
+}
+
+// MarketRequest is implemented by requests scoped to a single market.
+// The manager only routes them to providers listing that market in
+// SupportedMarkets.
+type MarketRequest interface {
+	RequestMarket() domain.Market
+}
+
+// requestMarket returns the market targeted by req, or an empty market when
+// req does not implement MarketRequest.
+func requestMarket(req any) domain.Market {
+	if r, ok := req.(MarketRequest); ok {
+		return r.RequestMarket()
+	}
+	return ""
+}
+
+// checkProvider reports why p cannot serve a request for the given market and
+// symbols, or an empty string if it can.
+func checkProvider[Req, Resp any](p Provider[Req, Resp], market domain.Market, symbols []string) string {
+	if market != "" {
+		supported := false
+		for _, m := range p.SupportedMarkets() {
+			if m == market {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Sprintf("market %s not supported", market)
+		}
+	}
+	for _, s := range symbols {
+		if !p.CanHandle(s) {
+			return fmt.Sprintf("cannot handle symbol %s", s)
+		}
+	}
+	return ""
+}
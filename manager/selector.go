@@ -1,6 +1,10 @@
 package manager
 
-import "sort"
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+)
 
 type Selector interface {
 	Select(providers []ProviderInfo) []string
@@ -31,6 +35,9 @@ func (s *PrioritySelector) Select(providers []ProviderInfo) []string {
 	return names
 }
 
+// WeightedSelector orders providers by weighted random sampling without
+// replacement, so a provider with twice the weight is twice as likely to be
+// tried first. Providers with a non-positive weight are always tried last.
 type WeightedSelector struct{}
 
 func NewWeightedSelector() *WeightedSelector {
@@ -42,16 +49,27 @@ func (s *WeightedSelector) Select(providers []ProviderInfo) []string {
 		return nil
 	}
 
-	sorted := make([]ProviderInfo, len(providers))
-	copy(sorted, providers)
+	type keyed struct {
+		name string
+		key  float64
+	}
+	sorted := make([]keyed, len(providers))
+	for i, p := range providers {
+		key := math.Inf(-1)
+		if p.Weight > 0 {
+			// Efraimidis-Spirakis: key = u^(1/w)
+			key = math.Pow(rand.Float64(), 1/float64(p.Weight))
+		}
+		sorted[i] = keyed{name: p.Name, key: key}
+	}
 
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Weight > sorted[j].Weight
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].key > sorted[j].key
 	})
 
 	names := make([]string, len(sorted))
 	for i, p := range sorted {
-		names[i] = p.Name
+		names[i] = p.name
 	}
 	return names
 }
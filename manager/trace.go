@@ -13,6 +13,9 @@ type RequestTrace struct {
 	Requests  []*request.Record
 	TotalTime time.Duration
 	StartTime time.Time
+	// Coalesced is set when the fetch joined an identical in-flight fetch
+	// and the requests were made on behalf of another caller.
+	Coalesced bool
 }
 
 func NewRequestTrace(provider string) *RequestTrace {
@@ -66,6 +69,19 @@ func (t *RequestTrace) Finish() {
 	t.TotalTime = time.Since(t.StartTime)
 }
 
+// coalescedCopy returns a copy of the trace with its own FetchID and records
+// tagged with request.TagCoalesced.
+func (t *RequestTrace) coalescedCopy() *RequestTrace {
+	cp := *t
+	cp.FetchID = generateFetchID()
+	cp.Coalesced = true
+	cp.Requests = make([]*request.Record, len(t.Requests))
+	for i, r := range t.Requests {
+		cp.Requests[i] = request.CoalescedRecord(r)
+	}
+	return &cp
+}
+
 type fetchIDGenerator struct {
 	counter uint64
 }
@@ -0,0 +1,121 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/souloss/quantds/domain"
+	"github.com/souloss/quantds/domain/calendar"
+)
+
+// TTLPolicy decides how long a fetch result stays cached. A zero duration
+// keeps the fetch TTL of the cache.
+type TTLPolicy interface {
+	TTL(ctx context.Context, req any, now time.Time) time.Duration
+}
+
+// WithCacheTTL sets the policy deciding the fetch TTL of each result stored
+// by the cache of WithTwoLevelCache.
+func WithCacheTTL[Req, Resp any](policy TTLPolicy) ManagerOption[Req, Resp] {
+	return func(m *Manager[Req, Resp]) {
+		m.ttlPolicy = policy
+	}
+}
+
+// HistoryRequest is implemented by requests for a range of past data, such
+// as klines.
+type HistoryRequest interface {
+	// RequestEnd returns the end of the requested range, or zero for data
+	// up to now.
+	RequestEnd() time.Time
+	// RequestIntraday reports whether the data has bars shorter than a day.
+	RequestIntraday() bool
+}
+
+// MarketHoursTTL caches results for a short time while the exchange of a
+// request is trading and for a long time while it is closed. A result never
+// outlives the session boundary following it, so quotes cached overnight
+// expire at the open and intraday results expire at the close.
+//
+// A HistoryRequest for daily or longer bars ending before the current
+// trading day is settled: it is cached for the long time, regardless of the
+// session.
+//
+// The exchange is taken from the first symbol of a SymbolRequest, or from
+// the default exchange of the market of a MarketRequest. Requests without an
+// exchange, or for exchanges the calendar does not know, keep the fetch TTL
+// of the cache.
+type MarketHoursTTL struct {
+	calendar *calendar.Calendar
+	open     time.Duration
+	closed   time.Duration
+}
+
+// NewMarketHoursTTL creates a MarketHoursTTL. A nil calendar uses the
+// built-in trading rules.
+func NewMarketHoursTTL(cal *calendar.Calendar, open, closed time.Duration) *MarketHoursTTL {
+	if cal == nil {
+		cal = calendar.New()
+	}
+	return &MarketHoursTTL{calendar: cal, open: open, closed: closed}
+}
+
+// TTL implements TTLPolicy.
+func (p *MarketHoursTTL) TTL(ctx context.Context, req any, now time.Time) time.Duration {
+	exchange := requestExchange(req)
+	if exchange == "" {
+		return 0
+	}
+	if r, ok := req.(HistoryRequest); ok && settled(r, exchange, now) {
+		return p.closed
+	}
+	open, next, err := p.calendar.State(ctx, exchange, now)
+	if err != nil {
+		return 0
+	}
+	ttl := p.closed
+	if open {
+		ttl = p.open
+	}
+	if !next.IsZero() {
+		if until := next.Sub(now); until < ttl {
+			ttl = until
+		}
+	}
+	return ttl
+}
+
+// settled reports whether the data of r can no longer change: it has daily or
+// longer bars and ends before the day of now at exchange.
+func settled(r HistoryRequest, exchange domain.Exchange, now time.Time) bool {
+	end := r.RequestEnd()
+	if end.IsZero() || r.RequestIntraday() {
+		return false
+	}
+	now = now.In(calendar.Location(exchange))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return end.Before(today)
+}
+
+// requestExchange returns the exchange targeted by req, or an empty exchange
+// when it cannot be derived.
+func requestExchange(req any) domain.Exchange {
+	if r, ok := req.(SymbolRequest); ok {
+		for _, s := range r.RequestSymbols() {
+			var sym domain.Symbol
+			if err := sym.Parse(s); err != nil {
+				continue
+			}
+			if sym.Exchange != "" {
+				return sym.Exchange
+			}
+			return domain.MarketConfigs[sym.Market].DefaultExchange
+		}
+	}
+	if market := requestMarket(req); market != "" {
+		return domain.MarketConfigs[market].DefaultExchange
+	}
+	return ""
+}
+
+var _ TTLPolicy = (*MarketHoursTTL)(nil)
@@ -0,0 +1,302 @@
+package request
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultCacheMaxEntries = 1024
+	DefaultCacheMaxBytes   = 64 << 20
+)
+
+// defaultKeyHeaders are the request headers that distinguish otherwise
+// identical requests in the cache key.
+var defaultKeyHeaders = []string{"Authorization", "Cookie", "X-Api-Key", "X-Auth-Token", "Accept"}
+
+// BuildCacheKey hashes the method, URL, body and the credential and content
+// negotiation headers of req.
+func BuildCacheKey(req Request) string {
+	return buildCacheKey(req, defaultKeyHeaders)
+}
+
+func buildCacheKey(req Request, keyHeaders []string) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte(req.URL))
+	h.Write(req.Body)
+	for _, name := range keyHeaders {
+		if v, ok := headerValue(req.Headers, name); ok {
+			h.Write([]byte{0})
+			h.Write([]byte(strings.ToLower(name)))
+			h.Write([]byte{':'})
+			h.Write([]byte(v))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CachingClient caches successful GET responses of the wrapped client in a
+// concurrency-safe LRU bounded by entry count and bytes.
+//
+// Entries live for the client TTL unless the response carries Cache-Control
+// or Expires: no-store, no-cache and max-age=0 responses are not cached, and
+// max-age or Expires replace the TTL.
+type CachingClient struct {
+	client     Client
+	ttl        time.Duration
+	maxEntries int
+	maxBytes   int64
+	keyHeaders []string
+
+	mu    sync.Mutex
+	lru   *list.List // front = most recently used
+	cache map[string]*list.Element
+	size  int64
+
+	flight SingleFlight[cachedCall]
+}
+
+type cachedResponse struct {
+	key       string
+	resp      Response
+	size      int64
+	expiresAt time.Time
+}
+
+type cachedCall struct {
+	resp   Response
+	record *Record
+}
+
+// CachingOption configures a CachingClient.
+type CachingOption func(*CachingClient)
+
+// WithCacheMaxEntries limits the number of cached responses. Zero means unlimited.
+func WithCacheMaxEntries(n int) CachingOption {
+	return func(c *CachingClient) {
+		c.maxEntries = n
+	}
+}
+
+// WithCacheMaxBytes limits the total size of cached responses. Zero means unlimited.
+func WithCacheMaxBytes(n int64) CachingOption {
+	return func(c *CachingClient) {
+		c.maxBytes = n
+	}
+}
+
+// WithCacheKeyHeaders adds request headers that take part in the cache key.
+func WithCacheKeyHeaders(headers ...string) CachingOption {
+	return func(c *CachingClient) {
+		c.keyHeaders = append(c.keyHeaders, headers...)
+	}
+}
+
+func NewCachingClient(client Client, ttl time.Duration, opts ...CachingOption) *CachingClient {
+	c := &CachingClient{
+		client:     client,
+		ttl:        ttl,
+		maxEntries: DefaultCacheMaxEntries,
+		maxBytes:   DefaultCacheMaxBytes,
+		keyHeaders: append([]string(nil), defaultKeyHeaders...),
+		lru:        list.New(),
+		cache:      make(map[string]*list.Element),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	sort.Strings(c.keyHeaders)
+	return c
+}
+
+// Do serves GET requests from the cache. Concurrent identical requests that
+// miss the cache share a single upstream call; the records returned to the
+// callers that joined it are tagged with TagCoalesced.
+func (c *CachingClient) Do(ctx context.Context, req Request) (Response, *Record, error) {
+	if req.Method == "GET" && c.ttl > 0 {
+		key := buildCacheKey(req, c.keyHeaders)
+		if resp, ok := c.get(key); ok {
+			record := NewRecord()
+			record.Request = req
+			record.FromCache = true
+			record.Response = resp
+			return resp, record, nil
+		}
+
+		call, err, coalesced := c.flight.Do(ctx, key, func(ctx context.Context) (cachedCall, error) {
+			resp, record, err := c.client.Do(ctx, req)
+			if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				c.set(key, resp)
+			}
+			return cachedCall{resp: resp, record: record}, err
+		})
+		// The shared record is copied for every caller so that each one
+		// can tag its own record.
+		if coalesced {
+			return call.resp, CoalescedRecord(call.record), err
+		}
+		return call.resp, call.record.Clone(), err
+	}
+
+	return c.client.Do(ctx, req)
+}
+
+func (c *CachingClient) get(key string) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.cache[key]
+	if !ok {
+		return Response{}, false
+	}
+	entry := elem.Value.(*cachedResponse)
+	if !time.Now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return Response{}, false
+	}
+	c.lru.MoveToFront(elem)
+
+	// Callers own the returned response
+	resp := entry.resp
+	resp.Body = bytes.Clone(resp.Body)
+	resp.Headers = maps.Clone(resp.Headers)
+	return resp, true
+}
+
+func (c *CachingClient) set(key string, resp Response) {
+	ttl, ok := responseTTL(resp.Headers, c.ttl, time.Now())
+	if !ok {
+		return
+	}
+
+	size := int64(len(key) + len(resp.Body))
+	for k, v := range resp.Headers {
+		size += int64(len(k) + len(v))
+	}
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.cache[key]; ok {
+		c.remove(elem)
+	}
+	resp.Body = bytes.Clone(resp.Body)
+	resp.Headers = maps.Clone(resp.Headers)
+	c.cache[key] = c.lru.PushFront(&cachedResponse{
+		key:       key,
+		resp:      resp,
+		size:      size,
+		expiresAt: time.Now().Add(ttl),
+	})
+	c.size += size
+
+	for c.lru.Len() > 0 &&
+		((c.maxBytes > 0 && c.size > c.maxBytes) || (c.maxEntries > 0 && c.lru.Len() > c.maxEntries)) {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove drops a cache entry. The caller must hold the lock.
+func (c *CachingClient) remove(elem *list.Element) {
+	entry := elem.Value.(*cachedResponse)
+	c.lru.Remove(elem)
+	delete(c.cache, entry.key)
+	c.size -= entry.size
+}
+
+// Len returns the number of cached responses.
+func (c *CachingClient) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Size returns the total size of cached responses in bytes.
+func (c *CachingClient) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *CachingClient) Close() {
+	c.client.Close()
+}
+
+func (c *CachingClient) ClearCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.cache = make(map[string]*list.Element)
+	c.size = 0
+}
+
+var _ Client = (*CachingClient)(nil)
+
+// responseTTL returns how long a response may be cached according to its
+// Cache-Control and Expires headers, falling back to def. It returns false
+// when the response must not be cached.
+func responseTTL(headers map[string]string, def time.Duration, now time.Time) (time.Duration, bool) {
+	if cc, ok := headerValue(headers, "Cache-Control"); ok {
+		maxAge := -1
+		for _, directive := range strings.Split(cc, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-store", "no-cache":
+				return 0, false
+			case "max-age":
+				if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+					maxAge = seconds
+				}
+			}
+		}
+		if maxAge == 0 {
+			return 0, false
+		}
+		if maxAge > 0 {
+			return time.Duration(maxAge) * time.Second, true
+		}
+	}
+
+	if exp, ok := headerValue(headers, "Expires"); ok {
+		expires, err := http.ParseTime(exp)
+		if err != nil {
+			// Invalid dates such as "0" mean already expired
+			return 0, false
+		}
+		if date, ok := headerValue(headers, "Date"); ok {
+			if t, err := http.ParseTime(date); err == nil {
+				now = t
+			}
+		}
+		ttl := expires.Sub(now)
+		return ttl, ttl > 0
+	}
+
+	return def, true
+}
+
+func headerValue(headers map[string]string, name string) (string, bool) {
+	if v, ok := headers[name]; ok {
+		return v, true
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,145 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBuildCacheKey_Headers(t *testing.T) {
+	base := Request{Method: "GET", URL: "http://example.com"}
+	alice := Request{Method: "GET", URL: "http://example.com", Headers: map[string]string{"Authorization": "Bearer alice"}}
+	bob := Request{Method: "GET", URL: "http://example.com", Headers: map[string]string{"authorization": "Bearer bob"}}
+	agent := Request{Method: "GET", URL: "http://example.com", Headers: map[string]string{"User-Agent": "test"}}
+
+	if BuildCacheKey(base) == BuildCacheKey(alice) || BuildCacheKey(alice) == BuildCacheKey(bob) {
+		t.Error("Authorization should be part of the cache key")
+	}
+	if BuildCacheKey(base) != BuildCacheKey(agent) {
+		t.Error("User-Agent should not be part of the cache key")
+	}
+
+	custom := buildCacheKey(agent, []string{"User-Agent"})
+	if custom == buildCacheKey(base, []string{"User-Agent"}) {
+		t.Error("configured key headers should be part of the cache key")
+	}
+}
+
+func TestResponseTTL(t *testing.T) {
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantTTL time.Duration
+		wantOK  bool
+	}{
+		{"default", nil, time.Minute, true},
+		{"max-age", map[string]string{"Cache-Control": "public, max-age=30"}, 30 * time.Second, true},
+		{"max-age zero", map[string]string{"Cache-Control": "max-age=0"}, 0, false},
+		{"no-store", map[string]string{"cache-control": "max-age=30, no-store"}, 0, false},
+		{"no-cache", map[string]string{"Cache-Control": "no-cache"}, 0, false},
+		{"expires", map[string]string{
+			"Date":    "Mon, 03 Jun 2024 12:00:00 GMT",
+			"Expires": "Mon, 03 Jun 2024 12:05:00 GMT",
+		}, 5 * time.Minute, true},
+		{"expired", map[string]string{"Expires": "Mon, 03 Jun 2024 11:00:00 GMT"}, 0, false},
+		{"invalid expires", map[string]string{"Expires": "0"}, 0, false},
+		{"max-age over expires", map[string]string{
+			"Cache-Control": "max-age=10",
+			"Expires":       "Mon, 03 Jun 2024 12:05:00 GMT",
+		}, 10 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, ok := responseTTL(tt.headers, time.Minute, now)
+			if ok != tt.wantOK || (ok && ttl != tt.wantTTL) {
+				t.Errorf("responseTTL() = %v, %v, want %v, %v", ttl, ok, tt.wantTTL, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCachingClient_Bounded(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Query().Get("nocache") != "" {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer ts.Close()
+
+	client := NewCachingClient(NewClient(DefaultConfig()), time.Minute, WithCacheMaxEntries(2))
+	defer client.Close()
+
+	get := func(query string) {
+		t.Helper()
+		if _, _, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL + "?" + query}); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+
+	get("a=1")
+	get("b=1")
+	get("a=1") // a becomes most recently used
+	get("c=1") // evicts b
+	if client.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", client.Len())
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("upstream calls = %d, want 3", n)
+	}
+	get("b=1")
+	if n := calls.Load(); n != 4 {
+		t.Errorf("upstream calls after eviction = %d, want 4", n)
+	}
+
+	get("nocache=1")
+	get("nocache=1")
+	if n := calls.Load(); n != 6 {
+		t.Errorf("no-store responses should not be cached, calls = %d", n)
+	}
+
+	small := NewCachingClient(NewClient(DefaultConfig()), time.Minute, WithCacheMaxBytes(200))
+	defer small.Close()
+	for i := range 10 {
+		small.Do(context.Background(), Request{Method: "GET", URL: fmt.Sprintf("%s?i=%d", ts.URL, i)})
+	}
+	if small.Size() > 200 {
+		t.Errorf("Size() = %d, want <= 200", small.Size())
+	}
+}
+
+func TestCachingClient_Concurrent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer ts.Close()
+
+	client := NewCachingClient(NewClient(DefaultConfig()), time.Minute, WithCacheMaxEntries(5))
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			want := fmt.Sprintf("i=%d", i%10)
+			resp, _, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL + "?" + want})
+			if err != nil || string(resp.Body) != want {
+				t.Errorf("Do() = %q, %v, want %q", resp.Body, err, want)
+			}
+			if i%7 == 0 {
+				client.ClearCache()
+			}
+		}()
+	}
+	wg.Wait()
+}
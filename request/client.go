@@ -2,12 +2,11 @@ package request
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
+	"net/http"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
 	"resty.dev/v3"
 )
 
@@ -19,37 +18,14 @@ type Client interface {
 type ClientImpl struct {
 	client   *resty.Client
 	executor failsafe.Executor[Response]
+	limiter  ratelimiter.RateLimiter[Response]
+	weight   func(Request) uint
+	budgets  *budgets
+	deadline time.Duration
 }
 
 func NewClient(cfg *Config) *ClientImpl {
-	if cfg == nil {
-		cfg = DefaultConfig()
-	}
-
-	client := resty.New()
-	client.SetRetryCount(0)
-
-	var policies []failsafe.Policy[Response]
-
-	if cfg.Timeout != nil {
-		policies = append(policies, cfg.Timeout)
-	}
-	if cfg.CircuitBreaker != nil {
-		policies = append(policies, cfg.CircuitBreaker)
-	}
-	if cfg.RetryPolicy != nil {
-		policies = append(policies, cfg.RetryPolicy)
-	}
-	if cfg.RateLimiter != nil {
-		policies = append(policies, cfg.RateLimiter)
-	}
-
-	executor := failsafe.With[Response](policies...)
-
-	return &ClientImpl{
-		client:   client,
-		executor: executor,
-	}
+	return NewClientWithResty(resty.New(), cfg)
 }
 
 func NewClientWithResty(restyClient *resty.Client, cfg *Config) *ClientImpl {
@@ -62,42 +38,79 @@ func NewClientWithResty(restyClient *resty.Client, cfg *Config) *ClientImpl {
 		cfg = DefaultConfig()
 	}
 
-	var policies []failsafe.Policy[Response]
-
-	if cfg.Timeout != nil {
-		policies = append(policies, cfg.Timeout)
-	}
-	if cfg.CircuitBreaker != nil {
-		policies = append(policies, cfg.CircuitBreaker)
-	}
-	if cfg.RetryPolicy != nil {
-		policies = append(policies, cfg.RetryPolicy)
-	}
-	if cfg.RateLimiter != nil {
-		policies = append(policies, cfg.RateLimiter)
-	}
-
-	executor := failsafe.With[Response](policies...)
-
-	return &ClientImpl{
+	c := &ClientImpl{
 		client:   restyClient,
-		executor: executor,
+		executor: failsafe.With[Response](cfg.policies()...),
+		weight:   cfg.Weight,
+		budgets:  newBudgets(cfg.UsageLimit),
+		deadline: cfg.Deadline,
 	}
+	c.limiter, _ = cfg.RateLimiter.(ratelimiter.RateLimiter[Response])
+	return c
 }
 
 func (c *ClientImpl) Do(ctx context.Context, req Request) (Response, *Record, error) {
 	record := NewRecord()
 	record.Request = req
 
+	if c.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.deadline)
+		defer cancel()
+	}
+
+	host := hostOf(req.URL)
 	var attempt int
-	resp, execErr := c.executor.GetWithExecution(func(exec failsafe.Execution[Response]) (Response, error) {
+	resp, execErr := c.executor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[Response]) (Response, error) {
 		attempt = exec.Attempts()
-		return c.doHTTP(ctx, req)
+		weight := c.weightOf(req)
+		if err := c.budgets.throttle(exec.Context(), host, weight); err != nil {
+			return Response{}, err
+		}
+		if err := c.acquireWeight(exec.Context(), req); err != nil {
+			c.budgets.release(host, weight)
+			return Response{}, err
+		}
+		resp, err := c.doHTTP(exec.Context(), req)
+		rl, _ := c.budgets.update(host, weight, resp.Headers, time.Now())
+		if err != nil {
+			return resp, err
+		}
+		if throttled(resp.StatusCode, rl) {
+			// surface the rejection to the retry policy so it waits as asked,
+			// unless the request cannot wait that long
+			callErr := ClassifyError(nil, resp.StatusCode)
+			callErr.RetryAfter = rl.RetryAfter
+			callErr.Reset = rl.Reset
+			if rl.RetryAfter > 0 {
+				callErr.Reset = time.Now().Add(rl.RetryAfter)
+			}
+			if outlasts(exec.Context(), rl.RetryAfter) {
+				callErr.Cause = ErrBudgetExhausted
+			}
+			return resp, callErr
+		}
+		if req.Check != nil {
+			if err := req.Check(resp); err != nil {
+				callErr := ClassifyError(err, resp.StatusCode)
+				if callErr.StatusCode == 0 {
+					callErr.StatusCode = resp.StatusCode
+				}
+				return resp, callErr
+			}
+		}
+		return resp, nil
 	})
 
 	record.Attempt = attempt
 	record.Duration = time.Since(record.StartTime)
 	record.Response = resp
+	if rl, ok := c.budgets.get(host); ok {
+		record.RateLimit = &rl
+		for k, v := range rl.Tags() {
+			record.Tags[k] = v
+		}
+	}
 
 	if execErr != nil {
 		callErr := ClassifyError(execErr, resp.StatusCode)
@@ -114,6 +127,39 @@ func (c *ClientImpl) Do(ctx context.Context, req Request) (Response, *Record, er
 	return resp, record, nil
 }
 
+// throttled reports whether a response rejected the request for exceeding
+// the host's rate limit.
+func throttled(statusCode int, rl RateLimit) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		return true
+	case http.StatusServiceUnavailable:
+		return rl.RetryAfter > 0
+	}
+	return false
+}
+
+func (c *ClientImpl) weightOf(req Request) int {
+	if c.weight != nil {
+		if w := c.weight(req); w > 0 {
+			return int(w)
+		}
+	}
+	return 1
+}
+
+// acquireWeight takes the permits a weighted request needs beyond the one
+// the rate limiter policy already took for the attempt.
+func (c *ClientImpl) acquireWeight(ctx context.Context, req Request) error {
+	if c.limiter == nil || c.weight == nil {
+		return nil
+	}
+	if w := c.weight(req); w > 1 {
+		return c.limiter.AcquirePermits(ctx, w-1)
+	}
+	return nil
+}
+
 func (c *ClientImpl) doHTTP(ctx context.Context, req Request) (Response, error) {
 	r := c.client.R().SetContext(ctx)
 
@@ -184,67 +230,3 @@ func (c *NoopClient) Do(_ context.Context, req Request) (Response, *Record, erro
 func (c *NoopClient) Close() {}
 
 var _ Client = (*NoopClient)(nil)
-
-func BuildCacheKey(req Request) string {
-	h := sha256.New()
-	h.Write([]byte(req.Method))
-	h.Write([]byte(req.URL))
-	h.Write(req.Body)
-	return hex.EncodeToString(h.Sum(nil))
-}
-
-type CachingClient struct {
-	client Client
-	cache  map[string]cachedResponse
-	ttl    time.Duration
-}
-
-type cachedResponse struct {
-	data      []byte
-	expiresAt time.Time
-}
-
-func NewCachingClient(client Client, ttl time.Duration) *CachingClient {
-	return &CachingClient{
-		client: client,
-		cache:  make(map[string]cachedResponse),
-		ttl:    ttl,
-	}
-}
-
-func (c *CachingClient) Do(ctx context.Context, req Request) (Response, *Record, error) {
-	if req.Method == "GET" && c.ttl > 0 {
-		key := BuildCacheKey(req)
-		if cached, ok := c.cache[key]; ok && time.Now().Before(cached.expiresAt) {
-			var resp Response
-			if err := json.Unmarshal(cached.data, &resp); err == nil {
-				record := NewRecord()
-				record.Request = req
-				record.FromCache = true
-				record.Response = resp
-				return resp, record, nil
-			}
-		}
-
-		resp, record, err := c.client.Do(ctx, req)
-		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			if data, merr := json.Marshal(resp); merr == nil {
-				c.cache[key] = cachedResponse{
-					data:      data,
-					expiresAt: time.Now().Add(c.ttl),
-				}
-			}
-		}
-		return resp, record, err
-	}
-
-	return c.client.Do(ctx, req)
-}
-
-func (c *CachingClient) Close() {
-	c.client.Close()
-}
-
-func (c *CachingClient) ClearCache() {
-	c.cache = make(map[string]cachedResponse)
-}
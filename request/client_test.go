@@ -2,11 +2,15 @@ package request
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
 	"github.com/failsafe-go/failsafe-go/timeout"
 )
 
@@ -132,6 +136,7 @@ func TestClient_Do_Timeout(t *testing.T) {
 
 	cfg := DefaultConfig()
 	cfg.Timeout = timeout.New[Response](100 * time.Millisecond)
+	cfg.RetryPolicy = nil
 
 	client := NewClient(cfg)
 	defer client.Close()
@@ -244,6 +249,53 @@ func TestCachingClient(t *testing.T) {
 	}
 }
 
+func TestCachingClient_Coalesce(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`response`))
+	}))
+	defer ts.Close()
+
+	client := NewCachingClient(NewClient(DefaultConfig()), 5*time.Minute)
+	defer client.Close()
+
+	const callers = 20
+	req := Request{Method: "GET", URL: ts.URL}
+	records := make([]*Record, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, record, err := client.Do(context.Background(), req)
+			if err != nil || string(resp.Body) != "response" {
+				t.Errorf("Do() = %q, %v", resp.Body, err)
+			}
+			records[i] = record
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("upstream calls = %d, want 1", n)
+	}
+	coalesced := 0
+	for _, r := range records {
+		if r.Tags[TagCoalesced] == "true" {
+			coalesced++
+		}
+	}
+	if coalesced != callers-1 {
+		t.Errorf("coalesced records = %d, want %d", coalesced, callers-1)
+	}
+}
+
 func TestBuildCacheKey(t *testing.T) {
 	req1 := Request{Method: "GET", URL: "http://example.com", Body: []byte("test")}
 	req2 := Request{Method: "GET", URL: "http://example.com", Body: []byte("test")}
@@ -261,3 +313,182 @@ func TestBuildCacheKey(t *testing.T) {
 		t.Error("Different methods should have different cache keys")
 	}
 }
+
+func TestClient_Do_WeightedRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Config{
+		RateLimiter: BurstyRateLimiter(5, time.Hour, 0),
+		Weight: func(req Request) uint {
+			if req.Method == "POST" {
+				return 3
+			}
+			return 0
+		},
+	})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	for _, method := range []string{"POST", "GET"} {
+		if _, _, err := client.Do(ctx, Request{Method: method, URL: ts.URL}); err != nil {
+			t.Fatalf("Do(%s) error = %v", method, err)
+		}
+	}
+	// 4 of 5 permits used: a weight 3 request cannot be served
+	_, record, err := client.Do(ctx, Request{Method: "POST", URL: ts.URL})
+	if err == nil {
+		t.Fatal("Do() should fail once the weight budget is spent")
+	}
+	if record.Error == nil || (record.Error.Type != ErrorTypeTimeout && record.Error.Type != ErrorTypeCanceled) {
+		t.Errorf("Error = %v, want the wait for permits to end with the context", record.Error)
+	}
+	if _, record, _ := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL}); record.Error == nil || record.Error.Type != ErrorTypeRateLimited {
+		t.Errorf("Error = %v, want %s", record.Error, ErrorTypeRateLimited)
+	}
+}
+
+func TestClient_Do_Check(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch {
+		case r.URL.Path == "/missing":
+			w.Write([]byte(`{"data": null}`))
+		case n == 1:
+			w.Write([]byte(`{"note": "slow down"}`))
+		default:
+			w.Write([]byte(`{"data": {}}`))
+		}
+	}))
+	defer ts.Close()
+
+	check := func(resp Response) error {
+		switch string(resp.Body) {
+		case `{"note": "slow down"}`:
+			return NewVendorError("test", ErrorTypeRateLimited, "", "slow down")
+		case `{"data": null}`:
+			return NewVendorError("test", ErrorTypeNoData, "", "data is null")
+		}
+		return nil
+	}
+
+	client := NewClient(DefaultConfig())
+	defer client.Close()
+
+	_, record, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL, Check: check})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if record.Attempt != 2 {
+		t.Errorf("Attempt = %d, want the rate limited body retried", record.Attempt)
+	}
+
+	calls.Store(0)
+	_, record, err = client.Do(context.Background(), Request{Method: "GET", URL: ts.URL + "/missing", Check: check})
+	if !errors.Is(err, ErrNoData) || record.Error.StatusCode != http.StatusOK {
+		t.Fatalf("Do() error = %v, want %v with status 200", err, ErrNoData)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server calls = %d, want no data not retried", calls.Load())
+	}
+}
+
+func TestClient_Do_TimeoutPerAttempt(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := DefaultConfig(WithTimeout(timeout.New[Response](200 * time.Millisecond)))
+	client := NewClient(cfg)
+	defer client.Close()
+
+	_, record, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL})
+	if err != nil {
+		t.Fatalf("Do() error = %v, want the retry to succeed", err)
+	}
+	if record.Attempt != 2 {
+		t.Errorf("Attempt = %d, want 2", record.Attempt)
+	}
+}
+
+func TestClient_Do_Deadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(DefaultConfig(WithDeadline(300 * time.Millisecond)))
+	defer client.Close()
+
+	start := time.Now()
+	_, record, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL})
+	if err == nil {
+		t.Fatal("Expected deadline error")
+	}
+	if record.Error.Type != ErrorTypeTimeout {
+		t.Errorf("Error.Type = %s, want %s", record.Error.Type, ErrorTypeTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do() took %v, want the deadline to stop it", elapsed)
+	}
+}
+
+func TestDefaultCircuitBreaker_IgnoresCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	cfg := DefaultConfig()
+	cfg.RetryPolicy = nil
+	breaker := cfg.CircuitBreaker.(circuitbreaker.CircuitBreaker[Response])
+	client := NewClient(cfg)
+	defer client.Close()
+
+	for i := 0; i < 6; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		if _, _, err := client.Do(ctx, Request{Method: "GET", URL: ts.URL}); err == nil {
+			t.Fatal("Expected canceled error")
+		}
+	}
+	if !breaker.IsClosed() {
+		t.Error("circuit breaker opened on canceled requests")
+	}
+
+	for _, err := range []error{context.Canceled, &RequestError{Type: ErrorTypeCanceled}, ErrNoData} {
+		if isHostFailure(err) {
+			t.Errorf("isHostFailure(%v) = true, want false", err)
+		}
+	}
+	if !isHostFailure(&RequestError{Type: ErrorTypeServer}) {
+		t.Error("isHostFailure(server error) = false, want true")
+	}
+}
+
+func TestDefaultConfig_RateLimiter(t *testing.T) {
+	if cfg := DefaultConfig(); cfg.RateLimiter == nil {
+		t.Error("DefaultConfig() should install the default rate limiter")
+	}
+	limiter := BurstyRateLimiter(1, time.Minute, 0)
+	if cfg := DefaultConfig(WithRateLimiter(limiter)); cfg.RateLimiter != limiter {
+		t.Error("WithRateLimiter() should replace the default rate limiter")
+	}
+}
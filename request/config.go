@@ -1,6 +1,8 @@
 package request
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
@@ -14,7 +16,20 @@ type Config struct {
 	RetryPolicy    failsafe.Policy[Response]
 	CircuitBreaker failsafe.Policy[Response]
 	RateLimiter    failsafe.Policy[Response]
-	Timeout        failsafe.Policy[Response]
+	// Timeout bounds each attempt; a timed out attempt may be retried.
+	Timeout failsafe.Policy[Response]
+
+	// Deadline bounds a whole request, including retries, backoff and rate
+	// limiter waits. 0 means no deadline beyond the caller's context.
+	Deadline time.Duration
+
+	// Weight returns how many RateLimiter permits a request consumes, such as
+	// an exchange's request weight. nil, or a result of 0, counts 1 per request.
+	Weight func(Request) uint
+
+	// UsageLimit is the budget per window of hosts whose rate limit headers
+	// only report usage, such as Binance's X-MBX-USED-WEIGHT-1M.
+	UsageLimit int
 }
 
 type ConfigOption func(*Config)
@@ -43,11 +58,33 @@ func WithTimeout(policy failsafe.Policy[Response]) ConfigOption {
 	}
 }
 
+func WithDeadline(d time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.Deadline = d
+	}
+}
+
+func WithWeight(weight func(Request) uint) ConfigOption {
+	return func(c *Config) {
+		c.Weight = weight
+	}
+}
+
+func WithUsageLimit(limit int) ConfigOption {
+	return func(c *Config) {
+		c.UsageLimit = limit
+	}
+}
+
+// DefaultConfig returns the default retry, circuit breaker, rate limiter,
+// timeout and deadline, overridden by opts.
 func DefaultConfig(opts ...ConfigOption) *Config {
 	cfg := &Config{
 		RetryPolicy:    DefaultRetryPolicy(),
 		CircuitBreaker: DefaultCircuitBreaker(),
+		RateLimiter:    DefaultRateLimiter(),
 		Timeout:        DefaultTimeout(),
+		Deadline:       DefaultDeadline,
 	}
 	for _, opt := range opts {
 		opt(cfg)
@@ -55,28 +92,115 @@ func DefaultConfig(opts ...ConfigOption) *Config {
 	return cfg
 }
 
+const maxRetryDelay = 30 * time.Second
+
+// retryAfter returns how long the server asked to wait before retrying err.
+func retryAfter(err error) time.Duration {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.RetryAfter
+	}
+	return 0
+}
+
 func DefaultRetryPolicy() failsafe.Policy[Response] {
 	return retrypolicy.NewBuilder[Response]().
 		HandleIf(func(resp Response, err error) bool {
-			return IsRetryableError(err)
+			// a local rate limiter that could not grant a permit in time, or
+			// a host that asks to wait longer than the maximum backoff, will
+			// not do better on a retry
+			if errors.Is(err, ratelimiter.ErrExceeded) || errors.Is(err, ErrBudgetExhausted) {
+				return false
+			}
+			return IsRetryableError(err) && retryAfter(err) <= maxRetryDelay
 		}).
 		WithMaxRetries(3).
-		WithBackoff(time.Second, 30*time.Second).
+		WithBackoff(time.Second, maxRetryDelay).
+		WithDelayFunc(func(exec failsafe.ExecutionAttempt[Response]) time.Duration {
+			if d := retryAfter(exec.LastError()); d > 0 {
+				return d
+			}
+			return -1
+		}).
 		Build()
 }
 
 func DefaultCircuitBreaker() failsafe.Policy[Response] {
 	return circuitbreaker.NewBuilder[Response]().
+		HandleIf(func(_ Response, err error) bool {
+			return isHostFailure(err)
+		}).
 		WithFailureRateThreshold(0.5, 5, time.Minute).
 		WithDelay(30 * time.Second).
 		WithSuccessThreshold(3).
 		Build()
 }
 
+// isHostFailure reports whether err counts against the health of the host.
+// A host answering that a symbol is unknown or has no data is healthy, and a
+// request its caller cancelled says nothing about the host.
+func isHostFailure(err error) bool {
+	if err == nil || IsRequestSpecific(err) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var reqErr *RequestError
+	return !errors.As(err, &reqErr) || reqErr.Type != ErrorTypeCanceled
+}
+
+// DefaultDeadline is the default total time a request may take, across all
+// of its attempts.
+const DefaultDeadline = time.Minute
+
+// DefaultTimeout returns the default per-attempt timeout.
 func DefaultTimeout() failsafe.Policy[Response] {
 	return timeout.New[Response](30 * time.Second)
 }
 
+// DefaultRateLimitPerSecond is the conservative request rate DefaultConfig
+// allows clients of vendors that do not publish a rate limit.
+const DefaultRateLimitPerSecond = 10
+
+// DefaultRateLimiter returns the rate limiter DefaultConfig installs:
+// DefaultRateLimitPerSecond requests per second, waiting up to
+// DefaultRateLimitWait for a permit.
 func DefaultRateLimiter() failsafe.Policy[Response] {
-	return ratelimiter.NewBursty[Response](100, time.Second)
+	return BurstyRateLimiter(DefaultRateLimitPerSecond, time.Second, DefaultRateLimitWait)
+}
+
+// DefaultRateLimitWait is how long a request waits for a rate limiter permit
+// in the default configurations before failing over.
+const DefaultRateLimitWait = 15 * time.Second
+
+// BurstyRateLimiter allows maxRequests per period, all of which may be used
+// at once. A request waits up to maxWait for a permit and otherwise fails
+// with an ErrorTypeRateLimited error.
+func BurstyRateLimiter(maxRequests uint, period, maxWait time.Duration) ratelimiter.RateLimiter[Response] {
+	return ratelimiter.NewBurstyBuilder[Response](maxRequests, period).WithMaxWaitTime(maxWait).Build()
+}
+
+// SmoothRateLimiter allows maxRequests per period, spread evenly over the
+// period. A request waits up to maxWait for a permit and otherwise fails
+// with an ErrorTypeRateLimited error.
+func SmoothRateLimiter(maxRequests uint, period, maxWait time.Duration) ratelimiter.RateLimiter[Response] {
+	return ratelimiter.NewSmoothBuilder[Response](maxRequests, period).WithMaxWaitTime(maxWait).Build()
+}
+
+// policies returns the configured policies from outermost to innermost. The
+// timeout is innermost so that it bounds each attempt rather than the
+// retries around it.
+func (c *Config) policies() []failsafe.Policy[Response] {
+	var policies []failsafe.Policy[Response]
+	if c.CircuitBreaker != nil {
+		policies = append(policies, c.CircuitBreaker)
+	}
+	if c.RetryPolicy != nil {
+		policies = append(policies, c.RetryPolicy)
+	}
+	if c.RateLimiter != nil {
+		policies = append(policies, c.RateLimiter)
+	}
+	if c.Timeout != nil {
+		policies = append(policies, c.Timeout)
+	}
+	return policies
 }
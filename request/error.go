@@ -1,10 +1,14 @@
 package request
 
 import (
+	"context"
 	"errors"
 	"net"
 	"net/url"
 	"strings"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
 )
 
 type ErrorType string
@@ -13,11 +17,28 @@ const (
 	ErrorTypeNone        ErrorType = ""
 	ErrorTypeNetwork     ErrorType = "network"
 	ErrorTypeTimeout     ErrorType = "timeout"
+	ErrorTypeCanceled    ErrorType = "canceled"
 	ErrorTypeRateLimited ErrorType = "rate_limited"
 	ErrorTypeAuth        ErrorType = "auth"
 	ErrorTypeServer      ErrorType = "server"
 	ErrorTypeClient      ErrorType = "client"
 	ErrorTypeUnknown     ErrorType = "unknown"
+
+	// Errors vendors report in the body of an otherwise successful response.
+	ErrorTypeQuota         ErrorType = "quota"          // daily or plan quota used up
+	ErrorTypeInvalidSymbol ErrorType = "invalid_symbol" // symbol unknown to the vendor
+	ErrorTypeNoData        ErrorType = "no_data"        // no data for the symbol or range
+	ErrorTypeMaintenance   ErrorType = "maintenance"    // upstream down for maintenance
+)
+
+// Targets for errors.Is, which matches a RequestError by its Type.
+var (
+	ErrAuth          = &RequestError{Type: ErrorTypeAuth, Message: "authentication error"}
+	ErrRateLimited   = &RequestError{Type: ErrorTypeRateLimited, Message: "rate limited"}
+	ErrQuota         = &RequestError{Type: ErrorTypeQuota, Message: "quota exhausted"}
+	ErrInvalidSymbol = &RequestError{Type: ErrorTypeInvalidSymbol, Message: "invalid symbol"}
+	ErrNoData        = &RequestError{Type: ErrorTypeNoData, Message: "no data"}
+	ErrMaintenance   = &RequestError{Type: ErrorTypeMaintenance, Message: "upstream maintenance"}
 )
 
 type RequestError struct {
@@ -25,13 +46,29 @@ type RequestError struct {
 	Message    string
 	StatusCode int
 	Cause      error
+	// RetryAfter is how long the server asked to wait before retrying,
+	// from Retry-After or the host's rate limit headers.
+	RetryAfter time.Duration
+	// Reset is when the host's rate limit budget refills, zero when unknown.
+	Reset time.Time
+	// Vendor and Code identify an error a vendor reported in the response
+	// body, e.g. tushare and 40203; empty for transport and HTTP errors.
+	Vendor string
+	Code   string
 }
 
 func (e *RequestError) Error() string {
+	msg := e.Message
+	if e.Vendor != "" {
+		msg = e.Vendor + ": " + msg
+		if e.Code != "" {
+			msg += " (code " + e.Code + ")"
+		}
+	}
 	if e.Cause != nil {
-		return e.Message + ": " + e.Cause.Error()
+		return msg + ": " + e.Cause.Error()
 	}
-	return e.Message
+	return msg
 }
 
 func (e *RequestError) Unwrap() error {
@@ -51,7 +88,8 @@ func ClassifyError(err error, statusCode int) *RequestError {
 		if statusCode >= 500 {
 			return &RequestError{Type: ErrorTypeServer, StatusCode: statusCode, Message: "server error"}
 		}
-		if statusCode == 429 {
+		// Binance answers 418 once an IP keeps sending requests after a 429
+		if statusCode == 429 || statusCode == 418 {
 			return &RequestError{Type: ErrorTypeRateLimited, StatusCode: statusCode, Message: "rate limited"}
 		}
 		if statusCode == 401 || statusCode == 403 {
@@ -68,6 +106,14 @@ func ClassifyError(err error, statusCode int) *RequestError {
 		return reqErr
 	}
 
+	if errors.Is(err, ratelimiter.ErrExceeded) {
+		return &RequestError{Type: ErrorTypeRateLimited, Message: "rate limit exceeded", Cause: err}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return &RequestError{Type: ErrorTypeCanceled, Cause: err}
+	}
+
 	var netErr net.Error
 	if errors.As(err, &netErr) {
 		if netErr.Timeout() {
@@ -91,6 +137,10 @@ func ClassifyError(err error, statusCode int) *RequestError {
 	return &RequestError{Type: ErrorTypeUnknown, Cause: err}
 }
 
+// IsRetryableError reports whether retrying err may succeed. Vendor errors
+// other than rate limiting are not retried: quotas, credentials and symbols
+// do not change within a backoff, and maintenance outlasts it, so the
+// manager falls back to the next provider instead.
 func IsRetryableError(err error) bool {
 	if err == nil {
 		return false
@@ -105,6 +155,17 @@ func IsRetryableError(err error) bool {
 	}
 }
 
+// IsRequestSpecific reports whether err concerns only the request, such as
+// an unknown symbol or a range without data, rather than the health of the
+// provider that reported it.
+func IsRequestSpecific(err error) bool {
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+	return reqErr.Type == ErrorTypeInvalidSymbol || reqErr.Type == ErrorTypeNoData
+}
+
 func NewError(typ ErrorType, message string, cause error) *RequestError {
 	return &RequestError{
 		Type:    typ,
@@ -121,3 +182,14 @@ func NewErrorWithStatus(typ ErrorType, message string, statusCode int, cause err
 		Cause:      cause,
 	}
 }
+
+// NewVendorError returns an error vendor reported in a response body under
+// its own code, which may be empty.
+func NewVendorError(vendor string, typ ErrorType, code, message string) *RequestError {
+	return &RequestError{
+		Type:    typ,
+		Message: message,
+		Vendor:  vendor,
+		Code:    code,
+	}
+}
@@ -1,8 +1,13 @@
 package request
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"testing"
+
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
 )
 
 func TestClassifyError(t *testing.T) {
@@ -30,6 +35,12 @@ func TestClassifyError(t *testing.T) {
 			statusCode: 429,
 			wantType:   ErrorTypeRateLimited,
 		},
+		{
+			name:       "rate limiter exceeded",
+			err:        ratelimiter.ErrExceeded,
+			statusCode: 0,
+			wantType:   ErrorTypeRateLimited,
+		},
 		{
 			name:       "nil error with 401 status",
 			err:        nil,
@@ -66,6 +77,12 @@ func TestClassifyError(t *testing.T) {
 			statusCode: 0,
 			wantType:   ErrorTypeTimeout,
 		},
+		{
+			name:       "context canceled",
+			err:        &url.Error{Op: "Get", URL: "http://example.com", Err: context.Canceled},
+			statusCode: 0,
+			wantType:   ErrorTypeCanceled,
+		},
 		{
 			name:       "unknown error",
 			err:        errors.New("some random error"),
@@ -115,11 +132,31 @@ func TestIsRetryableError(t *testing.T) {
 			err:       &netOpError{},
 			retryable: true,
 		},
+		{
+			name:      "canceled error",
+			err:       context.Canceled,
+			retryable: false,
+		},
 		{
 			name:      "unknown error",
 			err:       errors.New("unknown"),
 			retryable: false,
 		},
+		{
+			name:      "vendor rate limit",
+			err:       NewVendorError("alphavantage", ErrorTypeRateLimited, "", "call frequency exceeded"),
+			retryable: true,
+		},
+		{
+			name:      "vendor quota",
+			err:       NewVendorError("tushare", ErrorTypeQuota, "40203", "daily limit reached"),
+			retryable: false,
+		},
+		{
+			name:      "vendor no data",
+			err:       NewVendorError("eastmoney", ErrorTypeNoData, "", "data is null"),
+			retryable: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -149,6 +186,23 @@ func TestRequestError(t *testing.T) {
 	}
 }
 
+func TestNewVendorError(t *testing.T) {
+	err := error(NewVendorError("tushare", ErrorTypeAuth, "40101", "invalid token"))
+
+	if got, want := err.Error(), "tushare: invalid token (code 40101)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrAuth) || errors.Is(err, ErrQuota) {
+		t.Errorf("errors.Is() should match ErrAuth only")
+	}
+	if IsRequestSpecific(err) {
+		t.Error("IsRequestSpecific(auth) = true")
+	}
+	if !IsRequestSpecific(fmt.Errorf("fetch: %w", NewVendorError("xueqiu", ErrorTypeInvalidSymbol, "", "unknown symbol"))) {
+		t.Error("IsRequestSpecific(wrapped invalid symbol) = false")
+	}
+}
+
 type netTimeoutError struct{}
 
 func (e *netTimeoutError) Error() string   { return "timeout" }
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+// HostClient sends each request through a ClientImpl dedicated to the
+// request's host, so a failing host only trips its own circuit breaker and
+// exhausts its own retries and rate limit. configFor builds the Config for a
+// host on first use; returning configs that share one RateLimiter enforces a
+// single budget across hosts.
+type HostClient struct {
+	configFor func(host string) *Config
+
+	mu      sync.Mutex
+	clients map[string]*ClientImpl
+}
+
+func NewHostClient(configFor func(host string) *Config) *HostClient {
+	if configFor == nil {
+		configFor = func(string) *Config { return DefaultConfig() }
+	}
+	return &HostClient{
+		configFor: configFor,
+		clients:   make(map[string]*ClientImpl),
+	}
+}
+
+func (c *HostClient) Do(ctx context.Context, req Request) (Response, *Record, error) {
+	return c.client(hostOf(req.URL)).Do(ctx, req)
+}
+
+func (c *HostClient) client(host string) *ClientImpl {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	client, ok := c.clients[host]
+	if !ok {
+		client = NewClient(c.configFor(host))
+		c.clients[host] = client
+	}
+	return client
+}
+
+func (c *HostClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for host, client := range c.clients {
+		client.Close()
+		delete(c.clients, host)
+	}
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+var _ Client = (*HostClient)(nil)
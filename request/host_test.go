@@ -0,0 +1,41 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostClient_IsolatesHosts(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	a := httptest.NewServer(handler)
+	defer a.Close()
+	b := httptest.NewServer(handler)
+	defer b.Close()
+
+	var built atomic.Int32
+	client := NewHostClient(func(host string) *Config {
+		built.Add(1)
+		return &Config{RateLimiter: BurstyRateLimiter(1, time.Hour, 0)}
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, _, err := client.Do(ctx, Request{Method: "GET", URL: a.URL + "/x"}); err != nil {
+		t.Fatalf("Do(a) error = %v", err)
+	}
+	if _, record, _ := client.Do(ctx, Request{Method: "GET", URL: a.URL + "/y"}); record.Error == nil || record.Error.Type != ErrorTypeRateLimited {
+		t.Errorf("second Do(a) error = %v, want %s", record.Error, ErrorTypeRateLimited)
+	}
+	if _, _, err := client.Do(ctx, Request{Method: "GET", URL: b.URL}); err != nil {
+		t.Errorf("Do(b) error = %v, want its own budget", err)
+	}
+	if got := built.Load(); got != 2 {
+		t.Errorf("configs built = %d, want 2", got)
+	}
+}
@@ -0,0 +1,292 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExhausted is returned when a host reported its rate limit budget
+// as spent and it does not refill within DefaultRateLimitWait or before the
+// request's deadline.
+var ErrBudgetExhausted = errors.New("host rate limit budget exhausted")
+
+// Record tags describing the rate limit budget of the request's host, set
+// when the host reports one in its response headers.
+const (
+	TagRateLimitHost       = "ratelimit.host"
+	TagRateLimitLimit      = "ratelimit.limit"
+	TagRateLimitRemaining  = "ratelimit.remaining"
+	TagRateLimitUsed       = "ratelimit.used"
+	TagRateLimitReset      = "ratelimit.reset"
+	TagRateLimitRetryAfter = "ratelimit.retry_after"
+)
+
+// RateLimit is a host's rate limit budget as reported by its response
+// headers:
+//
+//   - Retry-After, in seconds or as an HTTP date
+//   - X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Used and
+//     X-RateLimit-Reset (Unix seconds or seconds from now), as sent by
+//     finnhub and polygon
+//   - X-MBX-USED-WEIGHT-<interval>, the weight Binance counted in the current
+//     interval, e.g. X-MBX-USED-WEIGHT-1M
+type RateLimit struct {
+	Host       string
+	Limit      int           // budget per window, 0 when unknown
+	Remaining  int           // -1 when unknown
+	Used       int           // -1 when unknown
+	Reset      time.Time     // when the budget refills, zero when unknown
+	RetryAfter time.Duration // how long the host asked clients to back off
+}
+
+// ParseRateLimit reads the rate limit headers of a response received at now.
+// usageLimit is the budget per window for hosts that only report usage, such
+// as Binance; 0 leaves Limit and Remaining unknown for them. ok is false when
+// headers carry no rate limit information.
+func ParseRateLimit(headers map[string]string, usageLimit int, now time.Time) (rl RateLimit, ok bool) {
+	rl = RateLimit{Remaining: -1, Used: -1}
+	var usageWindow time.Duration
+	for k, v := range headers {
+		key := strings.ToLower(k)
+		v = strings.TrimSpace(v)
+		switch {
+		case key == "retry-after":
+			if secs, err := strconv.Atoi(v); err == nil {
+				rl.RetryAfter = time.Duration(secs) * time.Second
+			} else if t, err := http.ParseTime(v); err == nil && t.After(now) {
+				rl.RetryAfter = t.Sub(now)
+			} else {
+				continue
+			}
+		case key == "x-ratelimit-limit":
+			if !parseInt(v, &rl.Limit) {
+				continue
+			}
+		case key == "x-ratelimit-remaining":
+			if !parseInt(v, &rl.Remaining) {
+				continue
+			}
+		case key == "x-ratelimit-used":
+			if !parseInt(v, &rl.Used) {
+				continue
+			}
+		case key == "x-ratelimit-reset":
+			reset, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			if reset >= 1e9 {
+				rl.Reset = time.Unix(int64(reset), 0)
+			} else {
+				rl.Reset = now.Add(time.Duration(reset * float64(time.Second)))
+			}
+		case strings.HasPrefix(key, "x-mbx-used-weight-"):
+			window, err := parseInterval(strings.TrimPrefix(key, "x-mbx-used-weight-"))
+			if err != nil || (usageWindow != 0 && window >= usageWindow) || !parseInt(v, &rl.Used) {
+				continue
+			}
+			usageWindow = window
+			rl.Reset = now.Truncate(window).Add(window)
+			if usageLimit > 0 {
+				rl.Limit = usageLimit
+				rl.Remaining = -1
+			}
+		default:
+			continue
+		}
+		ok = true
+	}
+	if rl.Limit > 0 {
+		if rl.Remaining < 0 && rl.Used >= 0 {
+			rl.Remaining = max(rl.Limit-rl.Used, 0)
+		}
+		if rl.Used < 0 && rl.Remaining >= 0 {
+			rl.Used = rl.Limit - rl.Remaining
+		}
+	}
+	return rl, ok
+}
+
+func parseInt(s string, v *int) bool {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return false
+	}
+	*v = n
+	return true
+}
+
+// parseInterval parses Binance interval suffixes such as 1m, 10s or 1d.
+func parseInterval(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, errors.New("invalid interval")
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, errors.New("invalid interval")
+	}
+	unit := map[byte]time.Duration{'s': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour}[s[len(s)-1]]
+	if unit == 0 {
+		return 0, errors.New("invalid interval")
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// Tags returns the budget as record tags.
+func (rl RateLimit) Tags() map[string]string {
+	tags := map[string]string{TagRateLimitHost: rl.Host}
+	if rl.Limit > 0 {
+		tags[TagRateLimitLimit] = strconv.Itoa(rl.Limit)
+	}
+	if rl.Remaining >= 0 {
+		tags[TagRateLimitRemaining] = strconv.Itoa(rl.Remaining)
+	}
+	if rl.Used >= 0 {
+		tags[TagRateLimitUsed] = strconv.Itoa(rl.Used)
+	}
+	if !rl.Reset.IsZero() {
+		tags[TagRateLimitReset] = rl.Reset.Format(time.RFC3339)
+	}
+	if rl.RetryAfter > 0 {
+		tags[TagRateLimitRetryAfter] = rl.RetryAfter.String()
+	}
+	return tags
+}
+
+// budgets tracks the rate limit budget of each host a client talks to, so
+// requests wait for the budget to refill instead of being rejected. The
+// weight of requests still in flight is taken from the last reported budget,
+// as the host may not have counted them yet.
+type budgets struct {
+	usageLimit int
+
+	mu       sync.Mutex
+	hosts    map[string]*hostBudget
+	inFlight map[string]int // weight sent to each host and not answered yet
+}
+
+type hostBudget struct {
+	RateLimit
+	retryUntil time.Time
+}
+
+func newBudgets(usageLimit int) *budgets {
+	return &budgets{usageLimit: usageLimit, hosts: make(map[string]*hostBudget), inFlight: make(map[string]int)}
+}
+
+// update completes a request of weight reserved on host and records the
+// budget its response reported. Without a reported budget the request is
+// counted against the known one. Responses of concurrent requests may arrive
+// out of order, so within a window the lowest reported budget is kept.
+// headers is nil when the request failed without a response.
+func (b *budgets) update(host string, weight int, headers map[string]string, now time.Time) (RateLimit, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done(host, weight)
+
+	old, known := b.hosts[host]
+	rl, ok := ParseRateLimit(headers, b.usageLimit, now)
+	if !ok {
+		if known && old.Remaining >= 0 {
+			old.Remaining = max(old.Remaining-weight, 0)
+			if old.Used >= 0 {
+				old.Used += weight
+			}
+		}
+		return RateLimit{}, false
+	}
+	rl.Host = host
+	hb := &hostBudget{RateLimit: rl}
+	if rl.RetryAfter > 0 {
+		hb.retryUntil = now.Add(rl.RetryAfter)
+	}
+	if known && old.Remaining >= 0 && rl.Remaining > old.Remaining && now.Before(old.Reset) {
+		hb.Remaining, hb.Used = old.Remaining, old.Used
+	}
+	b.hosts[host] = hb
+	return rl, true
+}
+
+// release returns the weight of a request reserved on host but not sent.
+func (b *budgets) release(host string, weight int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done(host, weight)
+}
+
+// done takes weight out of flight. The caller must hold the lock.
+func (b *budgets) done(host string, weight int) {
+	b.inFlight[host] -= weight
+	if b.inFlight[host] <= 0 {
+		delete(b.inFlight, host)
+	}
+}
+
+// get returns the last budget host reported.
+func (b *budgets) get(host string) (RateLimit, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hb, ok := b.hosts[host]
+	if !ok {
+		return RateLimit{}, false
+	}
+	return hb.RateLimit, true
+}
+
+// reserve returns how long to wait before sending a request of weight to
+// host: until the Retry-After period ends, or until the window resets when
+// the remaining budget less the weight in flight cannot cover weight. When
+// no wait is needed, weight is taken until update or release returns it.
+func (b *budgets) reserve(host string, weight int, now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if hb, ok := b.hosts[host]; ok {
+		if now.Before(hb.retryUntil) {
+			return hb.retryUntil.Sub(now)
+		}
+		if hb.Remaining >= 0 && !hb.Reset.IsZero() {
+			if now.After(hb.Reset) {
+				// the window has refilled; wait for the next response to learn the new budget
+				hb.Remaining, hb.Used, hb.Reset = -1, -1, time.Time{}
+			} else if hb.Remaining-b.inFlight[host] < weight {
+				return hb.Reset.Sub(now)
+			}
+		}
+	}
+	b.inFlight[host] += weight
+	return 0
+}
+
+// throttle waits until host's budget allows a request of weight and reserves
+// it, failing with ErrBudgetExhausted when that takes longer than
+// DefaultRateLimitWait or outlasts ctx.
+func (b *budgets) throttle(ctx context.Context, host string, weight int) error {
+	for {
+		now := time.Now()
+		wait := b.reserve(host, weight, now)
+		if wait <= 0 {
+			return nil
+		}
+		if wait > DefaultRateLimitWait || outlasts(ctx, wait) {
+			return &RequestError{Type: ErrorTypeRateLimited, Message: "rate limited", RetryAfter: wait, Reset: now.Add(wait), Cause: ErrBudgetExhausted}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// outlasts reports whether waiting d would run past ctx's deadline.
+func outlasts(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < d
+}
@@ -0,0 +1,229 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 30, 0, time.UTC)
+
+	rl, ok := ParseRateLimit(map[string]string{
+		"X-Ratelimit-Limit":     "60",
+		"X-Ratelimit-Remaining": "12",
+		"X-Ratelimit-Reset":     strconv.FormatInt(now.Add(20*time.Second).Unix(), 10),
+		"Content-Type":          "application/json",
+	}, 0, now)
+	if !ok || rl.Limit != 60 || rl.Remaining != 12 || rl.Used != 48 || !rl.Reset.Equal(now.Add(20*time.Second)) {
+		t.Errorf("finnhub headers = %+v, %v", rl, ok)
+	}
+
+	rl, ok = ParseRateLimit(map[string]string{
+		"X-Mbx-Used-Weight":    "1500",
+		"X-Mbx-Used-Weight-1m": "1500",
+		"X-Mbx-Used-Weight-1d": "90000",
+	}, 6000, now)
+	if !ok || rl.Used != 1500 || rl.Limit != 6000 || rl.Remaining != 4500 || !rl.Reset.Equal(now.Truncate(time.Minute).Add(time.Minute)) {
+		t.Errorf("binance headers = %+v, %v", rl, ok)
+	}
+
+	rl, _ = ParseRateLimit(map[string]string{"Retry-After": "7"}, 0, now)
+	if rl.RetryAfter != 7*time.Second || rl.Remaining != -1 {
+		t.Errorf("Retry-After seconds = %+v", rl)
+	}
+	rl, _ = ParseRateLimit(map[string]string{"Retry-After": now.Add(time.Minute).Format(http.TimeFormat)}, 0, now)
+	if rl.RetryAfter != time.Minute {
+		t.Errorf("Retry-After date = %v, want 1m", rl.RetryAfter)
+	}
+
+	if _, ok := ParseRateLimit(map[string]string{"Retry-After": "soon", "Content-Length": "10"}, 0, now); ok {
+		t.Error("ParseRateLimit() ok for headers without rate limit information")
+	}
+}
+
+func TestBudgets_Reserve(t *testing.T) {
+	now := time.Now()
+	b := newBudgets(0)
+	b.update("api.example.com", 0, map[string]string{
+		"X-Ratelimit-Limit":     "10",
+		"X-Ratelimit-Remaining": "2",
+		"X-Ratelimit-Reset":     "30",
+	}, now)
+
+	if wait := b.reserve("api.example.com", 2, now); wait != 0 {
+		t.Errorf("reserve within budget waited %v", wait)
+	}
+	if wait := b.reserve("api.example.com", 1, now); wait != 30*time.Second {
+		t.Errorf("reserve beyond budget = %v, want until reset", wait)
+	}
+	if wait := b.reserve("other.example.com", 1, now); wait != 0 {
+		t.Errorf("reserve on unknown host waited %v", wait)
+	}
+	if wait := b.reserve("api.example.com", 1, now.Add(time.Minute)); wait != 0 {
+		t.Errorf("reserve after reset waited %v", wait)
+	}
+}
+
+func TestBudgets_InFlight(t *testing.T) {
+	now := time.Now()
+	b := newBudgets(0)
+	headers := func(remaining string) map[string]string {
+		return map[string]string{
+			"X-Ratelimit-Limit":     "10",
+			"X-Ratelimit-Remaining": remaining,
+			"X-Ratelimit-Reset":     "30",
+		}
+	}
+	b.update("api.example.com", 0, headers("3"), now)
+
+	for i := 0; i < 3; i++ {
+		if wait := b.reserve("api.example.com", 1, now); wait != 0 {
+			t.Fatalf("reserve %d within budget waited %v", i, wait)
+		}
+	}
+	// the first response reports the budget before the others were counted
+	b.update("api.example.com", 1, headers("2"), now)
+	if wait := b.reserve("api.example.com", 1, now); wait == 0 {
+		t.Error("reserve ignored the requests still in flight")
+	}
+	// a late response with a higher budget does not raise it again
+	b.update("api.example.com", 1, headers("0"), now)
+	b.update("api.example.com", 1, headers("1"), now)
+	if rl, _ := b.get("api.example.com"); rl.Remaining != 0 {
+		t.Errorf("Remaining = %d after an out of order response, want 0", rl.Remaining)
+	}
+
+	b.release("api.example.com", 1)
+	if len(b.inFlight) != 0 {
+		t.Errorf("inFlight = %v after all requests completed", b.inFlight)
+	}
+}
+
+func TestClient_Do_ConcurrentBudget(t *testing.T) {
+	const limit = 10
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("X-Ratelimit-Limit", strconv.Itoa(limit))
+		w.Header().Set("X-Ratelimit-Remaining", strconv.Itoa(max(limit-int(n), 0)))
+		w.Header().Set("X-Ratelimit-Reset", "3600")
+		// earlier requests answer later, so their budget arrives stale
+		time.Sleep(time.Duration(limit-int(n)) * 20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(DefaultConfig(WithRateLimiter(nil)))
+	defer client.Close()
+
+	if _, _, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 3*limit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Do(context.Background(), Request{Method: "GET", URL: ts.URL})
+		}()
+		time.Sleep(10 * time.Millisecond)
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n > limit {
+		t.Errorf("server calls = %d, want the budget of %d to hold under concurrency", n, limit)
+	}
+}
+
+func TestClient_Do_RetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-Ratelimit-Limit", "60")
+		w.Header().Set("X-Ratelimit-Remaining", "59")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(DefaultConfig())
+	defer client.Close()
+
+	start := time.Now()
+	_, record, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the 1s Retry-After", elapsed)
+	}
+	if record.Attempt != 2 {
+		t.Errorf("Attempt = %d, want 2", record.Attempt)
+	}
+	if record.Tags[TagRateLimitRemaining] != "59" || record.RateLimit == nil || record.RateLimit.Limit != 60 {
+		t.Errorf("Tags = %v, RateLimit = %+v", record.Tags, record.RateLimit)
+	}
+}
+
+func TestClient_Do_RetryAfterPastDeadline(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	client := NewClient(DefaultConfig(WithDeadline(5 * time.Second)))
+	defer client.Close()
+
+	start := time.Now()
+	_, record, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do() took %v, want it to fail without waiting", elapsed)
+	}
+	if !errors.Is(err, ErrRateLimited) || !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("Do() error = %v, want an exhausted rate limit", err)
+	}
+	if reset := record.Error.Reset; reset.Before(start.Add(19*time.Second)) || reset.After(time.Now().Add(20*time.Second)) {
+		t.Errorf("Reset = %v, want about 20s from now", reset)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestClient_Do_BudgetExhausted(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-Ratelimit-Remaining", "0")
+		w.Header().Set("X-Ratelimit-Reset", "3600")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(DefaultConfig())
+	defer client.Close()
+
+	if _, _, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_, record, err := client.Do(context.Background(), Request{Method: "GET", URL: ts.URL})
+	if err == nil || record.Error.Type != ErrorTypeRateLimited {
+		t.Fatalf("Do() error = %v, want %s", err, ErrorTypeRateLimited)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server calls = %d, want the exhausted budget to stop the second request", calls.Load())
+	}
+}
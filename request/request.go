@@ -11,6 +11,11 @@ type Request struct {
 	URL     string
 	Headers map[string]string
 	Body    []byte
+	// Check returns the error a vendor reports in the response body, for
+	// vendors that answer failures with 200. It runs within each attempt,
+	// so the error is retried and recorded like a transport error. Check
+	// returns nil for bodies it cannot decode, leaving that to the caller.
+	Check func(Response) error `json:"-"`
 }
 
 type Response struct {
@@ -29,6 +34,9 @@ type Record struct {
 	Attempt   int
 	FromCache bool
 	Tags      map[string]string
+	// RateLimit is the budget the host last reported, nil when it reports
+	// none. It is also exposed as the ratelimit.* Tags.
+	RateLimit *RateLimit
 }
 
 func (r *Record) IsSuccess() bool {
@@ -39,6 +47,19 @@ func (r *Record) IsError() bool {
 	return r.Error != nil || r.Response.StatusCode >= 400
 }
 
+// Clone returns a copy of the record with its own Tags map.
+func (r *Record) Clone() *Record {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	cp.Tags = make(map[string]string, len(r.Tags)+1)
+	for k, v := range r.Tags {
+		cp.Tags[k] = v
+	}
+	return &cp
+}
+
 func (r *Record) ToJSON() ([]byte, error) {
 	return json.Marshal(r)
 }
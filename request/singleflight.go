@@ -0,0 +1,114 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var errFlightPanicked = errors.New("coalesced call panicked")
+
+// TagCoalesced is set to "true" on the records handed to callers whose call
+// was coalesced into an identical in-flight one.
+const TagCoalesced = "coalesced"
+
+// SingleFlight coalesces concurrent calls sharing a key into one execution.
+// The zero value is ready to use.
+//
+// The shared execution runs detached from the callers' contexts: each caller
+// stops waiting when its own context ends, and the execution is cancelled
+// only once every caller has stopped waiting. A caller cancelling therefore
+// never fails the others.
+type SingleFlight[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+type flightCall[T any] struct {
+	done     chan struct{}
+	val      T
+	err      error
+	panicked any
+
+	waiters int // guarded by SingleFlight.mu
+	cancel  context.CancelFunc
+}
+
+// Do runs fn once for all concurrent callers with the same key. fn gets a
+// context carrying the values of the first caller's ctx. coalesced reports
+// whether the caller received the result of another caller's call. An empty
+// key disables coalescing.
+func (g *SingleFlight[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (val T, err error, coalesced bool) {
+	if key == "" {
+		val, err = fn(ctx)
+		return val, err, false
+	}
+
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall[T])
+	}
+	call, coalesced := g.calls[key]
+	if !coalesced {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall[T]{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go g.run(callCtx, key, call, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.panicked != nil {
+			if !coalesced {
+				panic(call.panicked)
+			}
+			return val, errFlightPanicked, true
+		}
+		return call.val, call.err, coalesced
+	case <-ctx.Done():
+		g.leave(key, call)
+		return val, ctx.Err(), coalesced
+	}
+}
+
+// leave stops a caller waiting for call, cancelling it when no caller is
+// left. The key is released so later callers start a fresh call.
+func (g *SingleFlight[T]) leave(key string, call *flightCall[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	call.cancel()
+}
+
+func (g *SingleFlight[T]) run(ctx context.Context, key string, call *flightCall[T], fn func(context.Context) (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.panicked = r
+		}
+		g.mu.Lock()
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		call.cancel()
+		close(call.done)
+	}()
+	call.val, call.err = fn(ctx)
+}
+
+// CoalescedRecord returns a copy of record tagged as coalesced.
+func CoalescedRecord(record *Record) *Record {
+	cp := record.Clone()
+	if cp != nil {
+		cp.Tags[TagCoalesced] = "true"
+	}
+	return cp
+}